@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"tender-workspace/config"
+	"tender-workspace/services/postgres"
+	"tender-workspace/services/postgres/migrations"
+	"text/tabwriter"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const usage = `usage: migrate [-config path] <command> [steps]
+
+commands:
+  up [N]      apply N pending migrations (all by default)
+  down [N]    roll back N last applied migrations (1 by default)
+  status      print state of every known migration
+`
+
+func main() {
+	configPath := flag.String("config", "./config/config.yaml", "path to configuration file")
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+	if flag.NArg() == 0 || flag.NArg() > 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	var steps int
+	if flag.NArg() == 2 {
+		n, err := strconv.Atoi(flag.Arg(1))
+		if err != nil || n <= 0 {
+			fmt.Fprintf(os.Stderr, "steps must be positive integer, got %q\n", flag.Arg(1))
+			os.Exit(2)
+		}
+		steps = n
+	}
+
+	logger := zap.Must(zap.NewProduction())
+	config.Read(*configPath, logger)
+	psqlPool := postgres.Init(logger)
+	defer psqlPool.Close()
+
+	migrator, err := migrations.NewMigrator(psqlPool, logger)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("error while loading migrations: %v", err))
+	}
+	ctx := context.Background()
+	switch flag.Arg(0) {
+	case "up":
+		count, err := migrator.Up(ctx, steps)
+		if err != nil {
+			logger.Fatal(fmt.Sprintf("error while applying migrations: %v", err))
+		}
+		fmt.Printf("applied %d migrations\n", count)
+	case "down":
+		count, err := migrator.Down(ctx, steps)
+		if err != nil {
+			logger.Fatal(fmt.Sprintf("error while rolling back migrations: %v", err))
+		}
+		fmt.Printf("rolled back %d migrations\n", count)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		printStatus(statuses)
+		if err != nil {
+			logger.Fatal(fmt.Sprintf("error while getting migrations status: %v", err))
+		}
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+func printStatus(statuses []migrations.MigrationStatus) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.Applied {
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%06d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+	}
+	w.Flush()
+}
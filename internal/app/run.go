@@ -7,8 +7,13 @@ import (
 	"os"
 	"os/signal"
 	"tender-workspace/internal/delivery/route"
+	"tender-workspace/internal/scheduler"
 	f "tender-workspace/internal/utils/functions"
+	"tender-workspace/internal/utils/token"
+	"tender-workspace/internal/utils/tracing"
+	"tender-workspace/services/blob"
 	"tender-workspace/services/postgres"
+	"tender-workspace/services/postgres/migrations"
 
 	"github.com/gorilla/mux"
 	"github.com/spf13/viper"
@@ -16,11 +21,30 @@ import (
 )
 
 func Run(logger *zap.Logger) {
-	psqlConn := postgres.Init(logger)
+	if err := token.CheckSecret(viper.GetString("JWT_SECRET")); err != nil {
+		logger.Fatal(err.Error())
+	}
+	shutdownTracing, err := tracing.Init(logger)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("error while initializing tracing: %v", err))
+	}
+	psqlPool, err := postgres.New(logger)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("error while parsing PSQL pool config: %v", err))
+	}
+	migrator, err := migrations.NewMigrator(psqlPool, logger)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("error while loading migrations: %v", err))
+	}
+
+	storage, err := blob.New(logger)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("error while initializing blob storage: %v", err))
+	}
 
 	f.InitDtoValidator(logger)
 	r := mux.NewRouter()
-	handler := route.InitHTTPHandlers(r, psqlConn, logger)
+	handler := route.InitHTTPHandlers(r, psqlPool, storage, migrator, logger)
 
 	srv := &http.Server{
 		Handler:      handler,
@@ -37,13 +61,33 @@ func Run(logger *zap.Logger) {
 		}
 	}()
 
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt)
-	<-c
+	sigCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	// сервер уже отвечает на liveness probe, readiness станет успешной после подключения к PSQL и миграций
+	err = postgres.WaitForConnection(sigCtx, psqlPool, logger)
+	if err == nil && viper.GetBool("MIGRATE_ON_START") {
+		applyMigrations(sigCtx, migrator, logger)
+	}
+	// планировщик работает только с готовой схемой и завершается раньше, чем закрывается пул
+	schedulerDone := make(chan struct{})
+	if err == nil && sigCtx.Err() == nil {
+		go func() {
+			defer close(schedulerDone)
+			scheduler.InitDeadlines(psqlPool, logger).Run(sigCtx)
+		}()
+	} else {
+		close(schedulerDone)
+	}
+	<-sigCtx.Done()
+	stop()
+	<-schedulerDone
 
 	ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("SERVER_SHUTDOWN_DURATION"))
 	defer cancel()
-	err := srv.Shutdown(ctx)
+	err = srv.Shutdown(ctx)
+	psqlPool.Close()
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Warn(fmt.Sprintf("error while flushing spans: %v", err))
+	}
 	if err != nil {
 		logger.Error(fmt.Sprintf("server urgently has shut down with an error: %v", err))
 		os.Exit(1)
@@ -51,3 +95,16 @@ func Run(logger *zap.Logger) {
 	logger.Info("server has shut down")
 	os.Exit(0)
 }
+
+// applyMigrations применяет все недостающие миграции, до их окончания сервис не готов принимать трафик
+func applyMigrations(ctx context.Context, migrator *migrations.Migrator, logger *zap.Logger) {
+	count, err := migrator.Up(ctx, 0)
+	if err != nil && ctx.Err() != nil {
+		logger.Warn(fmt.Sprintf("migrations were interrupted: %v", err))
+		return
+	}
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("error while applying migrations: %v", err))
+	}
+	logger.Info(fmt.Sprintf("schema is up to date, applied %d migrations", count))
+}
@@ -0,0 +1,279 @@
+package attachment
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	ent "tender-workspace/internal/entity"
+	"tender-workspace/internal/entity/dto"
+	bqp "tender-workspace/internal/entity/dto/queries/bids"
+	tqp "tender-workspace/internal/entity/dto/queries/tenders"
+	"tender-workspace/internal/usecase/attachment"
+	f "tender-workspace/internal/utils/functions"
+	mc "tender-workspace/internal/utils/myconstants"
+	e "tender-workspace/internal/utils/myerrors"
+
+	"go.uber.org/zap"
+)
+
+// maxMultipartOverhead запас на заголовки и границы multipart сверх размера файла
+const maxMultipartOverhead = 1 << 20
+
+type DeliveryLayer struct {
+	ucAttachment attachment.Usecase
+	logger       *zap.Logger
+}
+
+func NewDeliveryLayer(ucAttachment attachment.Usecase, logger *zap.Logger) *DeliveryLayer {
+	return &DeliveryLayer{
+		ucAttachment: ucAttachment,
+		logger:       logger,
+	}
+}
+
+func (d *DeliveryLayer) UploadTenderAttachment(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
+	if r.Method != "POST" {
+		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
+		return
+	}
+
+	queryParams := new(tqp.TenderAttachments)
+	err := queryParams.GetParameters(r)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	input, err := readAttachment(w, r)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	a, err := d.ucAttachment.UploadTenderAttachment(r.Context(), input, queryParams)
+	if err != nil {
+		err = uploadError(err)
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	attachmentOutput := dto.NewAttachmentOutput(a)
+	responseData := f.NewResponseProps(w, attachmentOutput, http.StatusOK, mc.ApplicationJson)
+	f.Response(responseData)
+}
+
+func (d *DeliveryLayer) GetTenderAttachments(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
+	if r.Method != "GET" {
+		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
+		return
+	}
+
+	queryParams := new(tqp.TenderAttachments)
+	err := queryParams.GetParameters(r)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	attachments, err := d.ucAttachment.GetTenderAttachments(r.Context(), queryParams)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	attachmentsOutput := dto.NewArrayAttachmentOutput(attachments)
+	responseData := f.NewResponseProps(w, attachmentsOutput, http.StatusOK, mc.ApplicationJson)
+	f.Response(responseData)
+}
+
+func (d *DeliveryLayer) DownloadTenderAttachment(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
+	if r.Method != "GET" {
+		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
+		return
+	}
+
+	queryParams := new(tqp.TenderAttachment)
+	err := queryParams.GetParameters(r)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	a, content, err := d.ucAttachment.DownloadTenderAttachment(r.Context(), queryParams)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+	defer content.Close()
+
+	if err := writeAttachment(w, a, content); err != nil {
+		d.logger.Warn(fmt.Sprintf("error while sending attachment: %v", err), zap.String(mc.RequestID, requestId))
+	}
+}
+
+func (d *DeliveryLayer) UploadBidAttachment(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
+	if r.Method != "POST" {
+		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
+		return
+	}
+
+	queryParams := new(bqp.BidAttachments)
+	err := queryParams.GetParameters(r)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	input, err := readAttachment(w, r)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	a, err := d.ucAttachment.UploadBidAttachment(r.Context(), input, queryParams)
+	if err != nil {
+		err = uploadError(err)
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	attachmentOutput := dto.NewAttachmentOutput(a)
+	responseData := f.NewResponseProps(w, attachmentOutput, http.StatusOK, mc.ApplicationJson)
+	f.Response(responseData)
+}
+
+func (d *DeliveryLayer) GetBidAttachments(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
+	if r.Method != "GET" {
+		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
+		return
+	}
+
+	queryParams := new(bqp.BidAttachments)
+	err := queryParams.GetParameters(r)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	attachments, err := d.ucAttachment.GetBidAttachments(r.Context(), queryParams)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	attachmentsOutput := dto.NewArrayAttachmentOutput(attachments)
+	responseData := f.NewResponseProps(w, attachmentsOutput, http.StatusOK, mc.ApplicationJson)
+	f.Response(responseData)
+}
+
+func (d *DeliveryLayer) DownloadBidAttachment(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
+	if r.Method != "GET" {
+		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
+		return
+	}
+
+	queryParams := new(bqp.BidAttachment)
+	err := queryParams.GetParameters(r)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	a, content, err := d.ucAttachment.DownloadBidAttachment(r.Context(), queryParams)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+	defer content.Close()
+
+	if err := writeAttachment(w, a, content); err != nil {
+		d.logger.Warn(fmt.Sprintf("error while sending attachment: %v", err), zap.String(mc.RequestID, requestId))
+	}
+}
+
+// readAttachment
+// Finds the part 'file' of the multipart body, its content is read by the usecase
+// while the request is being handled.
+func readAttachment(w http.ResponseWriter, r *http.Request) (*dto.AttachmentInput, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, mc.MaxAttachmentSize+maxMultipartOverhead)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, e.ErrAttachment
+	}
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			if uploadErr := uploadError(err); uploadErr == e.ErrAttachmentSize {
+				return nil, uploadErr
+			}
+			return nil, e.ErrAttachment
+		}
+		if part.FormName() != "file" {
+			continue
+		}
+		return &dto.AttachmentInput{
+			Name:        part.FileName(),
+			ContentType: part.Header.Get("Content-Type"),
+			Content:     part,
+		}, nil
+	}
+}
+
+// uploadError заменяет ошибку чтения слишком большого тела на ошибку размера файла
+func uploadError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return e.ErrAttachmentSize
+	}
+	return err
+}
+
+// writeAttachment
+// Sends the file as download with its SHA-256 digest, so clients can check the content.
+func writeAttachment(w http.ResponseWriter, a *ent.Attachment, content io.Reader) error {
+	w.Header().Set("Content-Type", a.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(a.Size, 10))
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": a.Name})
+	if disposition == "" {
+		disposition = "attachment"
+	}
+	w.Header().Set("Content-Disposition", disposition)
+	w.Header().Set("ETag", `"`+a.SHA256+`"`)
+	if hash, err := hex.DecodeString(a.SHA256); err == nil {
+		w.Header().Set("Content-Digest", "sha-256=:"+base64.StdEncoding.EncodeToString(hash)+":")
+	}
+	w.WriteHeader(http.StatusOK)
+	_, err := io.Copy(w, content)
+	return err
+}
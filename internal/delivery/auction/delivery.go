@@ -0,0 +1,99 @@
+package auction
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"tender-workspace/internal/entity/dto"
+	bqp "tender-workspace/internal/entity/dto/queries/bids"
+	tqp "tender-workspace/internal/entity/dto/queries/tenders"
+	"tender-workspace/internal/usecase/auction"
+	f "tender-workspace/internal/utils/functions"
+	mc "tender-workspace/internal/utils/myconstants"
+	e "tender-workspace/internal/utils/myerrors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type DeliveryLayer struct {
+	ucAuction auction.Usecase
+	logger    *zap.Logger
+}
+
+func NewDeliveryLayer(ucAuction auction.Usecase, logger *zap.Logger) *DeliveryLayer {
+	return &DeliveryLayer{
+		ucAuction: ucAuction,
+		logger:    logger,
+	}
+}
+
+func (d *DeliveryLayer) PlaceOffer(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
+	if r.Method != "POST" {
+		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
+		return
+	}
+
+	queryParams := new(bqp.AuctionOffer)
+	err := queryParams.GetParameters(r)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrRequestBody)
+		return
+	}
+	var offerData dto.AuctionOfferInput
+	err = json.Unmarshal(body, &offerData)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrRequestBody)
+		return
+	}
+
+	ranking, err := d.ucAuction.PlaceOffer(r.Context(), &offerData, queryParams)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	rankingOutput := dto.NewAuctionRankingOutput(ranking, time.Now())
+	responseData := f.NewResponseProps(w, rankingOutput, http.StatusOK, mc.ApplicationJson)
+	f.Response(responseData)
+}
+
+func (d *DeliveryLayer) GetRanking(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
+	if r.Method != "GET" {
+		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
+		return
+	}
+
+	queryParams := new(tqp.AuctionRanking)
+	err := queryParams.GetParameters(r)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	ranking, err := d.ucAuction.GetRanking(r.Context(), queryParams)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	rankingOutput := dto.NewAuctionRankingOutput(ranking, time.Now())
+	responseData := f.NewResponseProps(w, rankingOutput, http.StatusOK, mc.ApplicationJson)
+	f.Response(responseData)
+}
@@ -2,7 +2,6 @@ package bids
 
 import (
 	"encoding/json"
-	"errors"
 	"io"
 	"net/http"
 	ent "tender-workspace/internal/entity"
@@ -32,69 +31,169 @@ func (d *DeliveryLayer) CreateBid(w http.ResponseWriter, r *http.Request) {
 	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
 	if r.Method != "POST" {
 		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrMethodNotAllowed.Error()}, http.StatusMethodNotAllowed, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
 		return
 	}
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrRequestBody.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrRequestBody)
 		return
 	}
 	var bidData dto.BidInput
 	err = json.Unmarshal(body, &bidData)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrRequestBody.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrRequestBody)
 		return
 	}
+	username, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		d.logger.Info(e.ErrUnauthorized.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrUnauthorized)
+		return
+	}
+	bidData.CreatorUsername = username
 	isValid, err := f.Validate(bidData)
 	if err != nil || !isValid {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrRequestBody.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrRequestBody)
+		return
+	}
+	if err = dto.ValidateBidItems(bidData.Items); err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
 		return
 	}
 
 	bid, err := d.ucBids.CreateBid(r.Context(), &bidData)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		if errors.Is(err, e.ErrBadStatusCreate) ||
-			errors.Is(err, e.ErrTenderExist) ||
-			errors.Is(err, e.ErrOrganizationExist) ||
-			errors.Is(err, e.ErrUserAndOrg) ||
-			errors.Is(err, e.ErrUserAlreadyHasBid) ||
-			errors.Is(err, e.ErrOrgAlreadyHasBid) ||
-			errors.Is(err, e.ErrBidYourself) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		if errors.Is(err, e.ErrUserExist) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusUnauthorized, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()}, http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, err)
 		return
 	}
 
 	tenderOutput := dto.NewBidOutput(bid)
+	w.Header().Set("ETag", f.ETag(bid.Version))
 	responseData := f.NewResponseProps(w, tenderOutput, http.StatusOK, mc.ApplicationJson)
 	f.Response(responseData)
 }
 
+func (d *DeliveryLayer) CreateSealedBid(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
+	if r.Method != "POST" {
+		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrRequestBody)
+		return
+	}
+	var bidData dto.SealedBidInput
+	err = json.Unmarshal(body, &bidData)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrRequestBody)
+		return
+	}
+	username, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		d.logger.Info(e.ErrUnauthorized.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrUnauthorized)
+		return
+	}
+	bidData.CreatorUsername = username
+	isValid, err := f.Validate(bidData)
+	if err != nil || !isValid {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrCommitment)
+		return
+	}
+
+	bid, err := d.ucBids.CreateSealedBid(r.Context(), &bidData)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	bidOutput := dto.NewBidOutput(bid)
+	w.Header().Set("ETag", f.ETag(bid.Version))
+	responseData := f.NewResponseProps(w, bidOutput, http.StatusOK, mc.ApplicationJson)
+	f.Response(responseData)
+}
+
+func (d *DeliveryLayer) RevealBid(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
+	if r.Method != "PUT" {
+		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
+		return
+	}
+
+	queryParams := new(bqp.BidReveal)
+	err := queryParams.GetParameters(r)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrRequestBody)
+		return
+	}
+	var revealData dto.BidRevealInput
+	err = json.Unmarshal(body, &revealData)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrRequestBody)
+		return
+	}
+	// content is validated as a usual bid edit
+	err = json.Unmarshal(revealData.Content, &revealData.Data)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrRequestBody)
+		return
+	}
+	isValid, err := f.Validate(revealData.Data)
+	if err != nil || !isValid {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrRequestBody)
+		return
+	}
+	if err = dto.ValidateBidItems(revealData.Data.Items); err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	bid, err := d.ucBids.RevealBid(r.Context(), &revealData, queryParams)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	bidOutput := dto.NewBidOutput(bid)
+	w.Header().Set("ETag", f.ETag(bid.Version))
+	responseData := f.NewResponseProps(w, bidOutput, http.StatusOK, mc.ApplicationJson)
+	f.Response(responseData)
+}
+
 func (d *DeliveryLayer) GetUserBids(w http.ResponseWriter, r *http.Request) {
 	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
 	if r.Method != "GET" {
 		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrMethodNotAllowed.Error()}, http.StatusMethodNotAllowed, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
 		return
 	}
 
@@ -102,33 +201,14 @@ func (d *DeliveryLayer) GetUserBids(w http.ResponseWriter, r *http.Request) {
 	err := queryParams.GetParameters(r)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		if errors.Is(err, e.ErrQPLimit) || errors.Is(err, e.ErrQPOffset) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		if errors.Is(err, e.ErrBadPermission) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusUnauthorized, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()},
-			http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, err)
 		return
 	}
 
-	bids, err := d.ucBids.GetUserBids(r.Context(), queryParams)
+	bids, cursors, err := d.ucBids.GetUserBids(r.Context(), queryParams)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		if errors.Is(err, e.ErrUserExist) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusUnauthorized, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()},
-			http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, err)
 		return
 	}
 	if len(bids) == 0 {
@@ -136,6 +216,7 @@ func (d *DeliveryLayer) GetUserBids(w http.ResponseWriter, r *http.Request) {
 	}
 
 	bidsOutput := dto.NewArrayBidOutput(bids)
+	f.SetCursors(w, cursors)
 	responseData := f.NewResponseProps(w, bidsOutput, http.StatusOK, mc.ApplicationJson)
 	f.Response(responseData)
 }
@@ -144,8 +225,7 @@ func (d *DeliveryLayer) GetTenderListOfBids(w http.ResponseWriter, r *http.Reque
 	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
 	if r.Method != "GET" {
 		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrMethodNotAllowed.Error()}, http.StatusMethodNotAllowed, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
 		return
 	}
 
@@ -153,38 +233,14 @@ func (d *DeliveryLayer) GetTenderListOfBids(w http.ResponseWriter, r *http.Reque
 	err := queryParams.GetParameters(r)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		if errors.Is(err, e.ErrQPLimit) || errors.Is(err, e.ErrQPOffset) || errors.Is(err, e.ErrTenderID) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		if errors.Is(err, e.ErrBadPermission) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusUnauthorized, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()},
-			http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, err)
 		return
 	}
 
-	bids, err := d.ucBids.GetTenderBids(r.Context(), queryParams)
+	bids, cursors, err := d.ucBids.GetTenderBids(r.Context(), queryParams)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		if errors.Is(err, e.ErrTenderExist) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		if errors.Is(err, e.ErrUserExist) || errors.Is(err, e.ErrResponsibilty) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusUnauthorized, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()},
-			http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, err)
 		return
 	}
 	if len(bids) == 0 {
@@ -192,6 +248,7 @@ func (d *DeliveryLayer) GetTenderListOfBids(w http.ResponseWriter, r *http.Reque
 	}
 
 	bidsOutput := dto.NewArrayBidOutput(bids)
+	f.SetCursors(w, cursors)
 	responseData := f.NewResponseProps(w, bidsOutput, http.StatusOK, mc.ApplicationJson)
 	f.Response(responseData)
 }
@@ -203,42 +260,19 @@ func (d *DeliveryLayer) GetBidStatus(w http.ResponseWriter, r *http.Request) {
 	err := queryParams.GetParameters(r)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		if errors.Is(err, e.ErrExistBidID) || errors.Is(err, e.ErrBidID) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		if errors.Is(err, e.ErrBadPermission) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusUnauthorized, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()},
-			http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, err)
 		return
 	}
 
-	status, err := d.ucBids.GetBidStatus(r.Context(), queryParams)
+	bid, err := d.ucBids.GetBidStatus(r.Context(), queryParams)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		if errors.Is(err, e.ErrUserExist) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusUnauthorized, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		if errors.Is(err, e.ErrNoBids) || errors.Is(err, e.ErrBadPermission) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()},
-			http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, err)
 		return
 	}
 
-	responseData := f.NewResponseProps(w, dto.BidStatus{Status: status}, http.StatusOK, mc.ApplicationJson)
+	w.Header().Set("ETag", f.ETag(bid.Version))
+	responseData := f.NewResponseProps(w, dto.BidStatus{Status: bid.Status}, http.StatusOK, mc.ApplicationJson)
 	f.Response(responseData)
 }
 
@@ -249,45 +283,19 @@ func (d *DeliveryLayer) UpdateBidStatus(w http.ResponseWriter, r *http.Request)
 	err := queryParams.GetParameters(r)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		if errors.Is(err, e.ErrExistBidID) ||
-			errors.Is(err, e.ErrBidID) ||
-			errors.Is(err, e.ErrExistStatus) ||
-			errors.Is(err, e.ErrQPBidStatusUpdate) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		if errors.Is(err, e.ErrBadPermission) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusUnauthorized, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()},
-			http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, err)
 		return
 	}
 
 	bid, err := d.ucBids.UpdateBidStatus(r.Context(), queryParams)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		if errors.Is(err, e.ErrUserExist) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusUnauthorized, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		if errors.Is(err, e.ErrNoBids) || errors.Is(err, e.ErrSetDeprecatedStatus) || errors.Is(err, e.ErrBadPermission) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()},
-			http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, err)
 		return
 	}
 
 	bidOutput := dto.NewBidOutput(bid)
+	w.Header().Set("ETag", f.ETag(bid.Version))
 	responseData := f.NewResponseProps(w, bidOutput, http.StatusOK, mc.ApplicationJson)
 	f.Response(responseData)
 }
@@ -296,8 +304,7 @@ func (d *DeliveryLayer) UpdateBid(w http.ResponseWriter, r *http.Request) {
 	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
 	if r.Method != "PATCH" {
 		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrMethodNotAllowed.Error()}, http.StatusMethodNotAllowed, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
 		return
 	}
 
@@ -305,65 +312,44 @@ func (d *DeliveryLayer) UpdateBid(w http.ResponseWriter, r *http.Request) {
 	err := queryParams.GetParameters(r)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		if errors.Is(err, e.ErrExistBidID) || errors.Is(err, e.ErrBidID) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		if errors.Is(err, e.ErrBadPermission) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusUnauthorized, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()},
-			http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, err)
 		return
 	}
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrRequestBody.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrRequestBody)
 		return
 	}
 	var bidData dto.BidUpdateDataInput
 	err = json.Unmarshal(body, &bidData)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrRequestBody.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrRequestBody)
 		return
 	}
 	isValid, err := f.Validate(bidData)
 	if err != nil || !isValid {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrRequestBody.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrRequestBody)
+		return
+	}
+	if err = dto.ValidateBidItems(bidData.Items); err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
 		return
 	}
 
 	bid, err := d.ucBids.UpdateBid(r.Context(), &bidData, queryParams)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		if errors.Is(err, e.ErrUserExist) || errors.Is(err, e.ErrResponsibilty) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusUnauthorized, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		if errors.Is(err, e.ErrNoTenders) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()},
-			http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, err)
 		return
 	}
 
 	bidOutput := dto.NewBidOutput(bid)
+	w.Header().Set("ETag", f.ETag(bid.Version))
 	responseData := f.NewResponseProps(w, bidOutput, http.StatusOK, mc.ApplicationJson)
 	f.Response(responseData)
 }
@@ -372,8 +358,7 @@ func (d *DeliveryLayer) SubmitDecision(w http.ResponseWriter, r *http.Request) {
 	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
 	if r.Method != "PUT" {
 		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrMethodNotAllowed.Error()}, http.StatusMethodNotAllowed, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
 		return
 	}
 
@@ -381,42 +366,104 @@ func (d *DeliveryLayer) SubmitDecision(w http.ResponseWriter, r *http.Request) {
 	err := queryParams.GetParameters(r)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		if errors.Is(err, e.ErrExistBidID) || errors.Is(err, e.ErrBidID) || errors.Is(err, e.ErrQPDecision) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		if errors.Is(err, e.ErrBadPermission) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusUnauthorized, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()},
-			http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	result, err := d.ucBids.SubmitDecision(r.Context(), queryParams)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	decisionOutput := dto.NewBidDecisionOutput(result)
+	w.Header().Set("ETag", f.ETag(result.Bid.Version))
+	responseData := f.NewResponseProps(w, decisionOutput, http.StatusOK, mc.ApplicationJson)
+	f.Response(responseData)
+}
+
+func (d *DeliveryLayer) RollbackBid(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
+	if r.Method != "PUT" {
+		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
+		return
+	}
+
+	queryParams := new(bqp.BidRollback)
+	err := queryParams.GetParameters(r)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
 		return
 	}
 
-	bid, err := d.ucBids.UpdateBidStatus(r.Context(), newUpdateBidStatusParams(queryParams))
+	bid, err := d.ucBids.RollbackBid(r.Context(), queryParams)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		if errors.Is(err, e.ErrUserExist) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusUnauthorized, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		if errors.Is(err, e.ErrNoBids) || errors.Is(err, e.ErrSetDeprecatedStatus) || errors.Is(err, e.ErrBadPermission) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()},
-			http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, err)
 		return
 	}
 
 	bidOutput := dto.NewBidOutput(bid)
+	w.Header().Set("ETag", f.ETag(bid.Version))
 	responseData := f.NewResponseProps(w, bidOutput, http.StatusOK, mc.ApplicationJson)
 	f.Response(responseData)
 }
+
+func (d *DeliveryLayer) GetBidDiff(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
+	if r.Method != "GET" {
+		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
+		return
+	}
+
+	queryParams := new(bqp.BidDiff)
+	err := queryParams.GetParameters(r)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	diff, err := d.ucBids.GetBidDiff(r.Context(), queryParams)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	diffOutput := dto.NewBidDiffOutput(diff)
+	responseData := f.NewResponseProps(w, diffOutput, http.StatusOK, mc.ApplicationJson)
+	f.Response(responseData)
+}
+
+func (d *DeliveryLayer) GetBidOffer(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
+	if r.Method != "GET" {
+		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
+		return
+	}
+
+	queryParams := new(bqp.BidOffer)
+	err := queryParams.GetParameters(r)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	offer, err := d.ucBids.GetBidOffer(r.Context(), queryParams)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	offerOutput := dto.NewBidOfferOutput(queryParams.BidID, offer)
+	responseData := f.NewResponseProps(w, offerOutput, http.StatusOK, mc.ApplicationJson)
+	f.Response(responseData)
+}
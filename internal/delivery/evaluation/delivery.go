@@ -0,0 +1,98 @@
+package evaluation
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"tender-workspace/internal/entity/dto"
+	bqp "tender-workspace/internal/entity/dto/queries/bids"
+	tqp "tender-workspace/internal/entity/dto/queries/tenders"
+	"tender-workspace/internal/usecase/evaluation"
+	f "tender-workspace/internal/utils/functions"
+	mc "tender-workspace/internal/utils/myconstants"
+	e "tender-workspace/internal/utils/myerrors"
+
+	"go.uber.org/zap"
+)
+
+type DeliveryLayer struct {
+	ucEvaluation evaluation.Usecase
+	logger       *zap.Logger
+}
+
+func NewDeliveryLayer(ucEvaluation evaluation.Usecase, logger *zap.Logger) *DeliveryLayer {
+	return &DeliveryLayer{
+		ucEvaluation: ucEvaluation,
+		logger:       logger,
+	}
+}
+
+func (d *DeliveryLayer) ScoreBid(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
+	if r.Method != "PUT" {
+		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
+		return
+	}
+
+	queryParams := new(bqp.BidScores)
+	err := queryParams.GetParameters(r)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrRequestBody)
+		return
+	}
+	var scoresData dto.BidScoresInput
+	err = json.Unmarshal(body, &scoresData)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrRequestBody)
+		return
+	}
+
+	scores, err := d.ucEvaluation.ScoreBid(r.Context(), &scoresData, queryParams)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	scoresOutput := dto.NewArrayBidScoreOutput(scores)
+	responseData := f.NewResponseProps(w, scoresOutput, http.StatusOK, mc.ApplicationJson)
+	f.Response(responseData)
+}
+
+func (d *DeliveryLayer) GetTenderScores(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
+	if r.Method != "GET" {
+		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
+		return
+	}
+
+	queryParams := new(tqp.TenderScores)
+	err := queryParams.GetParameters(r)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	matrix, err := d.ucEvaluation.GetTenderScores(r.Context(), queryParams)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	matrixOutput := dto.NewScoreMatrixOutput(matrix)
+	responseData := f.NewResponseProps(w, matrixOutput, http.StatusOK, mc.ApplicationJson)
+	f.Response(responseData)
+}
@@ -0,0 +1,85 @@
+package feedback
+
+import (
+	"net/http"
+	ent "tender-workspace/internal/entity"
+	"tender-workspace/internal/entity/dto"
+	bqp "tender-workspace/internal/entity/dto/queries/bids"
+	"tender-workspace/internal/usecase/feedback"
+	f "tender-workspace/internal/utils/functions"
+	mc "tender-workspace/internal/utils/myconstants"
+	e "tender-workspace/internal/utils/myerrors"
+
+	"go.uber.org/zap"
+)
+
+type DeliveryLayer struct {
+	ucFeedback feedback.Usecase
+	logger     *zap.Logger
+}
+
+func NewDeliveryLayer(ucFeedback feedback.Usecase, logger *zap.Logger) *DeliveryLayer {
+	return &DeliveryLayer{
+		ucFeedback: ucFeedback,
+		logger:     logger,
+	}
+}
+
+func (d *DeliveryLayer) SubmitFeedback(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
+	if r.Method != "PUT" {
+		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
+		return
+	}
+
+	queryParams := new(bqp.BidFeedback)
+	err := queryParams.GetParameters(r)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	bid, err := d.ucFeedback.SubmitFeedback(r.Context(), queryParams)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	bidOutput := dto.NewBidOutput(bid)
+	responseData := f.NewResponseProps(w, bidOutput, http.StatusOK, mc.ApplicationJson)
+	f.Response(responseData)
+}
+
+func (d *DeliveryLayer) GetReviews(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
+	if r.Method != "GET" {
+		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
+		return
+	}
+
+	queryParams := new(bqp.BidReviews)
+	err := queryParams.GetParameters(r)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	reviews, err := d.ucFeedback.GetReviews(r.Context(), queryParams)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+	if reviews == nil {
+		reviews = make([]*ent.Feedback, 0)
+	}
+
+	reviewsOutput := dto.NewArrayBidReviewOutput(reviews)
+	responseData := f.NewResponseProps(w, reviewsOutput, http.StatusOK, mc.ApplicationJson)
+	f.Response(responseData)
+}
@@ -12,8 +12,7 @@ import (
 // Чекер программа будет ждать первый успешный ответ и затем начнет выполнение тестовых сценариев.
 func Ping(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrMethodNotAllowed.Error()}, http.StatusMethodNotAllowed, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
 		return
 	}
 	propsResponse := f.NewResponseProps(w, ent.ResponseDetail{Detail: "ok"}, http.StatusOK, mc.ApplicationJson)
@@ -0,0 +1,61 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"tender-workspace/internal/entity/dto"
+	f "tender-workspace/internal/utils/functions"
+	mc "tender-workspace/internal/utils/myconstants"
+	e "tender-workspace/internal/utils/myerrors"
+	"tender-workspace/services/postgres/migrations"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	errPendingMigrations = errors.New("database schema has pending migrations")
+	errPoolSaturated     = errors.New("connection pool is saturated")
+)
+
+// Migrator
+// Source of migrations state, implemented by migrations.Migrator.
+type Migrator interface {
+	Status(ctx context.Context) ([]migrations.MigrationStatus, error)
+}
+
+type DeliveryLayer struct {
+	pool     *pgxpool.Pool
+	migrator Migrator
+}
+
+func NewDeliveryLayer(pool *pgxpool.Pool, migrator Migrator) *DeliveryLayer {
+	return &DeliveryLayer{
+		pool:     pool,
+		migrator: migrator,
+	}
+}
+
+// PoolStats возвращает текущую статистику пула соединений с PSQL.
+func (d *DeliveryLayer) PoolStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
+		return
+	}
+	stat := d.pool.Stat()
+	statsOutput := &dto.PoolStatsOutput{
+		MaxConns:                stat.MaxConns(),
+		TotalConns:              stat.TotalConns(),
+		AcquiredConns:           stat.AcquiredConns(),
+		IdleConns:               stat.IdleConns(),
+		ConstructingConns:       stat.ConstructingConns(),
+		AcquireCount:            stat.AcquireCount(),
+		EmptyAcquireCount:       stat.EmptyAcquireCount(),
+		CanceledAcquireCount:    stat.CanceledAcquireCount(),
+		AcquireDurationMs:       stat.AcquireDuration().Milliseconds(),
+		MaxLifetimeDestroyCount: stat.MaxLifetimeDestroyCount(),
+		MaxIdleDestroyCount:     stat.MaxIdleDestroyCount(),
+	}
+	propsResponse := f.NewResponseProps(w, statsOutput, http.StatusOK, mc.ApplicationJson)
+	f.Response(propsResponse)
+}
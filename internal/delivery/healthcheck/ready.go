@@ -0,0 +1,126 @@
+package healthcheck
+
+import (
+	"context"
+	"net/http"
+	"tender-workspace/internal/entity/dto"
+	f "tender-workspace/internal/utils/functions"
+	mc "tender-workspace/internal/utils/myconstants"
+	e "tender-workspace/internal/utils/myerrors"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	statusOk   = "ok"
+	statusFail = "fail"
+
+	checkTimeout = 2 * time.Second
+	// defaultPoolSaturation доля занятых соединений, при которой сервис перестает принимать трафик
+	defaultPoolSaturation = 0.9
+)
+
+// Live
+// Liveness probe: the process is running and handles requests, dependencies aren't checked.
+func Live(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
+		return
+	}
+	propsResponse := f.NewResponseProps(w, &dto.HealthOutput{Status: statusOk}, http.StatusOK, mc.ApplicationJson)
+	f.Response(propsResponse)
+}
+
+// Ready
+// Readiness probe: database answers, schema is up to date and pool isn't saturated.
+// Responds 503 with breakdown per dependency if any check fails.
+func (d *DeliveryLayer) Ready(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
+		return
+	}
+	output := &dto.HealthOutput{
+		Status: statusOk,
+		Checks: map[string]*dto.HealthCheckOutput{
+			"database":   d.checkDatabase(r.Context()),
+			"migrations": d.checkMigrations(r.Context()),
+			"pool":       d.checkPool(),
+		},
+	}
+	codeStatus := http.StatusOK
+	for _, check := range output.Checks {
+		if check.Status != statusOk {
+			output.Status = statusFail
+			codeStatus = http.StatusServiceUnavailable
+		}
+	}
+	propsResponse := f.NewResponseProps(w, output, codeStatus, mc.ApplicationJson)
+	f.Response(propsResponse)
+}
+
+func (d *DeliveryLayer) checkDatabase(ctx context.Context) *dto.HealthCheckOutput {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+	start := time.Now()
+	err := d.pool.Ping(ctx)
+	return newHealthCheckOutput(time.Since(start), err, nil)
+}
+
+func (d *DeliveryLayer) checkMigrations(ctx context.Context) *dto.HealthCheckOutput {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+	start := time.Now()
+	statuses, err := d.migrator.Status(ctx)
+	var applied, pending, version int
+	for _, status := range statuses {
+		if status.Applied {
+			applied++
+			version = status.Version
+		} else {
+			pending++
+		}
+	}
+	details := map[string]any{
+		"applied": applied,
+		"pending": pending,
+		"version": version,
+	}
+	if err == nil && pending != 0 {
+		err = errPendingMigrations
+	}
+	return newHealthCheckOutput(time.Since(start), err, details)
+}
+
+func (d *DeliveryLayer) checkPool() *dto.HealthCheckOutput {
+	threshold := viper.GetFloat64("HEALTH_POOL_SATURATION")
+	if threshold <= 0 {
+		threshold = defaultPoolSaturation
+	}
+	stat := d.pool.Stat()
+	saturation := float64(stat.AcquiredConns()) / float64(stat.MaxConns())
+	details := map[string]any{
+		"acquiredConns": stat.AcquiredConns(),
+		"maxConns":      stat.MaxConns(),
+		"saturation":    saturation,
+		"threshold":     threshold,
+	}
+	var err error
+	if saturation >= threshold {
+		err = errPoolSaturated
+	}
+	return newHealthCheckOutput(0, err, details)
+}
+
+func newHealthCheckOutput(latency time.Duration, err error, details map[string]any) *dto.HealthCheckOutput {
+	output := &dto.HealthCheckOutput{
+		Status:    statusOk,
+		LatencyMs: latency.Milliseconds(),
+		Details:   details,
+	}
+	if err != nil {
+		output.Status = statusFail
+		output.Error = err.Error()
+	}
+	return output
+}
@@ -1,9 +1,7 @@
 package organization
 
 import (
-	"database/sql"
 	"encoding/json"
-	"errors"
 	"io"
 	"net/http"
 	"strconv"
@@ -35,8 +33,7 @@ func (d *DeliveryLayer) GetListOfOrganizations(w http.ResponseWriter, r *http.Re
 	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
 	if r.Method != "GET" {
 		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrMethodNotAllowed.Error()}, http.StatusMethodNotAllowed, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
 		return
 	}
 
@@ -44,25 +41,14 @@ func (d *DeliveryLayer) GetListOfOrganizations(w http.ResponseWriter, r *http.Re
 	err := queryParams.GetParameters(r)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		if errors.Is(err, e.ErrQPLimit) ||
-			errors.Is(err, e.ErrQPOffset) ||
-			errors.Is(err, e.ErrQPOrgType) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()},
-			http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, err)
 		return
 	}
 
-	orgs, err := d.ucOrganizaiton.GetAll(r.Context(), queryParams)
-	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+	orgs, cursors, err := d.ucOrganizaiton.GetAll(r.Context(), queryParams)
+	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()},
-			http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, err)
 		return
 	}
 	if orgs == nil {
@@ -70,6 +56,7 @@ func (d *DeliveryLayer) GetListOfOrganizations(w http.ResponseWriter, r *http.Re
 	}
 
 	orgsOutput := newArrayOrgOutput(orgs)
+	f.SetCursors(w, cursors)
 	responseData := f.NewResponseProps(w, orgsOutput, http.StatusOK, mc.ApplicationJson)
 	f.Response(responseData)
 }
@@ -78,44 +65,41 @@ func (d *DeliveryLayer) CreateNewOrganization(w http.ResponseWriter, r *http.Req
 	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
 	if r.Method != "POST" {
 		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrMethodNotAllowed.Error()}, http.StatusMethodNotAllowed, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
 		return
 	}
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrRequestBody.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrRequestBody)
 		return
 	}
 	var orgData dto.OrganizationInput
 	err = json.Unmarshal(body, &orgData)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrRequestBody.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrRequestBody)
 		return
 	}
 	isValid, err := f.Validate(orgData)
 	if err != nil || !isValid {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrRequestBody.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrRequestBody)
 		return
 	}
 
+	username, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		d.logger.Info(e.ErrUnauthorized.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrUnauthorized)
+		return
+	}
+	orgData.CreatorUsername = username
 	org, err := d.ucOrganizaiton.Create(r.Context(), &orgData)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		if errors.Is(err, e.ErrQPOrgType) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()}, http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, err)
 		return
 	}
 
@@ -128,50 +112,39 @@ func (d *DeliveryLayer) UpdateOrganization(w http.ResponseWriter, r *http.Reques
 	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
 	if r.Method != "PUT" {
 		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrMethodNotAllowed.Error()}, http.StatusMethodNotAllowed, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
 		return
 	}
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrRequestBody.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrRequestBody)
 		return
 	}
 	var orgData dto.OrganizationInput
 	err = json.Unmarshal(body, &orgData)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrRequestBody.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrRequestBody)
 		return
 	}
 	isValid, err := f.Validate(orgData)
 	if err != nil || !isValid {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrRequestBody.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrRequestBody)
 		return
 	}
 	organizationID, err := strconv.Atoi(mux.Vars(r)["organizationID"])
 	if err != nil || organizationID < 1 {
 		d.logger.Info(e.ErrRequestBody.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrRequestBody.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrRequestBody)
 		return
 	}
 	org, err := d.ucOrganizaiton.Update(r.Context(), &orgData, organizationID)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		if errors.Is(err, e.ErrQPOrgType) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()}, http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, err)
 		return
 	}
 
@@ -184,34 +157,27 @@ func (d *DeliveryLayer) MakeResponsible(w http.ResponseWriter, r *http.Request)
 	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
 	if r.Method != "POST" {
 		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrMethodNotAllowed.Error()}, http.StatusMethodNotAllowed, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
 		return
 	}
 
 	organizationID, err := strconv.Atoi(mux.Vars(r)["organizationID"])
 	if err != nil || organizationID < 1 {
 		d.logger.Info(e.ErrRequestBody.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrRequestBody.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrRequestBody)
+		return
+	}
+	requesterUsername, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		d.logger.Info(e.ErrUnauthorized.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrUnauthorized)
 		return
 	}
 	username := mux.Vars(r)["username"]
-	err = d.ucOrganizaiton.MakeResponsible(r.Context(), username, organizationID)
+	err = d.ucOrganizaiton.MakeResponsible(r.Context(), requesterUsername, username, organizationID)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		if errors.Is(err, e.ErrUserExist) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusUnauthorized, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		if errors.Is(err, e.ErrOrganizationExist) || errors.Is(err, e.ErrUserAlreadyResponsible) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()}, http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, err)
 		return
 	}
 
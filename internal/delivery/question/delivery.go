@@ -0,0 +1,147 @@
+package question
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"tender-workspace/internal/entity/dto"
+	tqp "tender-workspace/internal/entity/dto/queries/tenders"
+	"tender-workspace/internal/usecase/question"
+	f "tender-workspace/internal/utils/functions"
+	mc "tender-workspace/internal/utils/myconstants"
+	e "tender-workspace/internal/utils/myerrors"
+
+	"go.uber.org/zap"
+)
+
+type DeliveryLayer struct {
+	ucQuestion question.Usecase
+	logger     *zap.Logger
+}
+
+func NewDeliveryLayer(ucQuestion question.Usecase, logger *zap.Logger) *DeliveryLayer {
+	return &DeliveryLayer{
+		ucQuestion: ucQuestion,
+		logger:     logger,
+	}
+}
+
+func (d *DeliveryLayer) AskQuestion(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
+	if r.Method != "POST" {
+		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
+		return
+	}
+
+	queryParams := new(tqp.AskQuestion)
+	err := queryParams.GetParameters(r)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrRequestBody)
+		return
+	}
+	var questionData dto.QuestionInput
+	err = json.Unmarshal(body, &questionData)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrRequestBody)
+		return
+	}
+
+	q, err := d.ucQuestion.AskQuestion(r.Context(), &questionData, queryParams)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	questionOutput := dto.NewQuestionOutput(q)
+	responseData := f.NewResponseProps(w, questionOutput, http.StatusOK, mc.ApplicationJson)
+	f.Response(responseData)
+}
+
+func (d *DeliveryLayer) GetQuestions(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
+	if r.Method != "GET" {
+		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
+		return
+	}
+
+	queryParams := new(tqp.TenderQuestions)
+	err := queryParams.GetParameters(r)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	questions, cursors, err := d.ucQuestion.GetQuestions(r.Context(), queryParams)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	questionsOutput := dto.NewArrayQuestionOutput(questions)
+	f.SetCursors(w, cursors)
+	responseData := f.NewResponseProps(w, questionsOutput, http.StatusOK, mc.ApplicationJson)
+	f.Response(responseData)
+}
+
+func (d *DeliveryLayer) AnswerQuestion(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
+	if r.Method != "PUT" {
+		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
+		return
+	}
+
+	queryParams := new(tqp.AnswerQuestion)
+	err := queryParams.GetParameters(r)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrRequestBody)
+		return
+	}
+	var answerData dto.AnswerInput
+	err = json.Unmarshal(body, &answerData)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrRequestBody)
+		return
+	}
+	// amendment of the tender is validated as the tender edit
+	isValid, err := f.Validate(answerData)
+	if err != nil || !isValid {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrRequestBody)
+		return
+	}
+
+	q, err := d.ucQuestion.AnswerQuestion(r.Context(), &answerData, queryParams)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	questionOutput := dto.NewQuestionOutput(q)
+	responseData := f.NewResponseProps(w, questionOutput, http.StatusOK, mc.ApplicationJson)
+	f.Response(responseData)
+}
@@ -0,0 +1,34 @@
+package attachment
+
+import (
+	delAttachment "tender-workspace/internal/delivery/attachment"
+	repoAttachment "tender-workspace/internal/repo/attachment"
+	repoBids "tender-workspace/internal/repo/bids"
+	repoOrgs "tender-workspace/internal/repo/organization"
+	repoTender "tender-workspace/internal/repo/tender"
+	repoUser "tender-workspace/internal/repo/user"
+	usecaseAttachment "tender-workspace/internal/usecase/attachment"
+	"tender-workspace/services/blob"
+
+	"github.com/gorilla/mux"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+func InitHandlers(r *mux.Router, psqlPool *pgxpool.Pool, storage blob.Storage, logger *zap.Logger) {
+	// init repo, usecase, handler
+	aRepo := repoAttachment.NewRepoLayer(psqlPool, logger)
+	bRepo := repoBids.NewRepoLayer(psqlPool, logger)
+	uRepo := repoUser.NewRepoLayer(psqlPool, logger)
+	oRepo := repoOrgs.NewRepoLayer(psqlPool, logger)
+	tRepo := repoTender.NewRepoLayer(psqlPool, logger)
+	aUsecase := usecaseAttachment.NewTracingLayer(usecaseAttachment.NewUsecaseLayer(aRepo, bRepo, uRepo, oRepo, tRepo, storage, logger))
+	aDelivery := delAttachment.NewDeliveryLayer(aUsecase, logger)
+
+	r.HandleFunc("/tenders/{tenderId}/attachments", aDelivery.GetTenderAttachments).Methods("GET")
+	r.HandleFunc("/tenders/{tenderId}/attachments", aDelivery.UploadTenderAttachment).Methods("POST")
+	r.HandleFunc("/tenders/{tenderId}/attachments/{attachmentId}", aDelivery.DownloadTenderAttachment)
+	r.HandleFunc("/bids/{bidId}/attachments", aDelivery.GetBidAttachments).Methods("GET")
+	r.HandleFunc("/bids/{bidId}/attachments", aDelivery.UploadBidAttachment).Methods("POST")
+	r.HandleFunc("/bids/{bidId}/attachments/{attachmentId}", aDelivery.DownloadBidAttachment)
+}
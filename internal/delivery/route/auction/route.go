@@ -0,0 +1,29 @@
+package auction
+
+import (
+	delAuction "tender-workspace/internal/delivery/auction"
+	repoAuction "tender-workspace/internal/repo/auction"
+	repoBids "tender-workspace/internal/repo/bids"
+	repoOrgs "tender-workspace/internal/repo/organization"
+	repoTender "tender-workspace/internal/repo/tender"
+	repoUser "tender-workspace/internal/repo/user"
+	usecaseAuction "tender-workspace/internal/usecase/auction"
+
+	"github.com/gorilla/mux"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+func InitHandlers(r *mux.Router, psqlPool *pgxpool.Pool, logger *zap.Logger) {
+	// init repo, usecase, handler
+	aRepo := repoAuction.NewRepoLayer(psqlPool, logger)
+	bRepo := repoBids.NewRepoLayer(psqlPool, logger)
+	uRepo := repoUser.NewRepoLayer(psqlPool, logger)
+	oRepo := repoOrgs.NewRepoLayer(psqlPool, logger)
+	tRepo := repoTender.NewRepoLayer(psqlPool, logger)
+	aUsecase := usecaseAuction.NewTracingLayer(usecaseAuction.NewUsecaseLayer(aRepo, bRepo, uRepo, oRepo, tRepo))
+	aDelivery := delAuction.NewDeliveryLayer(aUsecase, logger)
+
+	r.HandleFunc("/bids/{bidId}/auction", aDelivery.PlaceOffer)
+	r.HandleFunc("/tenders/{tenderId}/auction", aDelivery.GetRanking)
+}
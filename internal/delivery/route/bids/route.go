@@ -11,27 +11,29 @@ import (
 	usecaseBids "tender-workspace/internal/usecase/bids"
 
 	"github.com/gorilla/mux"
-	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 )
 
-func InitHandlers(r *mux.Router, psqlConn *pgx.Conn, logger *zap.Logger) {
+func InitHandlers(r *mux.Router, psqlPool *pgxpool.Pool, logger *zap.Logger) {
 	// init repo, usecase, handler
-	bRepo := repoBids.NewRepoLayer(psqlConn, logger)
-	uRepo := repoUser.NewRepoLayer(psqlConn, logger)
-	tRepo := repoTender.NewRepoLayer(psqlConn, logger)
-	oRepo := repoOrgs.NewRepoLayer(psqlConn, logger)
-	bUsecase := usecaseBids.NewUsecaseLayer(bRepo, uRepo, oRepo, tRepo)
+	bRepo := repoBids.NewRepoLayer(psqlPool, logger)
+	uRepo := repoUser.NewRepoLayer(psqlPool, logger)
+	tRepo := repoTender.NewRepoLayer(psqlPool, logger)
+	oRepo := repoOrgs.NewRepoLayer(psqlPool, logger)
+	bUsecase := usecaseBids.NewTracingLayer(usecaseBids.NewUsecaseLayer(bRepo, uRepo, oRepo, tRepo))
 	bDelivery := delBids.NewDeliveryLayer(bUsecase, logger)
 
 	r.HandleFunc("/bids/new", bDelivery.CreateBid)
+	r.HandleFunc("/bids/sealed", bDelivery.CreateSealedBid)
 	r.HandleFunc("/bids/my", bDelivery.GetUserBids)
 	r.HandleFunc("/bids/{tenderId}/list", bDelivery.GetTenderListOfBids)
 	r.HandleFunc("/bids/{bidId}/status", bDelivery.GetBidStatus).Methods("GET")
 	r.HandleFunc("/bids/{bidId}/status", bDelivery.UpdateBidStatus).Methods("PUT")
 	r.HandleFunc("/bids/{bidId}/edit", bDelivery.UpdateBid)
 	r.HandleFunc("/bids/{bidId}/submit_decision", bDelivery.SubmitDecision)
-	// r.HandleFunc("/bids/{bidId}/rollback/{version}")
-	// r.HandleFunc("/bids/{tenderId}/reviews")
-	// надо еще добавить rollback
+	r.HandleFunc("/bids/{bidId}/rollback/{version}", bDelivery.RollbackBid)
+	r.HandleFunc("/bids/{bidId}/diff", bDelivery.GetBidDiff)
+	r.HandleFunc("/bids/{bidId}/offer", bDelivery.GetBidOffer)
+	r.HandleFunc("/bids/{bidId}/reveal", bDelivery.RevealBid)
 }
@@ -0,0 +1,29 @@
+package evaluation
+
+import (
+	delEvaluation "tender-workspace/internal/delivery/evaluation"
+	repoBids "tender-workspace/internal/repo/bids"
+	repoEvaluation "tender-workspace/internal/repo/evaluation"
+	repoOrgs "tender-workspace/internal/repo/organization"
+	repoTender "tender-workspace/internal/repo/tender"
+	repoUser "tender-workspace/internal/repo/user"
+	usecaseEvaluation "tender-workspace/internal/usecase/evaluation"
+
+	"github.com/gorilla/mux"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+func InitHandlers(r *mux.Router, psqlPool *pgxpool.Pool, logger *zap.Logger) {
+	// init repo, usecase, handler
+	evRepo := repoEvaluation.NewRepoLayer(psqlPool, logger)
+	bRepo := repoBids.NewRepoLayer(psqlPool, logger)
+	uRepo := repoUser.NewRepoLayer(psqlPool, logger)
+	oRepo := repoOrgs.NewRepoLayer(psqlPool, logger)
+	tRepo := repoTender.NewRepoLayer(psqlPool, logger)
+	evUsecase := usecaseEvaluation.NewTracingLayer(usecaseEvaluation.NewUsecaseLayer(evRepo, bRepo, uRepo, oRepo, tRepo))
+	evDelivery := delEvaluation.NewDeliveryLayer(evUsecase, logger)
+
+	r.HandleFunc("/bids/{bidId}/scores", evDelivery.ScoreBid)
+	r.HandleFunc("/tenders/{tenderId}/scores", evDelivery.GetTenderScores)
+}
@@ -1,21 +1,29 @@
-package bids
+package feedback
 
-// import (
-// 	repoBids "tender-workspace/internal/repo/bids"
+import (
+	delFeedback "tender-workspace/internal/delivery/feedback"
+	repoBids "tender-workspace/internal/repo/bids"
+	repoFeedback "tender-workspace/internal/repo/feedback"
+	repoOrgs "tender-workspace/internal/repo/organization"
+	repoTender "tender-workspace/internal/repo/tender"
+	repoUser "tender-workspace/internal/repo/user"
+	usecaseFeedback "tender-workspace/internal/usecase/feedback"
 
-// 	// repoUser "tender-workspace/internal/repo/user"
+	"github.com/gorilla/mux"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
 
-// 	"github.com/gorilla/mux"
-// 	"github.com/jackc/pgx/v5"
-// 	"go.uber.org/zap"
-// )
+func InitHandlers(r *mux.Router, psqlPool *pgxpool.Pool, logger *zap.Logger) {
+	// init repo, usecase, handler
+	fRepo := repoFeedback.NewRepoLayer(psqlPool, logger)
+	bRepo := repoBids.NewRepoLayer(psqlPool, logger)
+	uRepo := repoUser.NewRepoLayer(psqlPool, logger)
+	oRepo := repoOrgs.NewRepoLayer(psqlPool, logger)
+	tRepo := repoTender.NewRepoLayer(psqlPool, logger)
+	fUsecase := usecaseFeedback.NewTracingLayer(usecaseFeedback.NewUsecaseLayer(fRepo, bRepo, uRepo, oRepo, tRepo))
+	fDelivery := delFeedback.NewDeliveryLayer(fUsecase, logger)
 
-// func InitHandlers(r *mux.Router, psqlConn *pgx.Conn, logger *zap.Logger) {
-// 	// init repo, usecase, handler
-// 	bRepo := repoBids.NewRepoLayer(psqlConn, logger)
-// 	bRepo := repoFeedback.NewRepoLayer(psqlConn, logger)
-// 	r.HandleFunc("/bids/{bidId}/feedback", bDelivery.SubmitDecision)
-// 	// r.HandleFunc("/bids/{bidId}/rollback/{version}")
-// 	// r.HandleFunc("/bids/{tenderId}/reviews")
-// 	// надо еще добавить rollback
-// }
+	r.HandleFunc("/bids/{bidId}/feedback", fDelivery.SubmitFeedback)
+	r.HandleFunc("/bids/{tenderId}/reviews", fDelivery.GetReviews)
+}
@@ -2,25 +2,39 @@ package route
 
 import (
 	"net/http"
+	"tender-workspace/internal/delivery/healthcheck"
+	"tender-workspace/internal/delivery/route/attachment"
+	"tender-workspace/internal/delivery/route/auction"
 	"tender-workspace/internal/delivery/route/bids"
+	"tender-workspace/internal/delivery/route/evaluation"
+	"tender-workspace/internal/delivery/route/feedback"
 	"tender-workspace/internal/delivery/route/organization"
 	"tender-workspace/internal/delivery/route/ping"
+	"tender-workspace/internal/delivery/route/question"
 	"tender-workspace/internal/delivery/route/tender"
 	"tender-workspace/internal/delivery/route/user"
 	"tender-workspace/internal/middlewares"
+	"tender-workspace/internal/utils/metrics"
+	"tender-workspace/services/blob"
 
 	"github.com/gorilla/mux"
-	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 )
 
-func InitHTTPHandlers(router *mux.Router, psqlConn *pgx.Conn, logger *zap.Logger) http.Handler {
-	router = router.PathPrefix("/api").Subrouter()
-	ping.InitHandlers(router)
-	organization.InitHandlers(router, psqlConn, logger)
-	user.InitHandlers(router, psqlConn, logger)
-	tender.InitHandlers(router, psqlConn, logger)
-	bids.InitHandlers(router, psqlConn, logger)
+func InitHTTPHandlers(router *mux.Router, psqlPool *pgxpool.Pool, storage blob.Storage, migrator healthcheck.Migrator, logger *zap.Logger) http.Handler {
+	router.Handle("/metrics", metrics.Handler()).Methods("GET")
+	api := router.PathPrefix("/api").Subrouter()
+	ping.InitHandlers(api, psqlPool, migrator)
+	organization.InitHandlers(api, psqlPool, logger)
+	user.InitHandlers(api, psqlPool, logger)
+	tender.InitHandlers(api, psqlPool, logger)
+	bids.InitHandlers(api, psqlPool, logger)
+	feedback.InitHandlers(api, psqlPool, logger)
+	evaluation.InitHandlers(api, psqlPool, logger)
+	auction.InitHandlers(api, psqlPool, logger)
+	question.InitHandlers(api, psqlPool, logger)
+	attachment.InitHandlers(api, psqlPool, storage, logger)
 
 	return middlewares.Init(router, logger)
 }
@@ -7,15 +7,15 @@ import (
 	usecaseOrg "tender-workspace/internal/usecase/organization"
 
 	"github.com/gorilla/mux"
-	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 )
 
-func InitHandlers(r *mux.Router, psqlConn *pgx.Conn, logger *zap.Logger) {
+func InitHandlers(r *mux.Router, psqlPool *pgxpool.Pool, logger *zap.Logger) {
 	// init repo, usecase, handler
-	orgRepo := repoOrg.NewRepoLayer(psqlConn, logger)
-	userRepo := repoUser.NewRepoLayer(psqlConn, logger)
-	orgUsecase := usecaseOrg.NewUsecaseLayer(orgRepo, userRepo)
+	orgRepo := repoOrg.NewRepoLayer(psqlPool, logger)
+	userRepo := repoUser.NewRepoLayer(psqlPool, logger)
+	orgUsecase := usecaseOrg.NewTracingLayer(usecaseOrg.NewUsecaseLayer(orgRepo, userRepo))
 	orgDelivery := delOrg.NewDeliveryLayer(orgUsecase, logger)
 	r.HandleFunc("/organizations", orgDelivery.GetListOfOrganizations)
 	r.HandleFunc("/organizations/new", orgDelivery.CreateNewOrganization)
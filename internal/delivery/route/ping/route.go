@@ -4,8 +4,13 @@ import (
 	"tender-workspace/internal/delivery/healthcheck"
 
 	"github.com/gorilla/mux"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-func InitHandlers(router *mux.Router) {
+func InitHandlers(router *mux.Router, psqlPool *pgxpool.Pool, migrator healthcheck.Migrator) {
+	hDelivery := healthcheck.NewDeliveryLayer(psqlPool, migrator)
 	router.HandleFunc("/ping", healthcheck.Ping)
+	router.HandleFunc("/health/live", healthcheck.Live)
+	router.HandleFunc("/health/ready", hDelivery.Ready)
+	router.HandleFunc("/db/stats", hDelivery.PoolStats)
 }
@@ -0,0 +1,28 @@
+package question
+
+import (
+	delQuestion "tender-workspace/internal/delivery/question"
+	repoOrgs "tender-workspace/internal/repo/organization"
+	repoQuestion "tender-workspace/internal/repo/question"
+	repoTender "tender-workspace/internal/repo/tender"
+	repoUser "tender-workspace/internal/repo/user"
+	usecaseQuestion "tender-workspace/internal/usecase/question"
+
+	"github.com/gorilla/mux"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+func InitHandlers(r *mux.Router, psqlPool *pgxpool.Pool, logger *zap.Logger) {
+	// init repo, usecase, handler
+	qRepo := repoQuestion.NewRepoLayer(psqlPool, logger)
+	uRepo := repoUser.NewRepoLayer(psqlPool, logger)
+	oRepo := repoOrgs.NewRepoLayer(psqlPool, logger)
+	tRepo := repoTender.NewRepoLayer(psqlPool, logger)
+	qUsecase := usecaseQuestion.NewTracingLayer(usecaseQuestion.NewUsecaseLayer(qRepo, uRepo, oRepo, tRepo))
+	qDelivery := delQuestion.NewDeliveryLayer(qUsecase, logger)
+
+	r.HandleFunc("/tenders/{tenderId}/questions", qDelivery.GetQuestions).Methods("GET")
+	r.HandleFunc("/tenders/{tenderId}/questions", qDelivery.AskQuestion).Methods("POST")
+	r.HandleFunc("/tenders/{tenderId}/questions/{questionId}/answer", qDelivery.AnswerQuestion)
+}
@@ -8,22 +8,26 @@ import (
 	usecaseTender "tender-workspace/internal/usecase/tender"
 
 	"github.com/gorilla/mux"
-	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 )
 
-func InitHandlers(r *mux.Router, psqlConn *pgx.Conn, logger *zap.Logger) {
+func InitHandlers(r *mux.Router, psqlPool *pgxpool.Pool, logger *zap.Logger) {
 	// init repo, usecase, handler
-	tRepo := repoTender.NewRepoLayer(psqlConn, logger)
-	uRepo := repoUser.NewRepoLayer(psqlConn, logger)
-	oRepo := repoOrg.NewRepoLayer(psqlConn, logger)
-	tUsecase := usecaseTender.NewUsecaseLayer(tRepo, uRepo, oRepo)
+	tRepo := repoTender.NewRepoLayer(psqlPool, logger)
+	uRepo := repoUser.NewRepoLayer(psqlPool, logger)
+	oRepo := repoOrg.NewRepoLayer(psqlPool, logger)
+	tUsecase := usecaseTender.NewTracingLayer(usecaseTender.NewUsecaseLayer(tRepo, uRepo, oRepo))
 	tDelivery := tender.NewDeliveryLayer(tUsecase, logger)
 	r.HandleFunc("/tenders", tDelivery.GetListOfTenders)
 	r.HandleFunc("/tenders/new", tDelivery.CreateNewTender)
+	r.HandleFunc("/tenders/search", tDelivery.SearchTenders)
 	r.HandleFunc("/tenders/my", tDelivery.GetUserTenders)
 	r.HandleFunc("/tenders/{tenderId}/status", tDelivery.GetTenderStatus).Methods("GET")
 	r.HandleFunc("/tenders/{tenderId}/status", tDelivery.UpdateTenderStatus).Methods("PUT")
 	r.HandleFunc("/tenders/{tenderId}/edit", tDelivery.UpdateTender)
-	// надо еще добавить rollback
+	r.HandleFunc("/tenders/{tenderId}/versions", tDelivery.GetTenderVersions)
+	r.HandleFunc("/tenders/{tenderId}/rollback/{version}", tDelivery.RollbackTender)
+	r.HandleFunc("/tenders/{tenderId}/award", tDelivery.GetTenderAward)
+	r.HandleFunc("/tenders/{tenderId}/lots", tDelivery.GetTenderLots)
 }
@@ -6,15 +6,16 @@ import (
 	usecaseUser "tender-workspace/internal/usecase/user"
 
 	"github.com/gorilla/mux"
-	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 )
 
-func InitHandlers(r *mux.Router, psqlConn *pgx.Conn, logger *zap.Logger) {
+func InitHandlers(r *mux.Router, psqlPool *pgxpool.Pool, logger *zap.Logger) {
 	// init repo, usecase, handler
-	userRepo := repoUser.NewRepoLayer(psqlConn, logger)
-	userUsecase := usecaseUser.NewUsecaseLayer(userRepo)
+	userRepo := repoUser.NewRepoLayer(psqlPool, logger)
+	userUsecase := usecaseUser.NewTracingLayer(usecaseUser.NewUsecaseLayer(userRepo))
 	userDelivery := delUser.NewDeliveryLayer(userUsecase, logger)
+	r.HandleFunc("/auth/login", userDelivery.Login)
 	r.HandleFunc("/users/new", userDelivery.CreateUser)
 	r.HandleFunc("/users/{username}", userDelivery.GetUser)
 	r.HandleFunc("/users/{username}/organizations", userDelivery.GetUserOrganizationsIds)
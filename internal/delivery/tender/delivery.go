@@ -2,7 +2,6 @@ package tender
 
 import (
 	"encoding/json"
-	"errors"
 	"io"
 	"net/http"
 	ent "tender-workspace/internal/entity"
@@ -32,31 +31,21 @@ func (d *DeliveryLayer) GetListOfTenders(w http.ResponseWriter, r *http.Request)
 	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
 	if r.Method != "GET" {
 		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrMethodNotAllowed.Error()}, http.StatusMethodNotAllowed, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
 		return
 	}
 	queryParams := new(tqp.ListTenders)
 	err := queryParams.GetParameters(r)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		if errors.Is(err, e.ErrQPLimit) || errors.Is(err, e.ErrQPOffset) || errors.Is(err, e.ErrQPServiceType) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()},
-			http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, err)
 		return
 	}
 
-	tenders, err := d.ucTender.GetTenders(r.Context(), queryParams)
+	tenders, cursors, err := d.ucTender.GetTenders(r.Context(), queryParams)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()},
-			http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, err)
 		return
 	}
 	if tenders == nil {
@@ -64,61 +53,83 @@ func (d *DeliveryLayer) GetListOfTenders(w http.ResponseWriter, r *http.Request)
 	}
 
 	tendersOutput := dto.NewArrayTenderOutput(tenders)
+	f.SetCursors(w, cursors)
 	responseData := f.NewResponseProps(w, tendersOutput, http.StatusOK, mc.ApplicationJson)
 	f.Response(responseData)
 }
 
+func (d *DeliveryLayer) SearchTenders(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
+	if r.Method != "GET" {
+		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
+		return
+	}
+	queryParams := new(tqp.SearchTenders)
+	err := queryParams.GetParameters(r)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	results, cursors, err := d.ucTender.SearchTenders(r.Context(), queryParams)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	resultsOutput := dto.NewArrayTenderSearchOutput(results)
+	f.SetCursors(w, cursors)
+	responseData := f.NewResponseProps(w, resultsOutput, http.StatusOK, mc.ApplicationJson)
+	f.Response(responseData)
+}
+
 func (d *DeliveryLayer) CreateNewTender(w http.ResponseWriter, r *http.Request) {
 	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
 	if r.Method != "POST" {
 		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrMethodNotAllowed.Error()}, http.StatusMethodNotAllowed, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
 		return
 	}
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrRequestBody.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrRequestBody)
 		return
 	}
 	var tenderData dto.TenderInput
 	err = json.Unmarshal(body, &tenderData)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrRequestBody.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrRequestBody)
 		return
 	}
+	username, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		d.logger.Info(e.ErrUnauthorized.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrUnauthorized)
+		return
+	}
+	tenderData.CreatorUsername = username
 	isValid, err := f.Validate(tenderData)
 	if err != nil || !isValid {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrRequestBody.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrRequestBody)
 		return
 	}
 
 	tender, err := d.ucTender.CreateTender(r.Context(), &tenderData)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		if errors.Is(err, e.ErrBadStatusCreate) || errors.Is(err, e.ErrQPServiceType) || errors.Is(err, e.ErrOrganizationExist) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		if errors.Is(err, e.ErrResponsibilty) || errors.Is(err, e.ErrUserExist) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusUnauthorized, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()}, http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, err)
 		return
 	}
 
 	tenderOutput := dto.NewTenderOutput(tender)
+	w.Header().Set("ETag", f.ETag(tender.Version))
 	responseData := f.NewResponseProps(w, tenderOutput, http.StatusOK, mc.ApplicationJson)
 	f.Response(responseData)
 }
@@ -127,8 +138,7 @@ func (d *DeliveryLayer) GetUserTenders(w http.ResponseWriter, r *http.Request) {
 	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
 	if r.Method != "GET" {
 		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrMethodNotAllowed.Error()}, http.StatusMethodNotAllowed, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
 		return
 	}
 
@@ -136,33 +146,14 @@ func (d *DeliveryLayer) GetUserTenders(w http.ResponseWriter, r *http.Request) {
 	err := queryParams.GetParameters(r)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		if errors.Is(err, e.ErrQPLimit) || errors.Is(err, e.ErrQPOffset) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		if errors.Is(err, e.ErrBadPermission) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusUnauthorized, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()},
-			http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
-		return
-	}
-
-	tenders, err := d.ucTender.GetUserTenders(r.Context(), queryParams)
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	tenders, cursors, err := d.ucTender.GetUserTenders(r.Context(), queryParams)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		if errors.Is(err, e.ErrUserIsNotResponsible) || errors.Is(err, e.ErrUserExist) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusUnauthorized, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()},
-			http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, err)
 		return
 	}
 	if tenders == nil {
@@ -170,6 +161,7 @@ func (d *DeliveryLayer) GetUserTenders(w http.ResponseWriter, r *http.Request) {
 	}
 
 	tenderOutput := dto.NewArrayTenderOutput(tenders)
+	f.SetCursors(w, cursors)
 	responseData := f.NewResponseProps(w, tenderOutput, http.StatusOK, mc.ApplicationJson)
 	f.Response(responseData)
 }
@@ -178,8 +170,7 @@ func (d *DeliveryLayer) GetTenderStatus(w http.ResponseWriter, r *http.Request)
 	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
 	if r.Method != "GET" {
 		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrMethodNotAllowed.Error()}, http.StatusMethodNotAllowed, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
 		return
 	}
 
@@ -187,42 +178,19 @@ func (d *DeliveryLayer) GetTenderStatus(w http.ResponseWriter, r *http.Request)
 	err := queryParams.GetParameters(r)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		if errors.Is(err, e.ErrExistTenderID) || errors.Is(err, e.ErrTenderID) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		if errors.Is(err, e.ErrBadPermission) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusUnauthorized, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()},
-			http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
-		return
-	}
-
-	status, err := d.ucTender.GetTenderStatus(r.Context(), queryParams)
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	tender, utilization, err := d.ucTender.GetTenderStatus(r.Context(), queryParams)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		if errors.Is(err, e.ErrNoTenders) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		if errors.Is(err, e.ErrUserExist) || errors.Is(err, e.ErrResponsibilty) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusUnauthorized, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()},
-			http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
-		return
-	}
-
-	responseData := f.NewResponseProps(w, dto.TenderStatus{Status: status}, http.StatusOK, mc.ApplicationJson)
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	w.Header().Set("ETag", f.ETag(tender.Version))
+	responseData := f.NewResponseProps(w, dto.NewTenderStatusOutput(tender, utilization), http.StatusOK, mc.ApplicationJson)
 	f.Response(responseData)
 }
 
@@ -230,8 +198,7 @@ func (d *DeliveryLayer) UpdateTenderStatus(w http.ResponseWriter, r *http.Reques
 	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
 	if r.Method != "PUT" {
 		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrMethodNotAllowed.Error()}, http.StatusMethodNotAllowed, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
 		return
 	}
 
@@ -239,45 +206,19 @@ func (d *DeliveryLayer) UpdateTenderStatus(w http.ResponseWriter, r *http.Reques
 	err := queryParams.GetParameters(r)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		if errors.Is(err, e.ErrExistTenderID) ||
-			errors.Is(err, e.ErrTenderID) ||
-			errors.Is(err, e.ErrTenderStatus) ||
-			errors.Is(err, e.ErrQPChangeStatus) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		if errors.Is(err, e.ErrBadPermission) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusUnauthorized, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()},
-			http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, err)
 		return
 	}
 
 	tender, err := d.ucTender.UpdateTenderStatus(r.Context(), queryParams)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		if errors.Is(err, e.ErrUserExist) || errors.Is(err, e.ErrResponsibilty) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusUnauthorized, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		if errors.Is(err, e.ErrNoTenders) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()},
-			http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, err)
 		return
 	}
 
 	tenderOutput := dto.NewTenderOutput(tender)
+	w.Header().Set("ETag", f.ETag(tender.Version))
 	responseData := f.NewResponseProps(w, tenderOutput, http.StatusOK, mc.ApplicationJson)
 	f.Response(responseData)
 }
@@ -286,8 +227,7 @@ func (d *DeliveryLayer) UpdateTender(w http.ResponseWriter, r *http.Request) {
 	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
 	if r.Method != "PATCH" {
 		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrMethodNotAllowed.Error()}, http.StatusMethodNotAllowed, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
 		return
 	}
 
@@ -295,64 +235,154 @@ func (d *DeliveryLayer) UpdateTender(w http.ResponseWriter, r *http.Request) {
 	err := queryParams.GetParameters(r)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		if errors.Is(err, e.ErrExistTenderID) || errors.Is(err, e.ErrTenderID) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		if errors.Is(err, e.ErrBadPermission) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusUnauthorized, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()},
-			http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, err)
 		return
 	}
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrRequestBody.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrRequestBody)
 		return
 	}
 	var tenderData dto.TenderUpdateDataInput
 	err = json.Unmarshal(body, &tenderData)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrRequestBody.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrRequestBody)
 		return
 	}
 	isValid, err := f.Validate(tenderData)
 	if err != nil || !isValid {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrRequestBody.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrRequestBody)
 		return
 	}
 	tender, err := d.ucTender.UpdateTender(r.Context(), &tenderData, queryParams)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		if errors.Is(err, e.ErrUserExist) || errors.Is(err, e.ErrResponsibilty) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusUnauthorized, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		if errors.Is(err, e.ErrNoTenders) || errors.Is(err, e.ErrQPServiceType) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: err.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()},
-			http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	tenderOutput := dto.NewTenderOutput(tender)
+	w.Header().Set("ETag", f.ETag(tender.Version))
+	responseData := f.NewResponseProps(w, tenderOutput, http.StatusOK, mc.ApplicationJson)
+	f.Response(responseData)
+}
+
+func (d *DeliveryLayer) RollbackTender(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
+	if r.Method != "PUT" {
+		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
+		return
+	}
+
+	queryParams := new(tqp.TenderRollback)
+	err := queryParams.GetParameters(r)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	tender, err := d.ucTender.RollbackTender(r.Context(), queryParams)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
 		return
 	}
 
 	tenderOutput := dto.NewTenderOutput(tender)
+	w.Header().Set("ETag", f.ETag(tender.Version))
 	responseData := f.NewResponseProps(w, tenderOutput, http.StatusOK, mc.ApplicationJson)
 	f.Response(responseData)
 }
+
+func (d *DeliveryLayer) GetTenderVersions(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
+	if r.Method != "GET" {
+		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
+		return
+	}
+
+	queryParams := new(tqp.TenderVersions)
+	err := queryParams.GetParameters(r)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	versions, err := d.ucTender.GetTenderVersions(r.Context(), queryParams)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+	if versions == nil {
+		versions = make([]*ent.TenderVersion, 0)
+	}
+
+	versionsOutput := dto.NewArrayTenderVersionOutput(versions)
+	responseData := f.NewResponseProps(w, versionsOutput, http.StatusOK, mc.ApplicationJson)
+	f.Response(responseData)
+}
+
+func (d *DeliveryLayer) GetTenderAward(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
+	if r.Method != "GET" {
+		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
+		return
+	}
+
+	queryParams := new(tqp.TenderAward)
+	err := queryParams.GetParameters(r)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	award, err := d.ucTender.GetTenderAward(r.Context(), queryParams)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	awardOutput := dto.NewTenderAwardOutput(award)
+	responseData := f.NewResponseProps(w, awardOutput, http.StatusOK, mc.ApplicationJson)
+	f.Response(responseData)
+}
+
+func (d *DeliveryLayer) GetTenderLots(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
+	if r.Method != "GET" {
+		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
+		return
+	}
+
+	queryParams := new(tqp.TenderLots)
+	err := queryParams.GetParameters(r)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	lots, err := d.ucTender.GetTenderLots(r.Context(), queryParams)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	lotsOutput := dto.NewArrayLotOutput(lots)
+	responseData := f.NewResponseProps(w, lotsOutput, http.StatusOK, mc.ApplicationJson)
+	f.Response(responseData)
+}
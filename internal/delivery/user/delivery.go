@@ -1,17 +1,13 @@
 package user
 
 import (
-	"database/sql"
 	"encoding/json"
-	"errors"
 	"io"
 	"net/http"
-	ent "tender-workspace/internal/entity"
 	"tender-workspace/internal/entity/dto"
 	ucUser "tender-workspace/internal/usecase/user"
 	f "tender-workspace/internal/utils/functions"
 	mc "tender-workspace/internal/utils/myconstants"
-	"tender-workspace/internal/utils/myerrors"
 	e "tender-workspace/internal/utils/myerrors"
 
 	"github.com/gorilla/mux"
@@ -34,44 +30,34 @@ func (d *DeliveryLayer) CreateUser(w http.ResponseWriter, r *http.Request) {
 	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
 	if r.Method != "POST" {
 		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrMethodNotAllowed.Error()}, http.StatusMethodNotAllowed, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
 		return
 	}
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrRequestBody.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrRequestBody)
 		return
 	}
 	var userData dto.UserInput
 	err = json.Unmarshal(body, &userData)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrRequestBody.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrRequestBody)
 		return
 	}
 	isValid, err := f.Validate(userData)
 	if err != nil || !isValid {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrRequestBody.Error()}, http.StatusBadRequest, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrRequestBody)
 		return
 	}
 
 	user, err := d.ucUser.Create(r.Context(), &userData)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		if errors.Is(err, myerrors.ErrUserAlreadyExist) {
-			propsError := f.NewResponseProps(w, ent.ResponseError{Error: err.Error()}, http.StatusUnauthorized, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()}, http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, err)
 		return
 	}
 
@@ -84,23 +70,14 @@ func (d *DeliveryLayer) GetUser(w http.ResponseWriter, r *http.Request) {
 	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
 	if r.Method != "GET" {
 		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrMethodNotAllowed.Error()}, http.StatusMethodNotAllowed, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
 		return
 	}
 	username := mux.Vars(r)["username"]
 	user, err := d.ucUser.GetData(r.Context(), username)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		if errors.Is(err, sql.ErrNoRows) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrUserNotExist.Error()},
-				http.StatusNotFound, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()},
-			http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, err)
 		return
 	}
 	userOutput := newUserOutput(user)
@@ -112,23 +89,14 @@ func (d *DeliveryLayer) GetUserOrganizationsIds(w http.ResponseWriter, r *http.R
 	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
 	if r.Method != "GET" {
 		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
-		propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrMethodNotAllowed.Error()}, http.StatusMethodNotAllowed, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
 		return
 	}
 	username := mux.Vars(r)["username"]
 	ids, err := d.ucUser.GetUserOrganizations(r.Context(), username)
 	if err != nil {
 		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
-		if errors.Is(err, e.ErrUserExist) {
-			propsError := f.NewResponseProps(w, ent.ResponseReason{Reason: e.ErrUserNotExist.Error()},
-				http.StatusUnauthorized, mc.ApplicationJson)
-			f.Response(propsError)
-			return
-		}
-		propsError := f.NewResponseProps(w, ent.ResponseError{Error: e.ErrInternal.Error()},
-			http.StatusInternalServerError, mc.ApplicationJson)
-		f.Response(propsError)
+		f.ResponseError(w, r, err)
 		return
 	}
 	if ids == nil {
@@ -137,3 +105,43 @@ func (d *DeliveryLayer) GetUserOrganizationsIds(w http.ResponseWriter, r *http.R
 	responseData := f.NewResponseProps(w, ids, http.StatusOK, mc.ApplicationJson)
 	f.Response(responseData)
 }
+
+func (d *DeliveryLayer) Login(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
+	if r.Method != "POST" {
+		d.logger.Info(e.ErrMethodNotAllowed.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrRequestBody)
+		return
+	}
+	var credentials dto.LoginInput
+	err = json.Unmarshal(body, &credentials)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrRequestBody)
+		return
+	}
+	isValid, err := f.Validate(credentials)
+	if err != nil || !isValid {
+		d.logger.Info(e.ErrRequestBody.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, e.ErrRequestBody)
+		return
+	}
+
+	authToken, err := d.ucUser.Login(r.Context(), &credentials)
+	if err != nil {
+		d.logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+		f.ResponseError(w, r, err)
+		return
+	}
+
+	tokenOutput := newTokenOutput(authToken)
+	responseData := f.NewResponseProps(w, tokenOutput, http.StatusOK, mc.ApplicationJson)
+	f.Response(responseData)
+}
@@ -15,3 +15,10 @@ func newUserOutput(uData *entity.Employee) *dto.UserOutPut {
 		CreatedAt: functions.FormatTime(uData.CreatedAt),
 	}
 }
+
+func newTokenOutput(authToken *entity.AuthToken) *dto.TokenOutput {
+	return &dto.TokenOutput{
+		Token:     authToken.Token,
+		ExpiresAt: functions.FormatTime(authToken.ExpiresAt),
+	}
+}
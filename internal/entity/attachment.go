@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// Attachment
+// File attached to the tender or to the bid, exactly one of TenderID and BidID is set.
+// Content is kept in the blob storage under StorageKey.
+type Attachment struct {
+	ID          int
+	TenderID    int
+	BidID       int
+	Name        string
+	ContentType string
+	Size        int64
+	SHA256      string // hex
+	StorageKey  string
+	CreatorID   int
+	CreatedAt   time.Time
+}
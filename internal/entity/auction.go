@@ -0,0 +1,62 @@
+package entity
+
+import (
+	mc "tender-workspace/internal/utils/myconstants"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Auction
+// Reverse auction of the tender: bidders lower price of their published bids while the auction runs,
+// an offer placed close to the end moves the end further.
+type Auction struct {
+	TenderID        int
+	Currency        string
+	StartPrice      decimal.Decimal
+	MinDecrement    decimal.Decimal
+	StartsAt        time.Time
+	EndsAt          time.Time
+	ExtensionWindow time.Duration // ставка за это время до окончания продлевает торги
+	Extension       time.Duration // окончание переносится не раньше чем на это время после ставки
+	ClosedAt        *time.Time
+	WinnerBidID     int
+}
+
+// Status
+// Running auction accepts offers, ended auction waits for the scheduler to award the lowest offer.
+func (a *Auction) Status(now time.Time) string {
+	switch {
+	case a.ClosedAt != nil || !now.Before(a.EndsAt):
+		return mc.AuctionEnded
+	case now.Before(a.StartsAt):
+		return mc.AuctionScheduled
+	default:
+		return mc.AuctionRunning
+	}
+}
+
+type AuctionOffer struct {
+	ID        int
+	TenderID  int
+	BidID     int
+	Price     decimal.Decimal
+	CreatedAt time.Time
+}
+
+// AuctionRanking
+// Live ranking of the auction, bids are ordered by their lowest offer.
+type AuctionRanking struct {
+	Auction   *Auction
+	BestPrice *decimal.Decimal // nil - ставок еще не было
+	Ranks     []*AuctionRank
+}
+
+type AuctionRank struct {
+	Rank     int
+	BidID    int
+	BidName  string
+	Price    decimal.Decimal
+	PlacedAt time.Time
+	Offers   int
+}
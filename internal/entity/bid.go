@@ -1,6 +1,10 @@
 package entity
 
-import "time"
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
 
 type Bid struct {
 	ID             int
@@ -13,9 +17,89 @@ type Bid struct {
 	AuthorType     string
 	OrganizationID int
 	CreatedAt      time.Time
+	// Offer ценовое предложение, nil если позиции не заданы
+	Offer *BidOffer
+	// OverBudget итог предложения выше бюджета тендера с политикой Flag
+	OverBudget bool
+	// Commitment хэш содержимого закрытого предложения, пустой для открытых
+	Commitment string
+	RevealedAt *time.Time
+	// Lots лоты тендера, на которые подано предложение, загружаются при создании и в списках
+	Lots []*BidLot
+}
+
+// IsSealed содержимое закрытого предложения еще не раскрыто
+func (b *Bid) IsSealed() bool {
+	return b.Commitment != "" && b.RevealedAt == nil
+}
+
+// BidOffer
+// Priced offer of the bid. Items are loaded only when they are requested,
+// totals are always present.
+type BidOffer struct {
+	Version  int // версия предложения, в которой позиции были заданы
+	Currency string
+	TotalNet decimal.Decimal
+	TotalVat decimal.Decimal
+	Total    decimal.Decimal
+	Items    []*BidItem
+}
+
+// BidItem line item of the offer, amounts are rounded to cents
+type BidItem struct {
+	Position  int
+	Name      string
+	Quantity  decimal.Decimal
+	Unit      string
+	UnitPrice decimal.Decimal
+	VatRate   decimal.Decimal // процент
+	Currency  string
+	NetAmount decimal.Decimal
+	VatAmount decimal.Decimal
+	Amount    decimal.Decimal
 }
 
 type BidUpdateData struct {
 	Name        string
 	Description string
 }
+
+type BidVersion struct {
+	BidID       int
+	Name        string
+	Description string
+	Status      string
+	Version     int
+	CreatedAt   time.Time
+	Offer       *BidOffer
+}
+
+// BidFieldChange describes how a single field of the bid differs between two versions
+type BidFieldChange struct {
+	Field string
+	From  string
+	To    string
+}
+
+type BidDiff struct {
+	BidID       int
+	FromVersion int
+	ToVersion   int
+	Changes     []*BidFieldChange
+}
+
+// BidDecisionTally shows how many approvers have voted for the bid
+type BidDecisionTally struct {
+	Approvals  int
+	Rejections int
+	Quorum     int
+}
+
+type BidDecisionResult struct {
+	Bid   *Bid
+	Tally *BidDecisionTally
+	// решение по лоту, пустые значения для тендера без лотов
+	LotID        int
+	LotStatus    string
+	TenderClosed bool
+}
@@ -0,0 +1,21 @@
+package dto
+
+import "io"
+
+// INPUT DTO (REQUEST BODY) -
+// AttachmentInput file part of the multipart upload
+type AttachmentInput struct {
+	Name        string
+	ContentType string // declared by the client, checked against the content
+	Content     io.Reader
+}
+
+// OUTPUT DTO (RESPONSE BODY)
+type AttachmentOutput struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+	SHA256      string `json:"sha256"`
+	CreatedAt   string `json:"createdAt"`
+}
@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// INPUT DTO (REQUEST BODY) -
+// AuctionInput makes the tender reverse auction, it's checked together with the tender
+type AuctionInput struct {
+	Currency     string          `json:"currency"`
+	StartPrice   decimal.Decimal `json:"startPrice"`   // first offer must not exceed it
+	MinDecrement decimal.Decimal `json:"minDecrement"` // next offer must be lower than the best one at least by it
+	StartsAt     time.Time       `json:"startsAt"`     // RFC 3339
+	EndsAt       time.Time       `json:"endsAt"`
+	// offer placed less than 'extensionWindow' seconds before the end
+	// moves the end to 'extension' seconds after the offer
+	ExtensionWindow int `json:"extensionWindow,omitempty"`
+	Extension       int `json:"extension,omitempty"`
+}
+
+type AuctionOfferInput struct {
+	Price decimal.Decimal `json:"price" valid:"-"` // checked against the auction
+}
+
+// OUTPUT DTO (RESPONSE BODY)
+type AuctionOutput struct {
+	Currency        string          `json:"currency"`
+	StartPrice      decimal.Decimal `json:"startPrice"`
+	MinDecrement    decimal.Decimal `json:"minDecrement"`
+	StartsAt        string          `json:"startsAt"`
+	EndsAt          string          `json:"endsAt"`
+	ExtensionWindow int             `json:"extensionWindow"`
+	Extension       int             `json:"extension"`
+	Status          string          `json:"status"`
+	WinnerBidID     int             `json:"winnerBidId,omitempty"`
+}
+
+type AuctionRankingOutput struct {
+	TenderID  int                  `json:"tenderId"`
+	Auction   *AuctionOutput       `json:"auction"`
+	BestPrice *decimal.Decimal     `json:"bestPrice,omitempty"`
+	Bids      []*AuctionRankOutput `json:"bids"`
+}
+
+type AuctionRankOutput struct {
+	Rank     int             `json:"rank"`
+	BidID    int             `json:"bidId"`
+	BidName  string          `json:"bidName"`
+	Price    decimal.Decimal `json:"price"`
+	PlacedAt string          `json:"placedAt"`
+	Offers   int             `json:"offers"`
+}
@@ -1,5 +1,12 @@
 package dto
 
+import (
+	"encoding/json"
+	e "tender-workspace/internal/utils/myerrors"
+
+	"github.com/shopspring/decimal"
+)
+
 // INPUT DTO (REQUEST BODY)
 type BidInput struct {
 	Name            string `json:"name" valid:"name"`
@@ -7,12 +14,69 @@ type BidInput struct {
 	Status          string `json:"status" valid:"-"`
 	TenderID        int    `json:"tenderId" valid:"-"`
 	OrganizationID  int    `json:"organizationId" valid:"-"`
-	CreatorUsername string `json:"creatorUsername" valid:"username"`
+	CreatorUsername string `json:"-" valid:"-"` // taken from bearer token
+	// Items priced offer, bid without items has no totals
+	Items []BidItemInput `json:"items,omitempty" valid:"optional"`
+	// LotIDs lots of the tender targeted by the bid, required for the tender with lots
+	LotIDs []int `json:"lotIds,omitempty" valid:"-"`
+}
+
+// SealedBidInput
+// Bid to the sealed tender. Its content is not sent until submission deadline,
+// only the commitment: hex encoded SHA-256 of the nonce followed by the content.
+type SealedBidInput struct {
+	Commitment      string `json:"commitment" valid:"commitment"`
+	Status          string `json:"status" valid:"-"`
+	TenderID        int    `json:"tenderId" valid:"-"`
+	OrganizationID  int    `json:"organizationId" valid:"-"`
+	CreatorUsername string `json:"-" valid:"-"` // taken from bearer token
+	// targeted lots aren't sealed
+	LotIDs []int `json:"lotIds,omitempty" valid:"-"`
+}
+
+// BidRevealInput
+// Content is hashed exactly as it's sent, so it's kept raw
+// and parsed into Data after the body is read.
+type BidRevealInput struct {
+	Nonce   string             `json:"nonce" valid:"-"`
+	Content json.RawMessage    `json:"content" valid:"-"`
+	Data    BidUpdateDataInput `json:"-" valid:"-"`
 }
 
 type BidUpdateDataInput struct {
 	Name        string `json:"name" valid:"name"`
 	Description string `json:"description" valid:"description"`
+	// Items replace the whole offer, omitted items keep the current one
+	Items []BidItemInput `json:"items,omitempty" valid:"optional"`
+}
+
+// BidItemInput
+// Line item of the offer. Numbers are accepted both as JSON numbers and strings,
+// they are parsed as decimals, so no precision is lost.
+type BidItemInput struct {
+	Name      string          `json:"name" valid:"name"`
+	Quantity  decimal.Decimal `json:"quantity" valid:"quantity"`
+	Unit      string          `json:"unit" valid:"unit"`
+	UnitPrice decimal.Decimal `json:"unitPrice" valid:"price"`
+	VatRate   decimal.Decimal `json:"vatRate" valid:"vatRate"` // percent
+	Currency  string          `json:"currency" valid:"currency"`
+}
+
+const maxBidItems = 100
+
+// ValidateBidItems
+// Checks rules that concern the whole offer: items are summed up,
+// so they must be in one currency.
+func ValidateBidItems(items []BidItemInput) error {
+	if len(items) > maxBidItems {
+		return e.ErrBidItems
+	}
+	for _, item := range items {
+		if item.Currency != items[0].Currency {
+			return e.ErrBidItems
+		}
+	}
+	return nil
 }
 
 // OUTPUT DTO (RESPONSE BODY)
@@ -24,8 +88,71 @@ type BidOutput struct {
 	AuthorID   int    `json:"authorId"`
 	Version    int    `json:"version"`
 	CreatedAt  string `json:"createdAt"`
+	// итоги отдаются только для предложений с позициями
+	Currency string           `json:"currency,omitempty"`
+	TotalNet *decimal.Decimal `json:"totalNet,omitempty"`
+	TotalVat *decimal.Decimal `json:"totalVat,omitempty"`
+	Total    *decimal.Decimal `json:"total,omitempty"`
+	// exceeds budget of the tender, such bids are accepted only with Flag policy
+	OverBudget bool `json:"overBudget,omitempty"`
+	// sealed bid has no content until it's revealed
+	Sealed     bool   `json:"sealed,omitempty"`
+	Commitment string `json:"commitment,omitempty"`
+	RevealedAt string `json:"revealedAt,omitempty"`
+	// лоты отдаются, только если они загружены
+	Lots []*BidLotOutput `json:"lots,omitempty"`
+}
+
+type BidItemOutput struct {
+	Position  int             `json:"position"`
+	Name      string          `json:"name"`
+	Quantity  decimal.Decimal `json:"quantity"`
+	Unit      string          `json:"unit"`
+	UnitPrice decimal.Decimal `json:"unitPrice"`
+	VatRate   decimal.Decimal `json:"vatRate"`
+	Currency  string          `json:"currency"`
+	NetAmount decimal.Decimal `json:"netAmount"`
+	VatAmount decimal.Decimal `json:"vatAmount"`
+	Amount    decimal.Decimal `json:"amount"`
+}
+
+type BidOfferOutput struct {
+	BidID    int              `json:"bidId"`
+	Currency string           `json:"currency"`
+	TotalNet decimal.Decimal  `json:"totalNet"`
+	TotalVat decimal.Decimal  `json:"totalVat"`
+	Total    decimal.Decimal  `json:"total"`
+	Items    []*BidItemOutput `json:"items"`
+}
+
+type BidDecisionTallyOutput struct {
+	Approvals  int `json:"approvals"`
+	Rejections int `json:"rejections"`
+	Quorum     int `json:"quorum"`
+}
+
+type BidDecisionOutput struct {
+	*BidOutput
+	Decisions *BidDecisionTallyOutput `json:"decisions"`
+	// decision on the lot, tender is closed when all its lots are awarded
+	LotID        int    `json:"lotId,omitempty"`
+	LotStatus    string `json:"lotStatus,omitempty"`
+	TenderClosed bool   `json:"tenderClosed,omitempty"`
 }
 
 type BidStatus struct {
 	Status string `json:"status"`
 }
+
+type BidFieldChangeOutput struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+type BidDiffOutput struct {
+	BidID       int                     `json:"bidId"`
+	FromVersion int                     `json:"fromVersion"`
+	ToVersion   int                     `json:"toVersion"`
+	Changes     []*BidFieldChangeOutput `json:"changes"`
+}
@@ -0,0 +1,51 @@
+package dto
+
+import "github.com/shopspring/decimal"
+
+// INPUT DTO (REQUEST BODY) -
+type CriterionInput struct {
+	Name   string          `json:"name" valid:"name"`
+	Weight decimal.Decimal `json:"weight" valid:"weight"`
+}
+
+type BidScoresInput struct {
+	Scores []ScoreInput `json:"scores" valid:"-"` // checked against criteria of the tender
+}
+
+type ScoreInput struct {
+	CriterionID int `json:"criterionId"`
+	Score       int `json:"score"`
+}
+
+// OUTPUT DTO (RESPONSE BODY)
+type CriterionOutput struct {
+	ID     int             `json:"id"`
+	Name   string          `json:"name"`
+	Weight decimal.Decimal `json:"weight"`
+}
+
+type BidScoreOutput struct {
+	CriterionID int `json:"criterionId"`
+	Score       int `json:"score"`
+}
+
+type ScoreMatrixOutput struct {
+	TenderID    int                     `json:"tenderId"`
+	Aggregation string                  `json:"aggregation"`
+	Criteria    []*CriterionOutput      `json:"criteria"`
+	Bids        []*ScoreMatrixRowOutput `json:"bids"`
+}
+
+type ScoreMatrixRowOutput struct {
+	Rank    int                     `json:"rank,omitempty"` // absent until the bid is scored by every criterion
+	BidID   int                     `json:"bidId"`
+	BidName string                  `json:"bidName"`
+	Scores  []*CriterionScoreOutput `json:"scores"`
+	Total   *decimal.Decimal        `json:"total"` // null until the bid is scored by every criterion
+}
+
+type CriterionScoreOutput struct {
+	CriterionID int              `json:"criterionId"`
+	Score       *decimal.Decimal `json:"score"`
+	Scorers     int              `json:"scorers"`
+}
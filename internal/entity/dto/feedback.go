@@ -0,0 +1,8 @@
+package dto
+
+// OUTPUT DTO (RESPONSE BODY)
+type BidReviewOutput struct {
+	ID          int    `json:"id"`
+	Description string `json:"description"`
+	CreatedAt   string `json:"createdAt"`
+}
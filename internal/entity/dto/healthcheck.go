@@ -0,0 +1,28 @@
+package dto
+
+// OUTPUT DTO (RESPONSE BODY)
+type PoolStatsOutput struct {
+	MaxConns                int32 `json:"maxConns"`
+	TotalConns              int32 `json:"totalConns"`
+	AcquiredConns           int32 `json:"acquiredConns"`
+	IdleConns               int32 `json:"idleConns"`
+	ConstructingConns       int32 `json:"constructingConns"`
+	AcquireCount            int64 `json:"acquireCount"`
+	EmptyAcquireCount       int64 `json:"emptyAcquireCount"`
+	CanceledAcquireCount    int64 `json:"canceledAcquireCount"`
+	AcquireDurationMs       int64 `json:"acquireDurationMs"`
+	MaxLifetimeDestroyCount int64 `json:"maxLifetimeDestroyCount"`
+	MaxIdleDestroyCount     int64 `json:"maxIdleDestroyCount"`
+}
+
+type HealthOutput struct {
+	Status string                        `json:"status"`
+	Checks map[string]*HealthCheckOutput `json:"checks,omitempty"`
+}
+
+type HealthCheckOutput struct {
+	Status    string         `json:"status"`
+	LatencyMs int64          `json:"latencyMs"`
+	Error     string         `json:"error,omitempty"`
+	Details   map[string]any `json:"details,omitempty"`
+}
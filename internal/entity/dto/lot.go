@@ -0,0 +1,31 @@
+package dto
+
+import "github.com/shopspring/decimal"
+
+// INPUT DTO (REQUEST BODY) -
+type LotInput struct {
+	Name        string `json:"name" valid:"name"`
+	Description string `json:"description" valid:"description"`
+	// budget of the lot, currency defaults to the tender budget one
+	Budget         *decimal.Decimal `json:"budget,omitempty" valid:"-"`
+	BudgetCurrency string           `json:"budgetCurrency,omitempty" valid:"-"`
+}
+
+// OUTPUT DTO (RESPONSE BODY)
+type LotOutput struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// сумма скрытого бюджета не отдается участникам
+	Budget         *decimal.Decimal `json:"budget,omitempty"`
+	BudgetCurrency string           `json:"budgetCurrency,omitempty"`
+	// победитель отдается, только если лот присужден
+	WinnerBidID            int    `json:"winnerBidId,omitempty"`
+	ExecutorOrganizationID int    `json:"executorOrganizationId,omitempty"`
+	AwardedAt              string `json:"awardedAt,omitempty"`
+}
+
+type BidLotOutput struct {
+	LotID  int    `json:"lotId"`
+	Status string `json:"status"`
+}
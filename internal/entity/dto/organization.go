@@ -5,6 +5,8 @@ type OrganizationInput struct {
 	Name        string `json:"name" valid:"name"`
 	Description string `json:"description" valid:"-"`
 	Type        string `json:"type" valid:"-"`
+	// CreatorUsername taken from bearer token, creator becomes responsible for the organization
+	CreatorUsername string `json:"-" valid:"-"`
 }
 
 // OUTPUT DATA FLOW
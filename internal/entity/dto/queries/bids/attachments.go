@@ -0,0 +1,72 @@
+package queries
+
+import (
+	"net/http"
+	"strconv"
+	f "tender-workspace/internal/utils/functions"
+	e "tender-workspace/internal/utils/myerrors"
+
+	"github.com/gorilla/mux"
+)
+
+// Used for upload and get list of bid attachments
+type BidAttachments struct {
+	BidID    int
+	Username string
+}
+
+func (q *BidAttachments) GetParameters(r *http.Request) error {
+	bidIdStr := mux.Vars(r)["bidId"]
+	if bidIdStr == "" {
+		return e.ErrExistBidID
+	}
+	bidId, err := strconv.Atoi(bidIdStr)
+	if err != nil || bidId < 1 {
+		return e.ErrBidID
+	}
+	q.BidID = bidId
+
+	username, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		return e.ErrUnauthorized
+	}
+	q.Username = username
+	return nil
+}
+
+// Used for download of bid attachment
+type BidAttachment struct {
+	BidID        int
+	AttachmentID int
+	Username     string
+}
+
+func (q *BidAttachment) GetParameters(r *http.Request) error {
+	vars := mux.Vars(r)
+	bidIdStr := vars["bidId"]
+	if bidIdStr == "" {
+		return e.ErrExistBidID
+	}
+	bidId, err := strconv.Atoi(bidIdStr)
+	if err != nil || bidId < 1 {
+		return e.ErrBidID
+	}
+	q.BidID = bidId
+
+	attachmentIdStr := vars["attachmentId"]
+	if attachmentIdStr == "" {
+		return e.ErrExistAttachmentID
+	}
+	attachmentId, err := strconv.Atoi(attachmentIdStr)
+	if err != nil || attachmentId < 1 {
+		return e.ErrAttachmentID
+	}
+	q.AttachmentID = attachmentId
+
+	username, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		return e.ErrUnauthorized
+	}
+	q.Username = username
+	return nil
+}
@@ -3,7 +3,11 @@ package queries
 import (
 	"net/http"
 	"strconv"
+	f "tender-workspace/internal/utils/functions"
 	e "tender-workspace/internal/utils/myerrors"
+	"unicode/utf8"
+
+	"github.com/gorilla/mux"
 )
 
 type BidFeedback struct {
@@ -14,29 +18,84 @@ type BidFeedback struct {
 
 func (q *BidFeedback) GetParameters(r *http.Request) error {
 	q.BidID = 0 // explicit
-	bidIdStr := r.Header.Get("bidId")
+	bidIdStr := mux.Vars(r)["bidId"]
 	if bidIdStr == "" {
-		return e.ErrExistTenderID
+		return e.ErrExistBidID
 	}
 	bidId, err := strconv.Atoi(bidIdStr)
-	if err != nil {
-		return err
-	}
-	if bidId < 1 {
-		return e.ErrTenderID
+	if err != nil || bidId < 1 {
+		return e.ErrBidID
 	}
 	q.BidID = bidId
 
-	feedback := r.Header.Get("bidFeedback")
+	queryParams := r.URL.Query()
+	feedback := queryParams.Get("bidFeedback")
 	if feedback == "" {
 		return e.ErrExistFeedback
 	}
+	if utf8.RuneCountInString(feedback) > 1000 {
+		return e.ErrQPFeedback
+	}
 	q.BidFeedback = feedback
 
-	username := r.Header.Get("username")
-	if username == "" {
-		return e.ErrBadPermission
+	username, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		return e.ErrUnauthorized
 	}
 	q.Username = username
 	return nil
 }
+
+type BidReviews struct {
+	TenderID          int
+	AuthorUsername    string
+	RequesterUsername string
+	Limit             int
+	Offset            int
+}
+
+func (q *BidReviews) GetParameters(r *http.Request) error {
+	tenderIdStr := mux.Vars(r)["tenderId"]
+	if tenderIdStr == "" {
+		return e.ErrExistTenderID
+	}
+	tenderId, err := strconv.Atoi(tenderIdStr)
+	if err != nil || tenderId < 1 {
+		return e.ErrTenderID
+	}
+	q.TenderID = tenderId
+
+	queryParams := r.URL.Query()
+	authorUsername := queryParams.Get("authorUsername")
+	if authorUsername == "" {
+		return e.ErrExistAuthor
+	}
+	q.AuthorUsername = authorUsername
+
+	requesterUsername, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		return e.ErrUnauthorized
+	}
+	q.RequesterUsername = requesterUsername
+
+	q.Limit = 5
+	limitStr := queryParams.Get("limit")
+	if limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 0 {
+			return e.ErrQPLimit
+		}
+		q.Limit = limit
+	}
+
+	q.Offset = 0 // explicit
+	offsetStr := queryParams.Get("offset")
+	if offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			return e.ErrQPOffset
+		}
+		q.Offset = offset
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package queries
+
+import (
+	"net/http"
+	"strconv"
+	f "tender-workspace/internal/utils/functions"
+	e "tender-workspace/internal/utils/myerrors"
+
+	"github.com/gorilla/mux"
+)
+
+// Used for get priced offer of the bid with line items
+type BidOffer struct {
+	BidID    int
+	Username string
+}
+
+func (q *BidOffer) GetParameters(r *http.Request) error {
+	bidIdStr := mux.Vars(r)["bidId"]
+	if bidIdStr == "" {
+		return e.ErrExistBidID
+	}
+	bidId, err := strconv.Atoi(bidIdStr)
+	if err != nil || bidId < 1 {
+		return e.ErrBidID
+	}
+	q.BidID = bidId
+
+	username, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		return e.ErrUnauthorized
+	}
+	q.Username = username
+	return nil
+}
@@ -0,0 +1,42 @@
+package queries
+
+import (
+	"net/http"
+	"strconv"
+	f "tender-workspace/internal/utils/functions"
+	e "tender-workspace/internal/utils/myerrors"
+
+	"github.com/gorilla/mux"
+)
+
+// Used for reveal of the sealed bid content
+type BidReveal struct {
+	BidID           int
+	Username        string
+	ExpectedVersion int // from If-Match header, 0 if any version matches
+}
+
+func (q *BidReveal) GetParameters(r *http.Request) error {
+	bidIdStr := mux.Vars(r)["bidId"]
+	if bidIdStr == "" {
+		return e.ErrExistBidID
+	}
+	bidId, err := strconv.Atoi(bidIdStr)
+	if err != nil || bidId < 1 {
+		return e.ErrBidID
+	}
+	q.BidID = bidId
+
+	expectedVersion, err := f.GetIfMatchVersion(r)
+	if err != nil {
+		return err
+	}
+	q.ExpectedVersion = expectedVersion
+
+	username, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		return e.ErrUnauthorized
+	}
+	q.Username = username
+	return nil
+}
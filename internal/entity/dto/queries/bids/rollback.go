@@ -0,0 +1,92 @@
+package queries
+
+import (
+	"net/http"
+	"strconv"
+	f "tender-workspace/internal/utils/functions"
+	e "tender-workspace/internal/utils/myerrors"
+
+	"github.com/gorilla/mux"
+)
+
+type BidRollback struct {
+	BidID           int
+	Version         int
+	Username        string
+	ExpectedVersion int // from If-Match header, 0 if any version matches
+}
+
+func (q *BidRollback) GetParameters(r *http.Request) error {
+	vars := mux.Vars(r)
+	bidIdStr := vars["bidId"]
+	if bidIdStr == "" {
+		return e.ErrExistBidID
+	}
+	bidId, err := strconv.Atoi(bidIdStr)
+	if err != nil || bidId < 1 {
+		return e.ErrBidID
+	}
+	q.BidID = bidId
+
+	versionStr := vars["version"]
+	if versionStr == "" {
+		return e.ErrExistVersion
+	}
+	version, err := strconv.Atoi(versionStr)
+	if err != nil || version < 1 {
+		return e.ErrVersion
+	}
+	q.Version = version
+
+	expectedVersion, err := f.GetIfMatchVersion(r)
+	if err != nil {
+		return err
+	}
+	q.ExpectedVersion = expectedVersion
+
+	username, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		return e.ErrUnauthorized
+	}
+	q.Username = username
+	return nil
+}
+
+// Used for comparing two versions of the bid
+type BidDiff struct {
+	BidID       int
+	FromVersion int
+	ToVersion   int
+	Username    string
+}
+
+func (q *BidDiff) GetParameters(r *http.Request) error {
+	bidIdStr := mux.Vars(r)["bidId"]
+	if bidIdStr == "" {
+		return e.ErrExistBidID
+	}
+	bidId, err := strconv.Atoi(bidIdStr)
+	if err != nil || bidId < 1 {
+		return e.ErrBidID
+	}
+	q.BidID = bidId
+
+	queryParams := r.URL.Query()
+	fromVersion, err := strconv.Atoi(queryParams.Get("from"))
+	if err != nil || fromVersion < 1 {
+		return e.ErrQPDiffVersions
+	}
+	q.FromVersion = fromVersion
+	toVersion, err := strconv.Atoi(queryParams.Get("to"))
+	if err != nil || toVersion < 1 {
+		return e.ErrQPDiffVersions
+	}
+	q.ToVersion = toVersion
+
+	username, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		return e.ErrUnauthorized
+	}
+	q.Username = username
+	return nil
+}
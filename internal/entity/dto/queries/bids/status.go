@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	f "tender-workspace/internal/utils/functions"
 	mc "tender-workspace/internal/utils/myconstants"
 	e "tender-workspace/internal/utils/myerrors"
 
@@ -26,18 +27,19 @@ func (q *BidStatus) GetParameters(r *http.Request) error {
 	}
 	q.BidID = bidId
 
-	username := r.URL.Query().Get("username")
-	if username == "" {
-		return e.ErrBadPermission
+	username, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		return e.ErrUnauthorized
 	}
 	q.Username = username
 	return nil
 }
 
 type UpdateBidStatus struct {
-	BidID    int
-	Status   string
-	Username string
+	BidID           int
+	Status          string
+	Username        string
+	ExpectedVersion int // from If-Match header, 0 if any version matches
 }
 
 func (q *UpdateBidStatus) GetParameters(r *http.Request) error {
@@ -56,7 +58,7 @@ func (q *UpdateBidStatus) GetParameters(r *http.Request) error {
 	if status == "" {
 		return e.ErrExistStatus
 	}
-	
+
 	statusLower := strings.ToLower(status)
 	if _, ok := mc.AvaliableBidStatus[statusLower]; !ok {
 		return e.ErrQPBidStatusUpdate
@@ -64,9 +66,15 @@ func (q *UpdateBidStatus) GetParameters(r *http.Request) error {
 	runes := []rune(statusLower)
 	q.Status = strings.ToUpper(string(runes[0])) + string(runes[1:])
 
-	username := queryParams.Get("username")
-	if username == "" {
-		return e.ErrBadPermission
+	expectedVersion, err := f.GetIfMatchVersion(r)
+	if err != nil {
+		return err
+	}
+	q.ExpectedVersion = expectedVersion
+
+	username, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		return e.ErrUnauthorized
 	}
 	q.Username = username
 	return nil
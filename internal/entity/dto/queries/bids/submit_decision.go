@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	f "tender-workspace/internal/utils/functions"
 	mc "tender-workspace/internal/utils/myconstants"
 	e "tender-workspace/internal/utils/myerrors"
 
@@ -12,6 +13,7 @@ import (
 
 type SubmitDecision struct {
 	BidID    int
+	LotID    int // обязателен для предложения на тендер с лотами
 	Decision string
 	Username string
 }
@@ -30,15 +32,23 @@ func (q *SubmitDecision) GetParameters(r *http.Request) error {
 	queryParams := r.URL.Query()
 	decision := queryParams.Get("decision")
 	decisionLower := strings.ToLower(decision)
-	if _, ok := mc.AvaliableBidStatusApprover[decision]; !ok {
+	if _, ok := mc.AvaliableBidStatusApprover[decisionLower]; !ok {
 		return e.ErrQPDecision
 	}
 	runes := []rune(decisionLower)
 	q.Decision = strings.ToUpper(string(runes[0])) + string(runes[1:])
 
-	username := queryParams.Get("username")
-	if username == "" {
-		return e.ErrBadPermission
+	if lotIdStr := queryParams.Get("lot_id"); lotIdStr != "" {
+		lotId, err := strconv.Atoi(lotIdStr)
+		if err != nil || lotId < 1 {
+			return e.ErrQPLotID
+		}
+		q.LotID = lotId
+	}
+
+	username, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		return e.ErrUnauthorized
 	}
 	q.Username = username
 	return nil
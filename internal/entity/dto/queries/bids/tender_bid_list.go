@@ -3,16 +3,18 @@ package queries
 import (
 	"net/http"
 	"strconv"
+	f "tender-workspace/internal/utils/functions"
 	e "tender-workspace/internal/utils/myerrors"
+	"tender-workspace/internal/utils/pagination"
 
 	"github.com/gorilla/mux"
 )
 
 type TenderBidList struct {
 	TenderID int
+	LotID    int // 0 - предложения на все лоты
 	Username string
-	Limit    int
-	Offset   int
+	pagination.Page
 }
 
 func (q *TenderBidList) GetParameters(r *http.Request) error {
@@ -23,31 +25,24 @@ func (q *TenderBidList) GetParameters(r *http.Request) error {
 	q.TenderID = tenderId
 
 	queryParams := r.URL.Query()
-
-	username := queryParams.Get("username")
-	if username == "" {
-		return e.ErrBadPermission
+	if lotIdStr := queryParams.Get("lot_id"); lotIdStr != "" {
+		lotId, err := strconv.Atoi(lotIdStr)
+		if err != nil || lotId < 1 {
+			return e.ErrQPLotID
+		}
+		q.LotID = lotId
 	}
-	q.Username = username
 
-	q.Limit = 5
-	limitStr := queryParams.Get("limit")
-	if limitStr != "" {
-		limit, err := strconv.Atoi(limitStr)
-		if err != nil || limit < 0 {
-			return e.ErrQPLimit
-		}
-		q.Limit = limit
+	username, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		return e.ErrUnauthorized
 	}
+	q.Username = username
 
-	q.Offset = 0 // explicit
-	offsetStr := queryParams.Get("offset")
-	if offsetStr != "" {
-		offset, err := strconv.Atoi(offsetStr)
-		if err != nil || offset < 0 {
-			return e.ErrQPOffset
-		}
-		q.Offset = offset
+	page, err := pagination.ParsePage(queryParams, pagination.SortName, pagination.SortCreatedAt, pagination.SortVersion, pagination.SortTotal)
+	if err != nil {
+		return err
 	}
+	q.Page = page
 	return nil
 }
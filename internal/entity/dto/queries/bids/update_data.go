@@ -3,14 +3,16 @@ package queries
 import (
 	"net/http"
 	"strconv"
+	f "tender-workspace/internal/utils/functions"
 	e "tender-workspace/internal/utils/myerrors"
 
 	"github.com/gorilla/mux"
 )
 
 type UpdateBidData struct {
-	BidID    int
-	Username string
+	BidID           int
+	Username        string
+	ExpectedVersion int // from If-Match header, 0 if any version matches
 }
 
 func (q *UpdateBidData) GetParameters(r *http.Request) error {
@@ -24,9 +26,15 @@ func (q *UpdateBidData) GetParameters(r *http.Request) error {
 	}
 	q.BidID = bidId
 
-	username := r.URL.Query().Get("username")
-	if username == "" {
-		return e.ErrBadPermission
+	expectedVersion, err := f.GetIfMatchVersion(r)
+	if err != nil {
+		return err
+	}
+	q.ExpectedVersion = expectedVersion
+
+	username, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		return e.ErrUnauthorized
 	}
 	q.Username = username
 	return nil
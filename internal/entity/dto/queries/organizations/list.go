@@ -2,39 +2,24 @@ package queries
 
 import (
 	"net/http"
-	"strconv"
 	"strings"
 	mc "tender-workspace/internal/utils/myconstants"
 	e "tender-workspace/internal/utils/myerrors"
+	"tender-workspace/internal/utils/pagination"
 )
 
 type OrganizationList struct {
-	Type   string
-	Limit  int
-	Offset int
+	Type string
+	pagination.Page
 }
 
 func (q *OrganizationList) GetParameters(r *http.Request) error {
 	queryParams := r.URL.Query()
-	q.Limit = 5
-	limitStr := queryParams.Get("limit")
-	if limitStr != "" {
-		limit, err := strconv.Atoi(limitStr)
-		if err != nil || limit < 0 {
-			return e.ErrQPLimit
-		}
-		q.Limit = limit
-	}
-
-	q.Offset = 0 // explicit
-	offsetStr := queryParams.Get("offset")
-	if offsetStr != "" {
-		offset, err := strconv.Atoi(offsetStr)
-		if err != nil || offset < 0 {
-			return e.ErrQPOffset
-		}
-		q.Offset = offset
+	page, err := pagination.ParsePage(queryParams, pagination.SortName, pagination.SortCreatedAt)
+	if err != nil {
+		return err
 	}
+	q.Page = page
 
 	q.Type = "" // explicit
 	orgType := queryParams.Get("type")
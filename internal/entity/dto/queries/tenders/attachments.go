@@ -0,0 +1,72 @@
+package queries
+
+import (
+	"net/http"
+	"strconv"
+	f "tender-workspace/internal/utils/functions"
+	e "tender-workspace/internal/utils/myerrors"
+
+	"github.com/gorilla/mux"
+)
+
+// Used for upload and get list of tender attachments
+type TenderAttachments struct {
+	TenderID int
+	Username string
+}
+
+func (q *TenderAttachments) GetParameters(r *http.Request) error {
+	tenderIdStr := mux.Vars(r)["tenderId"]
+	if tenderIdStr == "" {
+		return e.ErrExistTenderID
+	}
+	tenderId, err := strconv.Atoi(tenderIdStr)
+	if err != nil || tenderId < 1 {
+		return e.ErrTenderID
+	}
+	q.TenderID = tenderId
+
+	username, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		return e.ErrUnauthorized
+	}
+	q.Username = username
+	return nil
+}
+
+// Used for download of tender attachment
+type TenderAttachment struct {
+	TenderID     int
+	AttachmentID int
+	Username     string
+}
+
+func (q *TenderAttachment) GetParameters(r *http.Request) error {
+	vars := mux.Vars(r)
+	tenderIdStr := vars["tenderId"]
+	if tenderIdStr == "" {
+		return e.ErrExistTenderID
+	}
+	tenderId, err := strconv.Atoi(tenderIdStr)
+	if err != nil || tenderId < 1 {
+		return e.ErrTenderID
+	}
+	q.TenderID = tenderId
+
+	attachmentIdStr := vars["attachmentId"]
+	if attachmentIdStr == "" {
+		return e.ErrExistAttachmentID
+	}
+	attachmentId, err := strconv.Atoi(attachmentIdStr)
+	if err != nil || attachmentId < 1 {
+		return e.ErrAttachmentID
+	}
+	q.AttachmentID = attachmentId
+
+	username, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		return e.ErrUnauthorized
+	}
+	q.Username = username
+	return nil
+}
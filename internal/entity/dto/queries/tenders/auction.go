@@ -0,0 +1,35 @@
+package queries
+
+import (
+	"net/http"
+	"strconv"
+	f "tender-workspace/internal/utils/functions"
+	e "tender-workspace/internal/utils/myerrors"
+
+	"github.com/gorilla/mux"
+)
+
+// Used for get live ranking of reverse auction
+type AuctionRanking struct {
+	TenderID int
+	Username string
+}
+
+func (q *AuctionRanking) GetParameters(r *http.Request) error {
+	tenderIdStr := mux.Vars(r)["tenderId"]
+	if tenderIdStr == "" {
+		return e.ErrExistTenderID
+	}
+	tenderId, err := strconv.Atoi(tenderIdStr)
+	if err != nil || tenderId < 1 {
+		return e.ErrTenderID
+	}
+	q.TenderID = tenderId
+
+	username, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		return e.ErrUnauthorized
+	}
+	q.Username = username
+	return nil
+}
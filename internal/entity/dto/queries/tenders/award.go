@@ -0,0 +1,34 @@
+package queries
+
+import (
+	"net/http"
+	"strconv"
+	f "tender-workspace/internal/utils/functions"
+	e "tender-workspace/internal/utils/myerrors"
+
+	"github.com/gorilla/mux"
+)
+
+type TenderAward struct {
+	TenderID int
+	Username string
+}
+
+func (q *TenderAward) GetParameters(r *http.Request) error {
+	tenderIdStr := mux.Vars(r)["tenderId"]
+	if tenderIdStr == "" {
+		return e.ErrExistTenderID
+	}
+	tenderId, err := strconv.Atoi(tenderIdStr)
+	if err != nil || tenderId < 1 {
+		return e.ErrTenderID
+	}
+	q.TenderID = tenderId
+
+	username, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		return e.ErrUnauthorized
+	}
+	q.Username = username
+	return nil
+}
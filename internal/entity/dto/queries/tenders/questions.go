@@ -0,0 +1,112 @@
+package queries
+
+import (
+	"net/http"
+	"strconv"
+	f "tender-workspace/internal/utils/functions"
+	e "tender-workspace/internal/utils/myerrors"
+	"tender-workspace/internal/utils/pagination"
+
+	"github.com/gorilla/mux"
+)
+
+// Used for asking clarification question on the tender
+type AskQuestion struct {
+	TenderID int
+	Username string
+}
+
+func (q *AskQuestion) GetParameters(r *http.Request) error {
+	tenderIdStr := mux.Vars(r)["tenderId"]
+	if tenderIdStr == "" {
+		return e.ErrExistTenderID
+	}
+	tenderId, err := strconv.Atoi(tenderIdStr)
+	if err != nil || tenderId < 1 {
+		return e.ErrTenderID
+	}
+	q.TenderID = tenderId
+
+	username, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		return e.ErrUnauthorized
+	}
+	q.Username = username
+	return nil
+}
+
+// Used for get list of tender questions
+type TenderQuestions struct {
+	TenderID int
+	Username string
+	pagination.Page
+}
+
+func (q *TenderQuestions) GetParameters(r *http.Request) error {
+	tenderIdStr := mux.Vars(r)["tenderId"]
+	if tenderIdStr == "" {
+		return e.ErrExistTenderID
+	}
+	tenderId, err := strconv.Atoi(tenderIdStr)
+	if err != nil || tenderId < 1 {
+		return e.ErrTenderID
+	}
+	q.TenderID = tenderId
+
+	username, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		return e.ErrUnauthorized
+	}
+	q.Username = username
+
+	page, err := pagination.ParsePage(r.URL.Query(), pagination.SortCreatedAt)
+	if err != nil {
+		return err
+	}
+	q.Page = page
+	return nil
+}
+
+// Used for answering the question, If-Match is checked only when the tender is amended
+type AnswerQuestion struct {
+	TenderID        int
+	QuestionID      int
+	Username        string
+	ExpectedVersion int // from If-Match header, 0 if any version matches
+}
+
+func (q *AnswerQuestion) GetParameters(r *http.Request) error {
+	vars := mux.Vars(r)
+	tenderIdStr := vars["tenderId"]
+	if tenderIdStr == "" {
+		return e.ErrExistTenderID
+	}
+	tenderId, err := strconv.Atoi(tenderIdStr)
+	if err != nil || tenderId < 1 {
+		return e.ErrTenderID
+	}
+	q.TenderID = tenderId
+
+	questionIdStr := vars["questionId"]
+	if questionIdStr == "" {
+		return e.ErrExistQuestionID
+	}
+	questionId, err := strconv.Atoi(questionIdStr)
+	if err != nil || questionId < 1 {
+		return e.ErrQuestionID
+	}
+	q.QuestionID = questionId
+
+	expectedVersion, err := f.GetIfMatchVersion(r)
+	if err != nil {
+		return err
+	}
+	q.ExpectedVersion = expectedVersion
+
+	username, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		return e.ErrUnauthorized
+	}
+	q.Username = username
+	return nil
+}
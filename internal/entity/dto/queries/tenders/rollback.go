@@ -0,0 +1,78 @@
+package queries
+
+import (
+	"net/http"
+	"strconv"
+	f "tender-workspace/internal/utils/functions"
+	e "tender-workspace/internal/utils/myerrors"
+
+	"github.com/gorilla/mux"
+)
+
+type TenderRollback struct {
+	TenderID        int
+	Version         int
+	Username        string
+	ExpectedVersion int // from If-Match header, 0 if any version matches
+}
+
+func (q *TenderRollback) GetParameters(r *http.Request) error {
+	vars := mux.Vars(r)
+	tenderIdStr := vars["tenderId"]
+	if tenderIdStr == "" {
+		return e.ErrExistTenderID
+	}
+	tenderId, err := strconv.Atoi(tenderIdStr)
+	if err != nil || tenderId < 1 {
+		return e.ErrTenderID
+	}
+	q.TenderID = tenderId
+
+	versionStr := vars["version"]
+	if versionStr == "" {
+		return e.ErrExistVersion
+	}
+	version, err := strconv.Atoi(versionStr)
+	if err != nil || version < 1 {
+		return e.ErrVersion
+	}
+	q.Version = version
+
+	expectedVersion, err := f.GetIfMatchVersion(r)
+	if err != nil {
+		return err
+	}
+	q.ExpectedVersion = expectedVersion
+
+	username, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		return e.ErrUnauthorized
+	}
+	q.Username = username
+	return nil
+}
+
+// Used for get list of tender versions
+type TenderVersions struct {
+	TenderID int
+	Username string
+}
+
+func (q *TenderVersions) GetParameters(r *http.Request) error {
+	tenderIdStr := mux.Vars(r)["tenderId"]
+	if tenderIdStr == "" {
+		return e.ErrExistTenderID
+	}
+	tenderId, err := strconv.Atoi(tenderIdStr)
+	if err != nil || tenderId < 1 {
+		return e.ErrTenderID
+	}
+	q.TenderID = tenderId
+
+	username, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		return e.ErrUnauthorized
+	}
+	q.Username = username
+	return nil
+}
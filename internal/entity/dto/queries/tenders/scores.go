@@ -0,0 +1,46 @@
+package queries
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	f "tender-workspace/internal/utils/functions"
+	mc "tender-workspace/internal/utils/myconstants"
+	e "tender-workspace/internal/utils/myerrors"
+
+	"github.com/gorilla/mux"
+)
+
+// Used for get ranked score matrix of the tender bids
+type TenderScores struct {
+	TenderID    int
+	Username    string
+	Aggregation string // пусто - способ, заданный при создании тендера
+}
+
+func (q *TenderScores) GetParameters(r *http.Request) error {
+	tenderIdStr := mux.Vars(r)["tenderId"]
+	if tenderIdStr == "" {
+		return e.ErrExistTenderID
+	}
+	tenderId, err := strconv.Atoi(tenderIdStr)
+	if err != nil || tenderId < 1 {
+		return e.ErrTenderID
+	}
+	q.TenderID = tenderId
+
+	if aggregationStr := r.URL.Query().Get("aggregation"); aggregationStr != "" {
+		aggregation, ok := mc.AvaliableAggregation[strings.ToLower(aggregationStr)]
+		if !ok {
+			return e.ErrQPAggregation
+		}
+		q.Aggregation = aggregation
+	}
+
+	username, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		return e.ErrUnauthorized
+	}
+	q.Username = username
+	return nil
+}
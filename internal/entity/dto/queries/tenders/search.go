@@ -0,0 +1,145 @@
+package queries
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	mc "tender-workspace/internal/utils/myconstants"
+	e "tender-workspace/internal/utils/myerrors"
+	"tender-workspace/internal/utils/pagination"
+	"time"
+	"unicode/utf8"
+)
+
+const maxSearchQueryLength = 200
+
+// searchLanguages конфигурации полнотекстового поиска PSQL
+var searchLanguages = map[string]string{
+	"ru": "russian",
+	"en": "english",
+}
+
+// searchStatuses статусы тендеров, видимые в публичном поиске
+var searchStatuses = map[string]string{
+	"published": "Published",
+	"closed":    "Closed",
+}
+
+// Used for full-text search of tenders
+type SearchTenders struct {
+	pagination.Page
+	Query          string
+	Language       string // пусто - поиск сразу по русской и английской конфигурации
+	ServiceTypes   []string
+	Statuses       []string
+	OrganizationID int
+	CreatedFrom    time.Time
+	CreatedTo      time.Time // не включительно
+}
+
+func (q *SearchTenders) GetParameters(r *http.Request) error {
+	queryParams := r.URL.Query()
+
+	q.Query = strings.TrimSpace(queryParams.Get("q"))
+	if utf8.RuneCountInString(q.Query) > maxSearchQueryLength {
+		return e.ErrQPSearchQuery
+	}
+	// сортировка по релевантности имеет смысл только при заданном запросе
+	fields := []string{pagination.SortName, pagination.SortCreatedAt, pagination.SortVersion}
+	if q.Query != "" {
+		fields = append([]string{pagination.SortRelevance}, fields...)
+	}
+	page, err := pagination.ParsePage(queryParams, fields...)
+	if err != nil {
+		return err
+	}
+	q.Page = page
+
+	if lang := strings.ToLower(queryParams.Get("lang")); lang != "" {
+		config, ok := searchLanguages[lang]
+		if !ok {
+			return e.ErrQPSearchLanguage
+		}
+		q.Language = config
+	}
+
+	for _, serviceType := range splitValues(queryParams["service_type"]) {
+		serviceType = strings.ToLower(serviceType)
+		if _, ok := mc.AvaliableServiceType[serviceType]; !ok {
+			return e.ErrQPServiceType
+		}
+		q.ServiceTypes = append(q.ServiceTypes, capitalize(serviceType))
+	}
+
+	for _, status := range splitValues(queryParams["status"]) {
+		s, ok := searchStatuses[strings.ToLower(status)]
+		if !ok {
+			return e.ErrQPSearchStatus
+		}
+		q.Statuses = append(q.Statuses, s)
+	}
+	if len(q.Statuses) == 0 {
+		q.Statuses = []string{"Published"}
+	}
+
+	if orgStr := queryParams.Get("organization_id"); orgStr != "" {
+		orgID, err := strconv.Atoi(orgStr)
+		if err != nil || orgID <= 0 {
+			return e.ErrQPOrganizationID
+		}
+		q.OrganizationID = orgID
+	}
+
+	if fromStr := queryParams.Get("created_from"); fromStr != "" {
+		q.CreatedFrom, err = parseDate(fromStr, false)
+		if err != nil {
+			return e.ErrQPDateRange
+		}
+	}
+	if toStr := queryParams.Get("created_to"); toStr != "" {
+		q.CreatedTo, err = parseDate(toStr, true)
+		if err != nil {
+			return e.ErrQPDateRange
+		}
+	}
+	if !q.CreatedFrom.IsZero() && !q.CreatedTo.IsZero() && !q.CreatedFrom.Before(q.CreatedTo) {
+		return e.ErrQPDateRange
+	}
+	return nil
+}
+
+// splitValues поддерживает как повторяющиеся параметры, так и перечисление через запятую
+func splitValues(values []string) []string {
+	var res []string
+	for _, value := range values {
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				res = append(res, v)
+			}
+		}
+	}
+	return res
+}
+
+// parseDate принимает дату или RFC 3339, дата в конце диапазона включает весь день
+func parseDate(value string, end bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		if end {
+			t = t.Add(time.Nanosecond)
+		}
+		return t, nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return t, err
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+func capitalize(value string) string {
+	runes := []rune(value)
+	return strings.ToUpper(string(runes[0])) + string(runes[1:])
+}
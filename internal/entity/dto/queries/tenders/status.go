@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	f "tender-workspace/internal/utils/functions"
 	mc "tender-workspace/internal/utils/myconstants"
 	e "tender-workspace/internal/utils/myerrors"
 
@@ -27,18 +28,19 @@ func (q *TenderStatus) GetParameters(r *http.Request) error {
 	}
 	q.TenderID = tenderId
 
-	username := r.URL.Query().Get("username")
-	if username == "" {
-		return e.ErrBadPermission
+	username, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		return e.ErrUnauthorized
 	}
 	q.Username = username
 	return nil
 }
 
 type UpdateTenderStatus struct {
-	TenderID int
-	Username string
-	Status   string
+	TenderID        int
+	Username        string
+	Status          string
+	ExpectedVersion int // from If-Match header, 0 if any version matches
 }
 
 func (q *UpdateTenderStatus) GetParameters(r *http.Request) error {
@@ -65,9 +67,15 @@ func (q *UpdateTenderStatus) GetParameters(r *http.Request) error {
 	runes := []rune(statusLower)
 	q.Status = strings.ToUpper(string(runes[0])) + string(runes[1:])
 
-	username := queryParams.Get("username")
-	if username == "" {
-		return e.ErrBadPermission
+	expectedVersion, err := f.GetIfMatchVersion(r)
+	if err != nil {
+		return err
+	}
+	q.ExpectedVersion = expectedVersion
+
+	username, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		return e.ErrUnauthorized
 	}
 	q.Username = username
 	return nil
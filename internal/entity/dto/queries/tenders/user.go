@@ -2,41 +2,28 @@ package queries
 
 import (
 	"net/http"
-	"strconv"
+	f "tender-workspace/internal/utils/functions"
 	e "tender-workspace/internal/utils/myerrors"
+	"tender-workspace/internal/utils/pagination"
 )
 
 // Used for get list of user tenders
 type ListUserTenders struct {
-	Limit    int
-	Offset   int
+	pagination.Page
 	Username string
 }
 
 func (q *ListUserTenders) GetParameters(r *http.Request) error {
 	queryParams := r.URL.Query()
-	q.Limit = 5
-	limitStr := queryParams.Get("limit")
-	if limitStr != "" {
-		limit, err := strconv.Atoi(limitStr)
-		if err != nil || limit < 0 {
-			return e.ErrQPLimit
-		}
-		q.Limit = limit
-	}
-	q.Offset = 0 // explicit
-	offsetStr := queryParams.Get("offset")
-	if offsetStr != "" {
-		offset, err := strconv.Atoi(offsetStr)
-		if err != nil || offset < 0 {
-			return e.ErrQPOffset
-		}
-		q.Offset = offset
+	page, err := pagination.ParsePage(queryParams, pagination.SortName, pagination.SortCreatedAt, pagination.SortVersion)
+	if err != nil {
+		return err
 	}
+	q.Page = page
 
-	username := queryParams.Get("username")
-	if username == "" {
-		return e.ErrBadPermission
+	username, ok := f.GetAuthUsername(r.Context())
+	if !ok {
+		return e.ErrUnauthorized
 	}
 	q.Username = username
 	return nil
@@ -0,0 +1,35 @@
+package dto
+
+// INPUT DTO (REQUEST BODY) -
+type QuestionInput struct {
+	Text string `json:"text" valid:"-"`
+	// author of the anonymous question is hidden from other bidders
+	Anonymous bool `json:"anonymous,omitempty" valid:"-"`
+}
+
+type AnswerInput struct {
+	Text string `json:"text" valid:"-"`
+	// published answer is visible to everyone who can see the tender
+	Publish bool `json:"publish,omitempty" valid:"-"`
+	// tender amended together with the answer as a new version
+	Tender *TenderUpdateDataInput `json:"tender,omitempty" valid:"optional"`
+}
+
+// OUTPUT DTO (RESPONSE BODY)
+type QuestionOutput struct {
+	ID        int           `json:"id"`
+	TenderID  int           `json:"tenderId"`
+	AuthorID  int           `json:"authorId,omitempty"` // не отдается другим участникам, если вопрос анонимный
+	Anonymous bool          `json:"anonymous"`
+	Text      string        `json:"text"`
+	CreatedAt string        `json:"createdAt"`
+	Answer    *AnswerOutput `json:"answer,omitempty"`
+}
+
+type AnswerOutput struct {
+	Text          string `json:"text"`
+	AnsweredBy    int    `json:"answeredBy"`
+	AnsweredAt    string `json:"answeredAt"`
+	Published     bool   `json:"published"`
+	TenderVersion int    `json:"tenderVersion,omitempty"`
+}
@@ -1,5 +1,11 @@
 package dto
 
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
 // INPUT DTO (REQUEST BODY) -
 type TenderInput struct {
 	Name            string `json:"name" valid:"name"`
@@ -7,7 +13,27 @@ type TenderInput struct {
 	Type            string `json:"serviceType" valid:"-"`
 	Status          string `json:"status" valid:"-"`
 	OrganizationID  int    `json:"organizationId" valid:"-"`
-	CreatorUsername string `json:"creatorUsername" valid:"username"`
+	CreatorUsername string `json:"-" valid:"-"` // taken from bearer token
+	// RFC 3339, after submission deadline bids are not accepted,
+	// after decision deadline (or submission one, if it's not set) tender is closed automatically
+	SubmissionDeadline *time.Time `json:"submissionDeadline,omitempty" valid:"-"`
+	DecisionDeadline   *time.Time `json:"decisionDeadline,omitempty" valid:"-"`
+	// price ceiling, bids in other currency are not accepted,
+	// hidden budget is shown only to the tender side
+	Budget         *decimal.Decimal `json:"budget,omitempty" valid:"-"`
+	BudgetCurrency string           `json:"budgetCurrency,omitempty" valid:"-"`
+	BudgetHidden   bool             `json:"budgetHidden,omitempty" valid:"-"`
+	BudgetPolicy   string           `json:"budgetPolicy,omitempty" valid:"-"` // Reject (default) or Flag
+	// bids are scored by responsible employees per criterion, scores of the criterion
+	// are aggregated by the method, weighted total ranks the bids
+	Criteria         []CriterionInput `json:"criteria,omitempty" valid:"optional"`
+	ScoreAggregation string           `json:"scoreAggregation,omitempty" valid:"-"` // Mean (default), Median or TrimmedMean
+	// sealed tender accepts only commitments of bids before submission deadline
+	Sealed bool `json:"sealed,omitempty" valid:"-"`
+	// reverse auction tender is awarded to the lowest offer when the auction ends
+	Auction *AuctionInput `json:"auction,omitempty" valid:"-"`
+	// bids to the tender with lots target one or more lots, each lot is awarded separately
+	Lots []LotInput `json:"lots,omitempty" valid:"optional"`
 }
 
 type TenderUpdateDataInput struct {
@@ -25,8 +51,60 @@ type TenderOutput struct {
 	Type        string `json:"serviceType"`
 	Version     int    `json:"version"`
 	CreatedAt   string `json:"createdAt"`
+	// сроки и причина закрытия отдаются, только если заданы
+	SubmissionDeadline string `json:"submissionDeadline,omitempty"`
+	DecisionDeadline   string `json:"decisionDeadline,omitempty"`
+	ClosedReason       string `json:"closedReason,omitempty"`
+	// сумма скрытого бюджета не отдается участникам
+	Budget         *decimal.Decimal `json:"budget,omitempty"`
+	BudgetCurrency string           `json:"budgetCurrency,omitempty"`
+	BudgetHidden   bool             `json:"budgetHidden,omitempty"`
+	BudgetPolicy   string           `json:"budgetPolicy,omitempty"`
+	// критерии отдаются, только если они загружены
+	Criteria         []*CriterionOutput `json:"criteria,omitempty"`
+	ScoreAggregation string             `json:"scoreAggregation,omitempty"`
+	Sealed           bool               `json:"sealed,omitempty"`
+	// торги отдаются только при создании, дальше они доступны в рейтинге аукциона
+	Auction *AuctionOutput `json:"auction,omitempty"`
+	// лоты отдаются, только если они загружены
+	Lots []*LotOutput `json:"lots,omitempty"`
+}
+
+type TenderSearchOutput struct {
+	*TenderOutput
+	OrganizationID int     `json:"organizationId"`
+	Relevance      float32 `json:"relevance"`
 }
 
 type TenderStatus struct {
-	Status string `json:"status"`
+	Status            string                   `json:"status"`
+	BudgetUtilization *BudgetUtilizationOutput `json:"budgetUtilization,omitempty"`
+}
+
+type BudgetUtilizationOutput struct {
+	BidID       int             `json:"bidId"`
+	BidTotal    decimal.Decimal `json:"bidTotal"`
+	Budget      decimal.Decimal `json:"budget"`
+	Currency    string          `json:"currency"`
+	Utilization decimal.Decimal `json:"utilization"` // percent
+}
+
+type TenderVersionOutput struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	Type        string `json:"serviceType"`
+	Version     int    `json:"version"`
+	CreatedAt   string `json:"createdAt"`
+}
+
+type TenderAwardOutput struct {
+	TenderID               int    `json:"tenderId"`
+	BidID                  int    `json:"bidId"`
+	BidName                string `json:"bidName"`
+	BidVersion             int    `json:"bidVersion"`
+	AuthorType             string `json:"authorType"`
+	AuthorID               int    `json:"authorId"`
+	ExecutorOrganizationID int    `json:"executorOrganizationId,omitempty"`
+	AwardedAt              string `json:"awardedAt"`
 }
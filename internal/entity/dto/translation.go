@@ -3,6 +3,7 @@ package dto
 import (
 	ent "tender-workspace/internal/entity"
 	f "tender-workspace/internal/utils/functions"
+	"time"
 )
 
 func NewArrayTenderOutput(tenders []*ent.Tender) []*TenderOutput {
@@ -15,14 +16,100 @@ func NewArrayTenderOutput(tenders []*ent.Tender) []*TenderOutput {
 }
 
 func NewTenderOutput(tenders *ent.Tender) *TenderOutput {
-	return &TenderOutput{
-		ID:          tenders.ID,
-		Name:        tenders.Name,
-		Description: tenders.Description,
-		Status:      tenders.Status,
-		Type:        tenders.Type,
-		Version:     tenders.Version,
-		CreatedAt:   f.FormatTime(tenders.CreatedAt),
+	output := &TenderOutput{
+		ID:           tenders.ID,
+		Name:         tenders.Name,
+		Description:  tenders.Description,
+		Status:       tenders.Status,
+		Type:         tenders.Type,
+		Version:      tenders.Version,
+		CreatedAt:    f.FormatTime(tenders.CreatedAt),
+		ClosedReason: tenders.ClosedReason,
+		Sealed:       tenders.Sealed,
+	}
+	if tenders.SubmissionDeadline != nil {
+		output.SubmissionDeadline = f.FormatTime(*tenders.SubmissionDeadline)
+	}
+	if tenders.DecisionDeadline != nil {
+		output.DecisionDeadline = f.FormatTime(*tenders.DecisionDeadline)
+	}
+	if budget := tenders.Budget; budget != nil {
+		output.BudgetCurrency = budget.Currency
+		output.BudgetHidden = budget.Hidden
+		output.BudgetPolicy = budget.Policy
+		// бюджет всегда положительный, нулевая сумма значит, что она скрыта от пользователя
+		if !budget.Amount.IsZero() {
+			output.Budget = &budget.Amount
+		}
+	}
+	if len(tenders.Criteria) != 0 {
+		output.Criteria = NewArrayCriterionOutput(tenders.Criteria)
+		output.ScoreAggregation = tenders.ScoreAggregation
+	}
+	if tenders.Auction != nil {
+		output.Auction = NewAuctionOutput(tenders.Auction, time.Now())
+	}
+	if len(tenders.Lots) != 0 {
+		output.Lots = NewArrayLotOutput(tenders.Lots)
+	}
+	return output
+}
+
+func NewTenderStatusOutput(tender *ent.Tender, utilization *ent.BudgetUtilization) *TenderStatus {
+	output := &TenderStatus{Status: tender.Status}
+	if utilization != nil {
+		output.BudgetUtilization = &BudgetUtilizationOutput{
+			BidID:       utilization.BidID,
+			BidTotal:    utilization.BidTotal,
+			Budget:      utilization.Budget,
+			Currency:    utilization.Currency,
+			Utilization: utilization.Utilization,
+		}
+	}
+	return output
+}
+
+func NewArrayTenderSearchOutput(results []*ent.TenderSearchResult) []*TenderSearchOutput {
+	res := make([]*TenderSearchOutput, 0, len(results))
+	for _, result := range results {
+		res = append(res, &TenderSearchOutput{
+			TenderOutput:   NewTenderOutput(&result.Tender),
+			OrganizationID: result.OrganizationID,
+			Relevance:      result.Relevance,
+		})
+	}
+	return res
+}
+
+func NewArrayTenderVersionOutput(versions []*ent.TenderVersion) []*TenderVersionOutput {
+	res := make([]*TenderVersionOutput, 0, len(versions))
+	for _, version := range versions {
+		res = append(res, NewTenderVersionOutput(version))
+	}
+	return res
+}
+
+func NewTenderVersionOutput(version *ent.TenderVersion) *TenderVersionOutput {
+	return &TenderVersionOutput{
+		Name:        version.Name,
+		Description: version.Description,
+		Status:      version.Status,
+		Type:        version.Type,
+		Version:     version.Version,
+		CreatedAt:   f.FormatTime(version.CreatedAt),
+	}
+}
+
+func NewTenderAwardOutput(award *ent.TenderAward) *TenderAwardOutput {
+	return &TenderAwardOutput{
+		TenderID:               award.TenderID,
+		BidID:                  award.BidID,
+		BidName:                award.BidName,
+		BidVersion:             award.BidVersion,
+		AuthorType:             award.AuthorType,
+		AuthorID:               award.CreatorID,
+		ExecutorOrganizationID: award.ExecutorOrganizationID,
+		AwardedAt:              f.FormatTime(award.AwardedAt),
 	}
 }
 
@@ -36,7 +123,7 @@ func NewArrayBidOutput(bids []*ent.Bid) []*BidOutput {
 }
 
 func NewBidOutput(bid *ent.Bid) *BidOutput {
-	return &BidOutput{
+	output := &BidOutput{
 		ID:         bid.ID,
 		Name:       bid.Name,
 		Status:     bid.Status,
@@ -44,5 +131,249 @@ func NewBidOutput(bid *ent.Bid) *BidOutput {
 		AuthorID:   bid.CreatorID,
 		Version:    bid.Version,
 		CreatedAt:  f.FormatTime(bid.CreatedAt),
+		OverBudget: bid.OverBudget,
+		Sealed:     bid.IsSealed(),
+		Commitment: bid.Commitment,
+	}
+	if bid.RevealedAt != nil {
+		output.RevealedAt = f.FormatTime(*bid.RevealedAt)
+	}
+	for _, lot := range bid.Lots {
+		output.Lots = append(output.Lots, &BidLotOutput{
+			LotID:  lot.LotID,
+			Status: lot.Status,
+		})
+	}
+	if bid.Offer != nil {
+		output.Currency = bid.Offer.Currency
+		output.TotalNet = &bid.Offer.TotalNet
+		output.TotalVat = &bid.Offer.TotalVat
+		output.Total = &bid.Offer.Total
+	}
+	return output
+}
+
+func NewBidOfferOutput(bidID int, offer *ent.BidOffer) *BidOfferOutput {
+	items := make([]*BidItemOutput, 0, len(offer.Items))
+	for _, item := range offer.Items {
+		items = append(items, &BidItemOutput{
+			Position:  item.Position,
+			Name:      item.Name,
+			Quantity:  item.Quantity,
+			Unit:      item.Unit,
+			UnitPrice: item.UnitPrice,
+			VatRate:   item.VatRate,
+			Currency:  item.Currency,
+			NetAmount: item.NetAmount,
+			VatAmount: item.VatAmount,
+			Amount:    item.Amount,
+		})
+	}
+	return &BidOfferOutput{
+		BidID:    bidID,
+		Currency: offer.Currency,
+		TotalNet: offer.TotalNet,
+		TotalVat: offer.TotalVat,
+		Total:    offer.Total,
+		Items:    items,
+	}
+}
+
+func NewBidDecisionOutput(result *ent.BidDecisionResult) *BidDecisionOutput {
+	return &BidDecisionOutput{
+		BidOutput: NewBidOutput(result.Bid),
+		Decisions: &BidDecisionTallyOutput{
+			Approvals:  result.Tally.Approvals,
+			Rejections: result.Tally.Rejections,
+			Quorum:     result.Tally.Quorum,
+		},
+		LotID:        result.LotID,
+		LotStatus:    result.LotStatus,
+		TenderClosed: result.TenderClosed,
+	}
+}
+
+func NewBidDiffOutput(diff *ent.BidDiff) *BidDiffOutput {
+	changes := make([]*BidFieldChangeOutput, 0, len(diff.Changes))
+	for _, change := range diff.Changes {
+		changes = append(changes, &BidFieldChangeOutput{
+			Field: change.Field,
+			From:  change.From,
+			To:    change.To,
+		})
+	}
+	return &BidDiffOutput{
+		BidID:       diff.BidID,
+		FromVersion: diff.FromVersion,
+		ToVersion:   diff.ToVersion,
+		Changes:     changes,
+	}
+}
+
+func NewArrayBidReviewOutput(reviews []*ent.Feedback) []*BidReviewOutput {
+	res := make([]*BidReviewOutput, 0, len(reviews))
+	for _, review := range reviews {
+		res = append(res, NewBidReviewOutput(review))
+	}
+	return res
+}
+
+func NewBidReviewOutput(review *ent.Feedback) *BidReviewOutput {
+	return &BidReviewOutput{
+		ID:          review.ID,
+		Description: review.Text,
+		CreatedAt:   f.FormatTime(review.CreatedAt),
+	}
+}
+
+func NewArrayCriterionOutput(criteria []*ent.Criterion) []*CriterionOutput {
+	res := make([]*CriterionOutput, 0, len(criteria))
+	for _, criterion := range criteria {
+		res = append(res, &CriterionOutput{
+			ID:     criterion.ID,
+			Name:   criterion.Name,
+			Weight: criterion.Weight,
+		})
+	}
+	return res
+}
+
+func NewArrayBidScoreOutput(scores []*ent.BidScore) []*BidScoreOutput {
+	res := make([]*BidScoreOutput, 0, len(scores))
+	for _, score := range scores {
+		res = append(res, &BidScoreOutput{
+			CriterionID: score.CriterionID,
+			Score:       score.Score,
+		})
+	}
+	return res
+}
+
+func NewScoreMatrixOutput(matrix *ent.ScoreMatrix) *ScoreMatrixOutput {
+	output := &ScoreMatrixOutput{
+		TenderID:    matrix.TenderID,
+		Aggregation: matrix.Aggregation,
+		Criteria:    NewArrayCriterionOutput(matrix.Criteria),
+		Bids:        make([]*ScoreMatrixRowOutput, 0, len(matrix.Rows)),
+	}
+	for _, row := range matrix.Rows {
+		rowOutput := &ScoreMatrixRowOutput{
+			Rank:    row.Rank,
+			BidID:   row.BidID,
+			BidName: row.BidName,
+			Scores:  make([]*CriterionScoreOutput, 0, len(row.Scores)),
+			Total:   row.Total,
+		}
+		for _, score := range row.Scores {
+			rowOutput.Scores = append(rowOutput.Scores, &CriterionScoreOutput{
+				CriterionID: score.CriterionID,
+				Score:       score.Score,
+				Scorers:     score.Scorers,
+			})
+		}
+		output.Bids = append(output.Bids, rowOutput)
+	}
+	return output
+}
+
+func NewAuctionOutput(auction *ent.Auction, now time.Time) *AuctionOutput {
+	return &AuctionOutput{
+		Currency:        auction.Currency,
+		StartPrice:      auction.StartPrice,
+		MinDecrement:    auction.MinDecrement,
+		StartsAt:        f.FormatTime(auction.StartsAt),
+		EndsAt:          f.FormatTime(auction.EndsAt),
+		ExtensionWindow: int(auction.ExtensionWindow.Seconds()),
+		Extension:       int(auction.Extension.Seconds()),
+		Status:          auction.Status(now),
+		WinnerBidID:     auction.WinnerBidID,
+	}
+}
+
+func NewAuctionRankingOutput(ranking *ent.AuctionRanking, now time.Time) *AuctionRankingOutput {
+	output := &AuctionRankingOutput{
+		TenderID:  ranking.Auction.TenderID,
+		Auction:   NewAuctionOutput(ranking.Auction, now),
+		BestPrice: ranking.BestPrice,
+		Bids:      make([]*AuctionRankOutput, 0, len(ranking.Ranks)),
 	}
+	for _, rank := range ranking.Ranks {
+		output.Bids = append(output.Bids, &AuctionRankOutput{
+			Rank:     rank.Rank,
+			BidID:    rank.BidID,
+			BidName:  rank.BidName,
+			Price:    rank.Price,
+			PlacedAt: f.FormatTime(rank.PlacedAt),
+			Offers:   rank.Offers,
+		})
+	}
+	return output
+}
+
+func NewArrayLotOutput(lots []*ent.Lot) []*LotOutput {
+	res := make([]*LotOutput, 0, len(lots))
+	for _, lot := range lots {
+		lotOutput := &LotOutput{
+			ID:                     lot.ID,
+			Name:                   lot.Name,
+			Description:            lot.Description,
+			Budget:                 lot.Budget,
+			BudgetCurrency:         lot.BudgetCurrency,
+			WinnerBidID:            lot.WinnerBidID,
+			ExecutorOrganizationID: lot.ExecutorOrganizationID,
+		}
+		if lot.AwardedAt != nil {
+			lotOutput.AwardedAt = f.FormatTime(*lot.AwardedAt)
+		}
+		res = append(res, lotOutput)
+	}
+	return res
+}
+
+func NewQuestionOutput(q *ent.Question) *QuestionOutput {
+	questionOutput := &QuestionOutput{
+		ID:        q.ID,
+		TenderID:  q.TenderID,
+		AuthorID:  q.AuthorID,
+		Anonymous: q.Anonymous,
+		Text:      q.Text,
+		CreatedAt: f.FormatTime(q.CreatedAt),
+	}
+	if q.Answer != nil {
+		questionOutput.Answer = &AnswerOutput{
+			Text:          q.Answer.Text,
+			AnsweredBy:    q.Answer.AnsweredBy,
+			AnsweredAt:    f.FormatTime(q.Answer.AnsweredAt),
+			Published:     q.Answer.Published,
+			TenderVersion: q.Answer.TenderVersion,
+		}
+	}
+	return questionOutput
+}
+
+func NewArrayQuestionOutput(questions []*ent.Question) []*QuestionOutput {
+	res := make([]*QuestionOutput, 0, len(questions))
+	for _, q := range questions {
+		res = append(res, NewQuestionOutput(q))
+	}
+	return res
+}
+
+func NewAttachmentOutput(a *ent.Attachment) *AttachmentOutput {
+	return &AttachmentOutput{
+		ID:          a.ID,
+		Name:        a.Name,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		SHA256:      a.SHA256,
+		CreatedAt:   f.FormatTime(a.CreatedAt),
+	}
+}
+
+func NewArrayAttachmentOutput(attachments []*ent.Attachment) []*AttachmentOutput {
+	res := make([]*AttachmentOutput, 0, len(attachments))
+	for _, a := range attachments {
+		res = append(res, NewAttachmentOutput(a))
+	}
+	return res
 }
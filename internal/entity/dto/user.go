@@ -5,6 +5,12 @@ type UserInput struct {
 	Username  string `json:"username" valid:"username"`
 	FirstName string `json:"firstName" valid:"firstName"`
 	LastName  string `json:"lastName" valid:"lastName"`
+	Password  string `json:"password" valid:"password"`
+}
+
+type LoginInput struct {
+	Username string `json:"username" valid:"username"`
+	Password string `json:"password" valid:"password"`
 }
 
 // OUTPUT DTO (RESPONSE BODY)
@@ -15,3 +21,8 @@ type UserOutPut struct {
 	LastName  string `json:"lastName"`
 	CreatedAt string `json:"createdAt"`
 }
+
+type TokenOutput struct {
+	Token     string `json:"token"`
+	ExpiresAt string `json:"expiresAt"`
+}
@@ -9,3 +9,8 @@ type Employee struct {
 	LastName  string
 	CreatedAt time.Time
 }
+
+type AuthToken struct {
+	Token     string
+	ExpiresAt time.Time
+}
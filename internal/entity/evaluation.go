@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Criterion weighted evaluation criterion of the tender
+type Criterion struct {
+	ID       int
+	TenderID int
+	Position int
+	Name     string
+	Weight   decimal.Decimal
+}
+
+type BidScore struct {
+	BidID       int
+	CriterionID int
+	UserID      int
+	Score       int
+	CreatedAt   time.Time
+}
+
+// ScoreMatrix
+// Scores of the tender bids aggregated per criterion, rows are ranked by total.
+type ScoreMatrix struct {
+	TenderID    int
+	Aggregation string
+	Criteria    []*Criterion
+	Rows        []*ScoreMatrixRow
+}
+
+type ScoreMatrixRow struct {
+	Rank    int
+	BidID   int
+	BidName string
+	Scores  []*CriterionScore // в порядке критериев
+	// Total взвешенный итог, nil пока предложение оценено не по всем критериям
+	Total *decimal.Decimal
+}
+
+type CriterionScore struct {
+	CriterionID int
+	Score       *decimal.Decimal // nil - оценок еще нет
+	Scorers     int
+}
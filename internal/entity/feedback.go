@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+type Feedback struct {
+	ID        int
+	BidID     int
+	AuthorID  int
+	Text      string
+	CreatedAt time.Time
+}
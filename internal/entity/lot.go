@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Lot
+// Part of the tender with its own description and budget. Bids to the tender with lots
+// target one or more lots, and each lot is awarded separately.
+type Lot struct {
+	ID          int
+	TenderID    int
+	Position    int
+	Name        string
+	Description string
+	// бюджет лота необязателен, скрывается вместе с бюджетом тендера
+	Budget         *decimal.Decimal
+	BudgetCurrency string
+	// победитель лота, нулевые значения - лот еще не присужден
+	WinnerBidID            int
+	ExecutorOrganizationID int
+	AwardedAt              *time.Time
+}
+
+// BidLot lot targeted by the bid and the decision on it
+type BidLot struct {
+	LotID  int
+	Status string // Pending, Awarded или Rejected
+}
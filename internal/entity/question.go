@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+// Question
+// Clarification question of an employee on the tender. Anonymous question doesn't show
+// its author to other bidders, the tender side sees the author anyway.
+type Question struct {
+	ID        int
+	TenderID  int
+	AuthorID  int // 0 - автор скрыт от смотрящего
+	Anonymous bool
+	Text      string
+	CreatedAt time.Time
+	Answer    *QuestionAnswer // nil - вопрос еще без ответа
+}
+
+// QuestionAnswer
+// Answer of the tender side, until published it's visible only to the tender side and the author.
+type QuestionAnswer struct {
+	Text       string
+	AnsweredBy int
+	AnsweredAt time.Time
+	Published  bool
+	// версия тендера, измененного вместе с ответом, 0 - тендер не менялся
+	TenderVersion int
+}
@@ -1,13 +1,19 @@
 package entity
 
-type ResponseError struct {
-	Error string `json:"error"`
-}
-
 type ResponseDetail struct {
 	Detail string `json:"detail"`
 }
 
-type ResponseReason struct {
-	Reason string `json:"reason"`
+// Problem
+// RFC 7807 problem details, code and key are extension members.
+type Problem struct {
+	Type      string         `json:"type"`
+	Title     string         `json:"title"`
+	Status    int            `json:"status"`
+	Detail    string         `json:"detail"`
+	Instance  string         `json:"instance"`
+	Code      int            `json:"code"`
+	Key       string         `json:"key"`
+	RequestID string         `json:"requestId,omitempty"`
+	Details   map[string]any `json:"details,omitempty"`
 }
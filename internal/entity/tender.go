@@ -1,6 +1,10 @@
 package entity
 
-import "time"
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
 
 type Tender struct {
 	ID             int
@@ -12,6 +16,44 @@ type Tender struct {
 	OrganizationID int
 	CreatorID      int
 	CreatedAt      time.Time
+	// сроки необязательны, без них тендер закрывается только вручную или выбором победителя
+	SubmissionDeadline *time.Time
+	DecisionDeadline   *time.Time
+	ClosedReason       string
+	// бюджет необязателен, без него цена предложений не ограничивается
+	Budget *TenderBudget
+	// Criteria загружаются только при создании и подсчете оценок
+	Criteria         []*Criterion
+	ScoreAggregation string
+	// Sealed предложения принимаются в виде хэша и раскрываются после срока подачи
+	Sealed bool
+	// Auction загружается только при создании, nil - тендер без торгов
+	Auction *Auction
+	// Lots загружаются только при создании и запросе лотов
+	Lots []*Lot
+}
+
+// TenderBudget price ceiling of the tender
+type TenderBudget struct {
+	Amount   decimal.Decimal
+	Currency string
+	Hidden   bool   // сумма видна только ответственным за организацию тендера
+	Policy   string // Reject - предложения дороже потолка отклоняются, Flag - принимаются с отметкой
+}
+
+// BudgetUtilization share of the budget taken by the awarded bid
+type BudgetUtilization struct {
+	BidID       int
+	BidTotal    decimal.Decimal
+	Budget      decimal.Decimal
+	Currency    string
+	Utilization decimal.Decimal // percent
+}
+
+// TenderSearchResult tender found by full-text search with its rank
+type TenderSearchResult struct {
+	Tender
+	Relevance float32
 }
 
 type UpdateTenderData struct {
@@ -19,3 +61,26 @@ type UpdateTenderData struct {
 	Description string
 	Type        string
 }
+
+type TenderVersion struct {
+	TenderID    int
+	Name        string
+	Description string
+	Type        string
+	Status      string
+	Version     int
+	CreatedAt   time.Time
+}
+
+// TenderAward describes the bid that won the tender
+type TenderAward struct {
+	TenderID               int
+	BidID                  int
+	BidName                string
+	BidVersion             int
+	AuthorType             string
+	CreatorID              int
+	ExecutorOrganizationID int
+	AwardedAt              time.Time
+	BidOffer               *BidOffer // без позиций, nil - предложение без цены
+}
@@ -0,0 +1,42 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	f "tender-workspace/internal/utils/functions"
+	mc "tender-workspace/internal/utils/myconstants"
+	me "tender-workspace/internal/utils/myerrors"
+	"tender-workspace/internal/utils/token"
+
+	"go.uber.org/zap"
+)
+
+// Auth
+// Middleware that verifies bearer token and puts authenticated employee into the request context.
+// Requests without token are passed further, handlers decide whether they need an employee.
+func Auth(h http.Handler, logger *zap.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		if header == "" {
+			h.ServeHTTP(w, r)
+			return
+		}
+		requestId := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
+		tokenStr, found := strings.CutPrefix(header, "Bearer ")
+		if !found || tokenStr == "" {
+			logger.Info(me.ErrBadToken.Error(), zap.String(mc.RequestID, requestId))
+			f.ResponseError(w, r, me.ErrBadToken)
+			return
+		}
+		claims, err := token.Parse(tokenStr)
+		if err != nil {
+			logger.Info(err.Error(), zap.String(mc.RequestID, requestId))
+			f.ResponseError(w, r, me.ErrBadToken)
+			return
+		}
+		ctx := context.WithValue(r.Context(), mc.ContextKey(mc.AuthUsername), claims.Subject)
+		ctx = context.WithValue(ctx, mc.ContextKey(mc.AuthUserID), claims.UserID)
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
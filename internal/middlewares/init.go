@@ -8,7 +8,10 @@ import (
 )
 
 func Init(r *mux.Router, logger *zap.Logger) (h http.Handler) {
-	h = Access(r, logger)
+	h = Auth(r, logger)
+	h = Tracing(h, r)
+	h = Metrics(h, r)
+	h = Access(h, logger)
 	h = Recover(h, logger)
 	return h
 }
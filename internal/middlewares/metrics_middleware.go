@@ -0,0 +1,36 @@
+package middlewares
+
+import (
+	"net/http"
+	"tender-workspace/internal/utils/metrics"
+	"tender-workspace/internal/utils/recorder"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// unmatchedRoute метка для запросов, не попавших ни в один маршрут, чтобы не плодить метки по URI
+const unmatchedRoute = "unmatched"
+
+// Metrics
+// Middleware that counts requests and observes their latency by mux route template and status.
+func Metrics(h http.Handler, router *mux.Router) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := routeTemplate(router, r)
+		rec := recorder.NewResponseWriter(w)
+		timeNow := time.Now()
+		h.ServeHTTP(rec, r)
+		metrics.ObserveHTTPRequest(r.Method, route, rec.StatusCode, time.Since(timeNow))
+	})
+}
+
+// routeTemplate возвращает шаблон маршрута mux, например /api/tenders/{tenderId}/status
+func routeTemplate(router *mux.Router, r *http.Request) string {
+	var match mux.RouteMatch
+	if router.Match(r, &match) && match.Route != nil {
+		if template, err := match.Route.GetPathTemplate(); err == nil {
+			return template
+		}
+	}
+	return unmatchedRoute
+}
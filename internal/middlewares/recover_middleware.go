@@ -3,9 +3,8 @@ package middlewares
 import (
 	"fmt"
 	"net/http"
-	e "tender-workspace/internal/entity"
 	f "tender-workspace/internal/utils/functions"
-	mc "tender-workspace/internal/utils/myconstants"
+	me "tender-workspace/internal/utils/myerrors"
 
 	"go.uber.org/zap"
 )
@@ -15,8 +14,7 @@ func Recover(h http.Handler, logger *zap.Logger) http.Handler {
 		defer func() {
 			if err := recover(); err != nil {
 				logger.Error(fmt.Sprintf("error while handling request: %v", err))
-				props := f.NewResponseProps(w, e.ResponseReason{Reason: "internal server error, please try again later"}, http.StatusInternalServerError, mc.ApplicationJson)
-				f.Response(props)
+				f.ResponseError(w, r, me.ErrInternal)
 				return
 			}
 		}()
@@ -0,0 +1,41 @@
+package middlewares
+
+import (
+	"net/http"
+	mc "tender-workspace/internal/utils/myconstants"
+	"tender-workspace/internal/utils/recorder"
+	"tender-workspace/internal/utils/tracing"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing
+// Middleware that starts server span per request. Parent span is taken from W3C 'traceparent' header.
+func Tracing(h http.Handler, router *mux.Router) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := routeTemplate(router, r)
+		requestId, _ := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
+		ctx := tracing.Extract(r.Context(), r.Header)
+		ctx, span := tracing.Start(ctx, r.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(r.Method),
+				semconv.HTTPRoute(route),
+				semconv.URLPath(r.URL.Path),
+				attribute.String(mc.RequestID, requestId),
+			),
+		)
+		defer span.End()
+
+		rec := recorder.NewResponseWriter(w)
+		h.ServeHTTP(rec, r.WithContext(ctx))
+		span.SetAttributes(semconv.HTTPResponseStatusCode(rec.StatusCode))
+		if rec.StatusCode >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(rec.StatusCode))
+		}
+	})
+}
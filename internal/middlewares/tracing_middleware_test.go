@@ -0,0 +1,135 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	delUser "tender-workspace/internal/delivery/user"
+	repoUser "tender-workspace/internal/repo/user"
+	ucUser "tender-workspace/internal/usecase/user"
+	mc "tender-workspace/internal/utils/myconstants"
+	"tender-workspace/services/postgres"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// clientStub отвечает на любой QueryRow одним сотрудником, соединение с PSQL тесту не нужно
+type clientStub struct {
+	postgres.Client
+}
+
+func (c *clientStub) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return rowStub{username: args[0].(string)}
+}
+
+type rowStub struct {
+	username string
+}
+
+func (r rowStub) Scan(dest ...any) error {
+	*dest[0].(*int) = 1
+	*dest[1].(*string) = r.username
+	*dest[4].(*time.Time) = time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	return nil
+}
+
+// recordSpans подменяет глобальный провайдер на время теста и собирает завершенные спаны в памяти
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+	return recorder
+}
+
+func TestTracingSpans(t *testing.T) {
+	recorder := recordSpans(t)
+
+	// обработчик собран так же, как в route/user, только с заглушкой вместо пула
+	logger := zap.NewNop()
+	repo := repoUser.NewRepoLayer(&clientStub{}, logger)
+	delivery := delUser.NewDeliveryLayer(ucUser.NewTracingLayer(ucUser.NewUsecaseLayer(repo)), logger)
+	router := mux.NewRouter()
+	router.HandleFunc("/users/{username}", delivery.GetUser)
+	handler := Tracing(router, router)
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	parentID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	req := httptest.NewRequest(http.MethodGet, "/users/igormed", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	req = req.WithContext(context.WithValue(req.Context(), mc.ContextKey(mc.RequestID), "request-1"))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", w.Code, w.Body)
+	}
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	// каждый слой - дочерний спан предыдущего, корень продолжает трассу клиента
+	chain := []struct {
+		name string
+		kind trace.SpanKind
+	}{
+		{name: "GET /users/{username}", kind: trace.SpanKindServer},
+		{name: "usecase.user.GetData", kind: trace.SpanKindInternal},
+		{name: "repo.user.GetData", kind: trace.SpanKindClient},
+	}
+	if len(spans) != len(chain) {
+		t.Fatalf("got %d spans: %v", len(spans), spans)
+	}
+	parent := parentID
+	for _, want := range chain {
+		span, ok := spans[want.name]
+		if !ok {
+			t.Fatalf("span %q is not recorded, got %v", want.name, spans)
+		}
+		if span.SpanContext().TraceID() != traceID {
+			t.Errorf("%s: trace id = %s, want %s from traceparent", want.name, span.SpanContext().TraceID(), traceID)
+		}
+		if span.Parent().SpanID() != parent {
+			t.Errorf("%s: parent = %s, want %s", want.name, span.Parent().SpanID(), parent)
+		}
+		if span.SpanKind() != want.kind {
+			t.Errorf("%s: kind = %s, want %s", want.name, span.SpanKind(), want.kind)
+		}
+		parent = span.SpanContext().SpanID()
+	}
+	if !spans[chain[0].name].Parent().IsRemote() {
+		t.Error("server span parent is not taken from traceparent")
+	}
+}
+
+func TestTracingWithoutTraceparent(t *testing.T) {
+	recorder := recordSpans(t)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/ping", func(w http.ResponseWriter, r *http.Request) {})
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	Tracing(router, router).ServeHTTP(httptest.NewRecorder(), req)
+
+	ended := recorder.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("got %d spans, want 1", len(ended))
+	}
+	if ended[0].Parent().IsValid() || !ended[0].SpanContext().TraceID().IsValid() {
+		t.Errorf("request without traceparent: parent = %v, trace id = %s", ended[0].Parent(), ended[0].SpanContext().TraceID())
+	}
+}
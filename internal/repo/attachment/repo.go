@@ -0,0 +1,115 @@
+package attachment
+
+import (
+	"context"
+	"fmt"
+	ent "tender-workspace/internal/entity"
+	"tender-workspace/internal/utils/metrics"
+	mc "tender-workspace/internal/utils/myconstants"
+	"tender-workspace/internal/utils/tracing"
+	"tender-workspace/services/postgres"
+
+	"go.uber.org/zap"
+)
+
+type Repo interface {
+	Create(ctx context.Context, initData *ent.Attachment) (*ent.Attachment, error)
+	GetAttachment(ctx context.Context, attachmentID int) (*ent.Attachment, error)
+	// GetTenderAttachments возвращает файлы тендера в порядке загрузки
+	GetTenderAttachments(ctx context.Context, tenderID int) ([]*ent.Attachment, error)
+	// GetBidAttachments возвращает файлы предложения в порядке загрузки
+	GetBidAttachments(ctx context.Context, bidID int) ([]*ent.Attachment, error)
+}
+
+var _ Repo = (*RepoLayer)(nil)
+
+type RepoLayer struct {
+	Client postgres.Client
+	Logger *zap.Logger
+}
+
+func NewRepoLayer(client postgres.Client, logger *zap.Logger) *RepoLayer {
+	return &RepoLayer{
+		Client: postgres.Instrument(client, "attachment", metrics.ObserveQuery, tracing.TraceQuery),
+		Logger: logger,
+	}
+}
+
+var (
+	sqlRowCreateAttachment = `INSERT INTO attachments (
+		tender_id,
+		bid_id,
+		name,
+		content_type,
+		size,
+		sha256,
+		storage_key,
+		creator_id,
+		created_at
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) 
+	RETURNING id, tender_id, bid_id, name, content_type, size, sha256, storage_key, creator_id, created_at`
+	sqlRowGetAttachment = `SELECT id, tender_id, bid_id, name, content_type, size, sha256, storage_key, creator_id, created_at 
+	FROM attachments WHERE id=$1`
+	sqlRowGetTenderAttachments = `SELECT id, tender_id, bid_id, name, content_type, size, sha256, storage_key, creator_id, created_at 
+	FROM attachments WHERE tender_id=$1 ORDER BY created_at, id`
+	sqlRowGetBidAttachments = `SELECT id, tender_id, bid_id, name, content_type, size, sha256, storage_key, creator_id, created_at 
+	FROM attachments WHERE bid_id=$1 ORDER BY created_at, id`
+)
+
+func (r *RepoLayer) Create(ctx context.Context, initData *ent.Attachment) (*ent.Attachment, error) {
+	aDB := newAttachmentDB(initData)
+	row := r.Client.QueryRow(ctx, sqlRowCreateAttachment,
+		aDB.TenderID,
+		aDB.BidID,
+		aDB.Name,
+		aDB.ContentType,
+		aDB.Size,
+		aDB.SHA256,
+		aDB.StorageKey,
+		aDB.CreatorID,
+		aDB.CreatedAt,
+	)
+	var created attachmentDB
+	if err := scanAttachment(row, &created); err != nil {
+		return nil, err
+	}
+	return newAttachment(&created), nil
+}
+
+func (r *RepoLayer) GetAttachment(ctx context.Context, attachmentID int) (*ent.Attachment, error) {
+	row := r.Client.QueryRow(ctx, sqlRowGetAttachment, attachmentID)
+	var aDB attachmentDB
+	if err := scanAttachment(row, &aDB); err != nil {
+		return nil, err
+	}
+	return newAttachment(&aDB), nil
+}
+
+func (r *RepoLayer) GetTenderAttachments(ctx context.Context, tenderID int) ([]*ent.Attachment, error) {
+	return r.getAttachments(ctx, sqlRowGetTenderAttachments, tenderID)
+}
+
+func (r *RepoLayer) GetBidAttachments(ctx context.Context, bidID int) ([]*ent.Attachment, error) {
+	return r.getAttachments(ctx, sqlRowGetBidAttachments, bidID)
+}
+
+// getAttachments выполняет запрос списка файлов, строки с ошибкой сканирования пропускаются
+func (r *RepoLayer) getAttachments(ctx context.Context, query string, ownerID int) ([]*ent.Attachment, error) {
+	rows, err := r.Client.Query(ctx, query, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attachments := make([]*ent.Attachment, 0)
+	for rows.Next() {
+		var aDB attachmentDB
+		if err := scanAttachment(rows, &aDB); err != nil {
+			requestId := ctx.Value(mc.ContextKey(mc.RequestID)).(string)
+			r.Logger.Error(fmt.Sprintf("error while scanning sql result: %v", err), zap.String(mc.RequestID, requestId))
+			continue
+		}
+		attachments = append(attachments, newAttachment(&aDB))
+	}
+	return attachments, rows.Err()
+}
@@ -0,0 +1,71 @@
+package attachment
+
+import (
+	"database/sql"
+	ent "tender-workspace/internal/entity"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// attachmentDB колонки файла, у файла тендера пустой bid_id и наоборот
+type attachmentDB struct {
+	ID          int
+	TenderID    sql.NullInt32
+	BidID       sql.NullInt32
+	Name        string
+	ContentType string
+	Size        int64
+	SHA256      string
+	StorageKey  string
+	CreatorID   sql.NullInt32
+	CreatedAt   time.Time
+}
+
+func scanAttachment(row pgx.Row, a *attachmentDB) error {
+	return row.Scan(
+		&a.ID,
+		&a.TenderID,
+		&a.BidID,
+		&a.Name,
+		&a.ContentType,
+		&a.Size,
+		&a.SHA256,
+		&a.StorageKey,
+		&a.CreatorID,
+		&a.CreatedAt,
+	)
+}
+
+func newAttachmentDB(a *ent.Attachment) *attachmentDB {
+	return &attachmentDB{
+		TenderID:    newNullID(a.TenderID),
+		BidID:       newNullID(a.BidID),
+		Name:        a.Name,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		SHA256:      a.SHA256,
+		StorageKey:  a.StorageKey,
+		CreatorID:   newNullID(a.CreatorID),
+		CreatedAt:   a.CreatedAt,
+	}
+}
+
+func newAttachment(row *attachmentDB) *ent.Attachment {
+	return &ent.Attachment{
+		ID:          row.ID,
+		TenderID:    int(row.TenderID.Int32),
+		BidID:       int(row.BidID.Int32),
+		Name:        row.Name,
+		ContentType: row.ContentType,
+		Size:        row.Size,
+		SHA256:      row.SHA256,
+		StorageKey:  row.StorageKey,
+		CreatorID:   int(row.CreatorID.Int32),
+		CreatedAt:   row.CreatedAt,
+	}
+}
+
+func newNullID(id int) sql.NullInt32 {
+	return sql.NullInt32{Int32: int32(id), Valid: id != 0}
+}
@@ -0,0 +1,225 @@
+package auction
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	ent "tender-workspace/internal/entity"
+	"tender-workspace/internal/repo/bids"
+	"tender-workspace/internal/utils/metrics"
+	mc "tender-workspace/internal/utils/myconstants"
+	e "tender-workspace/internal/utils/myerrors"
+	sm "tender-workspace/internal/utils/statemachine"
+	"tender-workspace/internal/utils/tracing"
+	"tender-workspace/services/postgres"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type Repo interface {
+	// PlaceOffer сохраняет ставку, если торги идут и она ниже лучшей на минимальный шаг, и продлевает торги
+	PlaceOffer(ctx context.Context, offer *ent.AuctionOffer) (*ent.AuctionOffer, error)
+	// GetRanking возвращает лучшую ставку каждого действующего предложения, от меньшей цены к большей
+	GetRanking(ctx context.Context, tenderID int) ([]*ent.AuctionRank, error)
+	// CloseEnded закрывает один аукцион с истекшими торгами и возвращает причину закрытия его тендера,
+	// nil - таких аукционов нет
+	CloseEnded(ctx context.Context, now time.Time) (*ent.Auction, string, error)
+}
+
+var _ Repo = (*RepoLayer)(nil)
+
+type RepoLayer struct {
+	Client postgres.Client
+	Logger *zap.Logger
+}
+
+func NewRepoLayer(client postgres.Client, logger *zap.Logger) *RepoLayer {
+	return &RepoLayer{
+		Client: postgres.Instrument(client, "auction", metrics.ObserveQuery, tracing.TraceQuery),
+		Logger: logger,
+	}
+}
+
+var (
+	// ставки одного аукциона принимаются по очереди под блокировкой его строки
+	sqlRowLockAuction = `SELECT start_price, min_decrement, starts_at, ends_at, extension_window, extension, closed_at 
+	FROM tender_auctions WHERE tender_id=$1 FOR UPDATE`
+	// ставки отозванных и отклоненных предложений не учитываются
+	sqlRowGetBestPrice = `SELECT MIN(o.price) FROM auction_offers o JOIN bids b ON b.id = o.bid_id 
+	WHERE o.tender_id=$1 AND b.status='Published'`
+	sqlRowCreateOffer   = `INSERT INTO auction_offers (tender_id, bid_id, price, created_at) VALUES ($1, $2, $3, $4) RETURNING id`
+	sqlRowExtendAuction = `UPDATE tender_auctions SET ends_at=$2 WHERE tender_id=$1`
+	sqlRowGetRanking    = `SELECT bid_id, name, price, created_at, offers FROM (
+		SELECT DISTINCT ON (o.bid_id) o.bid_id, b.name, o.price, o.created_at, o.id, COUNT(*) OVER (PARTITION BY o.bid_id) AS offers 
+		FROM auction_offers o JOIN bids b ON b.id = o.bid_id 
+		WHERE o.tender_id=$1 AND b.status IN ('Published', 'Approved') 
+		ORDER BY o.bid_id, o.price, o.id
+	) best ORDER BY price, id`
+	// аукционы, заблокированные другой репликой, пропускаются, поэтому тендер закрывается ровно один раз
+	sqlRowLockEndedAuction = `SELECT a.tender_id, t.status FROM tender_auctions a JOIN tender t ON t.id = a.tender_id 
+	WHERE a.closed_at IS NULL AND a.ends_at <= $1 
+	ORDER BY a.ends_at LIMIT 1 
+	FOR UPDATE OF a SKIP LOCKED`
+	sqlRowGetWinnerBid = `SELECT o.bid_id FROM auction_offers o JOIN bids b ON b.id = o.bid_id 
+	WHERE o.tender_id=$1 AND b.status='Published' 
+	ORDER BY o.price, o.id LIMIT 1`
+	sqlRowEndAuctionTender = `UPDATE tender SET status='Closed', closed_reason='AuctionEnded', version=version+1, updated_at=$2 
+	WHERE id=$1 AND status='Published'`
+	sqlRowCreateTenderHistory = `INSERT INTO tender_history (tender_id, name, description, type, status, version, created_at)
+	SELECT id, name, description, type, status, version, $2 FROM tender WHERE id=$1`
+	sqlRowCloseAuction = `UPDATE tender_auctions SET closed_at=$2, winner_bid_id=$3 WHERE tender_id=$1`
+)
+
+func (r *RepoLayer) PlaceOffer(ctx context.Context, offer *ent.AuctionOffer) (*ent.AuctionOffer, error) {
+	tx, err := r.Client.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Откат транзакции в случае ошибки
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+	var auction ent.Auction
+	var extensionWindow, extension int
+	err = tx.QueryRow(ctx, sqlRowLockAuction, offer.TenderID).Scan(
+		&auction.StartPrice,
+		&auction.MinDecrement,
+		&auction.StartsAt,
+		&auction.EndsAt,
+		&extensionWindow,
+		&extension,
+		&auction.ClosedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if auction.Status(offer.CreatedAt) != mc.AuctionRunning {
+		err = e.ErrAuctionNotActive
+		return nil, err
+	}
+	var best decimal.NullDecimal
+	if err = tx.QueryRow(ctx, sqlRowGetBestPrice, offer.TenderID).Scan(&best); err != nil {
+		return nil, err
+	}
+	// первая ставка не выше стартовой цены, следующие ниже лучшей хотя бы на шаг
+	ceiling := auction.StartPrice
+	if best.Valid {
+		ceiling = best.Decimal.Sub(auction.MinDecrement)
+	}
+	if offer.Price.GreaterThan(ceiling) {
+		err = e.ErrAuctionOutbid
+		return nil, err
+	}
+	if err = tx.QueryRow(ctx, sqlRowCreateOffer, offer.TenderID, offer.BidID, offer.Price, offer.CreatedAt).Scan(&offer.ID); err != nil {
+		return nil, err
+	}
+	// ставка в последние секунды переносит окончание, чтобы остальные успели ответить
+	window := time.Duration(extensionWindow) * time.Second
+	if auction.EndsAt.Sub(offer.CreatedAt) < window {
+		endsAt := offer.CreatedAt.Add(time.Duration(extension) * time.Second)
+		if endsAt.After(auction.EndsAt) {
+			if _, err = tx.Exec(ctx, sqlRowExtendAuction, offer.TenderID, endsAt); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return offer, nil
+}
+
+func (r *RepoLayer) GetRanking(ctx context.Context, tenderID int) ([]*ent.AuctionRank, error) {
+	rows, err := r.Client.Query(ctx, sqlRowGetRanking, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ranks []*ent.AuctionRank
+	for rows.Next() {
+		var rank ent.AuctionRank
+		err = rows.Scan(&rank.BidID, &rank.BidName, &rank.Price, &rank.PlacedAt, &rank.Offers)
+		if err != nil {
+			return nil, err
+		}
+		rank.Rank = len(ranks) + 1
+		ranks = append(ranks, &rank)
+	}
+	return ranks, rows.Err()
+}
+
+// CloseEnded
+// Awards the lowest offer of published bid, without such offers the tender is closed
+// with no winner. Auction of the tender that isn't published anymore is just marked closed.
+func (r *RepoLayer) CloseEnded(ctx context.Context, now time.Time) (*ent.Auction, string, error) {
+	tx, err := r.Client.Begin(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Откат транзакции в случае ошибки
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+	auction := &ent.Auction{ClosedAt: &now}
+	var tenderStatus string
+	err = tx.QueryRow(ctx, sqlRowLockEndedAuction, now).Scan(&auction.TenderID, &tenderStatus)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil
+			tx.Rollback(ctx)
+			return nil, "", nil
+		}
+		return nil, "", err
+	}
+	closedReason := ""
+	if tenderStatus == "Published" {
+		if err = sm.Tender.Check(tenderStatus, "Closed", sm.RoleSystem); err != nil {
+			return nil, "", err
+		}
+		closedReason, err = closeTender(ctx, tx, auction, now)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	winner := sql.NullInt32{Int32: int32(auction.WinnerBidID), Valid: auction.WinnerBidID != 0}
+	if _, err = tx.Exec(ctx, sqlRowCloseAuction, auction.TenderID, now, winner); err != nil {
+		return nil, "", err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return nil, "", fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return auction, closedReason, nil
+}
+
+// closeTender
+// Closes the published tender of the ended auction, the winner is set to the auction.
+func closeTender(ctx context.Context, tx pgx.Tx, auction *ent.Auction, now time.Time) (string, error) {
+	var winnerBidID int
+	err := tx.QueryRow(ctx, sqlRowGetWinnerBid, auction.TenderID).Scan(&winnerBidID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", err
+	}
+	if winnerBidID != 0 {
+		if _, err = bids.AwardInTx(ctx, tx, winnerBidID, now); err != nil {
+			return "", err
+		}
+		auction.WinnerBidID = winnerBidID
+		return mc.ClosedAwarded, nil
+	}
+	if _, err = tx.Exec(ctx, sqlRowEndAuctionTender, auction.TenderID, now); err != nil {
+		return "", err
+	}
+	if _, err = tx.Exec(ctx, sqlRowCreateTenderHistory, auction.TenderID, now); err != nil {
+		return "", err
+	}
+	return mc.ClosedAuctionEnded, nil
+}
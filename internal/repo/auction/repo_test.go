@@ -0,0 +1,371 @@
+package auction
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	ent "tender-workspace/internal/entity"
+	mc "tender-workspace/internal/utils/myconstants"
+	e "tender-workspace/internal/utils/myerrors"
+	"tender-workspace/services/postgres"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/shopspring/decimal"
+)
+
+type offerStub struct {
+	id        int
+	bidID     int
+	price     decimal.Decimal
+	bidStatus string
+}
+
+type execStub struct {
+	sql  string
+	args []any
+}
+
+// dbStub
+// In-memory auction of one tender. Queries of the repo are answered the same way
+// PSQL answers them, so the repo code runs unchanged inside its transaction.
+type dbStub struct {
+	postgres.Client
+	pgx.Tx
+	auction         ent.Auction
+	extensionWindow int
+	extension       int
+	tenderStatus    string
+	offers          []offerStub
+	execs           []execStub
+	committed       bool
+}
+
+func (db *dbStub) Begin(ctx context.Context) (pgx.Tx, error) {
+	return db, nil
+}
+
+func (db *dbStub) Commit(ctx context.Context) error {
+	db.committed = true
+	return nil
+}
+
+func (db *dbStub) Rollback(ctx context.Context) error {
+	return nil
+}
+
+func (db *dbStub) QueryRow(ctx context.Context, query string, args ...any) pgx.Row {
+	a := &db.auction
+	switch {
+	case query == sqlRowLockAuction:
+		return rowStub{a.StartPrice, a.MinDecrement, a.StartsAt, a.EndsAt, db.extensionWindow, db.extension, a.ClosedAt}
+	case query == sqlRowGetBestPrice:
+		var best decimal.NullDecimal
+		if lowest := db.lowest(); lowest != nil {
+			best = decimal.NewNullDecimal(lowest.price)
+		}
+		return rowStub{best}
+	case query == sqlRowCreateOffer:
+		offer := offerStub{id: len(db.offers) + 1, bidID: args[1].(int), price: args[2].(decimal.Decimal), bidStatus: "Published"}
+		db.offers = append(db.offers, offer)
+		return rowStub{offer.id}
+	case query == sqlRowLockEndedAuction:
+		if a.ClosedAt != nil || a.EndsAt.After(args[0].(time.Time)) {
+			return rowStub{pgx.ErrNoRows}
+		}
+		return rowStub{a.TenderID, db.tenderStatus}
+	case query == sqlRowGetWinnerBid:
+		lowest := db.lowest()
+		if lowest == nil {
+			return rowStub{pgx.ErrNoRows}
+		}
+		return rowStub{lowest.bidID}
+	// запросы bids.AwardInTx
+	case strings.Contains(query, "FROM bids WHERE id=$1 FOR UPDATE"):
+		return rowStub{args[0], "Offer", "", db.bidStatus(args[0].(int)), 1, a.TenderID, 1, "Organization", sql.NullInt32{Int32: 1, Valid: true}}
+	case strings.HasPrefix(strings.TrimSpace(query), "UPDATE bids SET status='Approved'"):
+		db.execs = append(db.execs, execStub{sql: query, args: args})
+		return rowStub{args[0], "Offer", "", "Approved", 2, a.TenderID}
+	}
+	panic("unexpected query: " + query)
+}
+
+func (db *dbStub) Exec(ctx context.Context, query string, args ...any) (pgconn.CommandTag, error) {
+	db.execs = append(db.execs, execStub{sql: query, args: args})
+	if query == sqlRowExtendAuction {
+		db.auction.EndsAt = args[1].(time.Time)
+	}
+	return pgconn.NewCommandTag("UPDATE 1"), nil
+}
+
+func (db *dbStub) Query(ctx context.Context, query string, args ...any) (pgx.Rows, error) {
+	// другие опубликованные предложения аукциона отклоняет AwardInTx, тесту хватает пустого результата
+	return &rowsStub{}, nil
+}
+
+// lowest возвращает лучшую ставку действующих предложений, из равных - более раннюю
+func (db *dbStub) lowest() *offerStub {
+	var lowest *offerStub
+	for i, offer := range db.offers {
+		if offer.bidStatus != "Published" {
+			continue
+		}
+		if lowest == nil || offer.price.LessThan(lowest.price) {
+			lowest = &db.offers[i]
+		}
+	}
+	return lowest
+}
+
+func (db *dbStub) bidStatus(bidID int) string {
+	for _, offer := range db.offers {
+		if offer.bidID == bidID {
+			return offer.bidStatus
+		}
+	}
+	return "Published"
+}
+
+func (db *dbStub) executed(query string) []execStub {
+	var execs []execStub
+	for _, exec := range db.execs {
+		if exec.sql == query {
+			execs = append(execs, exec)
+		}
+	}
+	return execs
+}
+
+// rowStub заполняет аргументы Scan по порядку, ошибка вместо значений возвращается из Scan
+type rowStub []any
+
+func (row rowStub) Scan(dest ...any) error {
+	if len(row) == 1 {
+		if err, ok := row[0].(error); ok {
+			return err
+		}
+	}
+	for i, value := range row {
+		reflect.ValueOf(dest[i]).Elem().Set(reflect.ValueOf(value))
+	}
+	return nil
+}
+
+type rowsStub struct {
+	pgx.Rows
+}
+
+func (rows *rowsStub) Next() bool { return false }
+func (rows *rowsStub) Close()     {}
+func (rows *rowsStub) Err() error { return nil }
+
+var now = time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+
+func newDBStub(offers ...offerStub) *dbStub {
+	return &dbStub{
+		auction: ent.Auction{
+			TenderID:     5,
+			StartPrice:   decimal.RequireFromString("1000"),
+			MinDecrement: decimal.RequireFromString("10"),
+			StartsAt:     now.Add(-time.Hour),
+			EndsAt:       now.Add(time.Hour),
+		},
+		extensionWindow: 60,
+		extension:       120,
+		tenderStatus:    "Published",
+		offers:          offers,
+	}
+}
+
+func offer(id, bidID int, price string, bidStatus string) offerStub {
+	return offerStub{id: id, bidID: bidID, price: decimal.RequireFromString(price), bidStatus: bidStatus}
+}
+
+func TestPlaceOfferDecrement(t *testing.T) {
+	closedAt := now.Add(-time.Minute)
+	tests := []struct {
+		name    string
+		offers  []offerStub
+		price   string
+		prepare func(a *ent.Auction)
+		want    error
+	}{
+		{name: "first offer at start price", price: "1000"},
+		{name: "first offer above start price", price: "1000.01", want: e.ErrAuctionOutbid},
+		{name: "exactly one decrement below best", offers: []offerStub{offer(1, 1, "900", "Published")}, price: "890"},
+		{name: "more than decrement below best", offers: []offerStub{offer(1, 1, "900", "Published")}, price: "500.50"},
+		{name: "less than decrement below best", offers: []offerStub{offer(1, 1, "900", "Published")}, price: "890.01", want: e.ErrAuctionOutbid},
+		{name: "equal to best", offers: []offerStub{offer(1, 1, "900", "Published")}, price: "900", want: e.ErrAuctionOutbid},
+		{name: "best of several offers", offers: []offerStub{offer(1, 1, "900", "Published"), offer(2, 2, "850", "Published")}, price: "845", want: e.ErrAuctionOutbid},
+		{name: "offers of canceled bids are ignored", offers: []offerStub{offer(1, 1, "900", "Published"), offer(2, 2, "700", "Canceled")}, price: "890"},
+		{name: "only canceled offers, start price applies", offers: []offerStub{offer(1, 2, "700", "Canceled")}, price: "1000"},
+		{name: "not started", price: "900", prepare: func(a *ent.Auction) { a.StartsAt = now.Add(time.Minute) }, want: e.ErrAuctionNotActive},
+		{name: "ended", price: "900", prepare: func(a *ent.Auction) { a.EndsAt = now }, want: e.ErrAuctionNotActive},
+		{name: "closed", price: "900", prepare: func(a *ent.Auction) { a.ClosedAt = &closedAt }, want: e.ErrAuctionNotActive},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newDBStub(tt.offers...)
+			if tt.prepare != nil {
+				tt.prepare(&db.auction)
+			}
+			r := &RepoLayer{Client: db}
+			placed, err := r.PlaceOffer(context.Background(), &ent.AuctionOffer{
+				TenderID:  5,
+				BidID:     3,
+				Price:     decimal.RequireFromString(tt.price),
+				CreatedAt: now,
+			})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("PlaceOffer() error = %v, want %v", err, tt.want)
+			}
+			if tt.want != nil {
+				if len(db.offers) != len(tt.offers) || db.committed {
+					t.Errorf("rejected offer is saved")
+				}
+				return
+			}
+			if placed.ID != len(tt.offers)+1 || !db.committed {
+				t.Errorf("PlaceOffer() = %+v, committed %v", placed, db.committed)
+			}
+		})
+	}
+}
+
+func TestPlaceOfferExtension(t *testing.T) {
+	endsAt := now.Add(time.Hour)
+	tests := []struct {
+		name            string
+		placedAt        time.Time
+		extensionWindow int
+		extension       int
+		want            time.Time
+	}{
+		{name: "before the window", placedAt: endsAt.Add(-61 * time.Second), extensionWindow: 60, extension: 120, want: endsAt},
+		{name: "at the window start", placedAt: endsAt.Add(-60 * time.Second), extensionWindow: 60, extension: 120, want: endsAt},
+		{name: "inside the window", placedAt: endsAt.Add(-30 * time.Second), extensionWindow: 60, extension: 120, want: endsAt.Add(90 * time.Second)},
+		{name: "last second", placedAt: endsAt.Add(-time.Second), extensionWindow: 60, extension: 120, want: endsAt.Add(119 * time.Second)},
+		// продление не сокращает торги
+		{name: "extension shorter than time left", placedAt: endsAt.Add(-100 * time.Second), extensionWindow: 300, extension: 60, want: endsAt},
+		{name: "no window", placedAt: endsAt.Add(-time.Second), extension: 120, want: endsAt},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newDBStub()
+			db.extensionWindow, db.extension = tt.extensionWindow, tt.extension
+			r := &RepoLayer{Client: db}
+			_, err := r.PlaceOffer(context.Background(), &ent.AuctionOffer{
+				TenderID:  5,
+				BidID:     3,
+				Price:     decimal.RequireFromString("990"),
+				CreatedAt: tt.placedAt,
+			})
+			if err != nil {
+				t.Fatalf("PlaceOffer() error = %v", err)
+			}
+			if !db.auction.EndsAt.Equal(tt.want) {
+				t.Errorf("ends at %v, want %v", db.auction.EndsAt, tt.want)
+			}
+			if extended := len(db.executed(sqlRowExtendAuction)) != 0; extended != !tt.want.Equal(endsAt) {
+				t.Errorf("auction extended = %v", extended)
+			}
+		})
+	}
+}
+
+func TestCloseEnded(t *testing.T) {
+	tests := []struct {
+		name         string
+		offers       []offerStub
+		tenderStatus string
+		wantReason   string
+		wantWinner   int
+	}{
+		{
+			name:         "lowest offer wins",
+			offers:       []offerStub{offer(1, 11, "900", "Published"), offer(2, 12, "870", "Published"), offer(3, 12, "850", "Published"), offer(4, 13, "860", "Published")},
+			tenderStatus: "Published",
+			wantReason:   mc.ClosedAwarded,
+			wantWinner:   12,
+		},
+		{
+			name:         "earlier of equal offers wins",
+			offers:       []offerStub{offer(1, 11, "900", "Published"), offer(2, 13, "850", "Published"), offer(3, 12, "850", "Published")},
+			tenderStatus: "Published",
+			wantReason:   mc.ClosedAwarded,
+			wantWinner:   13,
+		},
+		{
+			name:         "offer of canceled bid doesn't win",
+			offers:       []offerStub{offer(1, 11, "900", "Published"), offer(2, 12, "500", "Canceled")},
+			tenderStatus: "Published",
+			wantReason:   mc.ClosedAwarded,
+			wantWinner:   11,
+		},
+		{
+			name:         "no valid offers",
+			offers:       []offerStub{offer(1, 12, "500", "Canceled")},
+			tenderStatus: "Published",
+			wantReason:   mc.ClosedAuctionEnded,
+		},
+		{
+			name:         "tender is not published anymore",
+			offers:       []offerStub{offer(1, 11, "900", "Published")},
+			tenderStatus: "Closed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newDBStub(tt.offers...)
+			db.auction.EndsAt = now.Add(-time.Second)
+			db.tenderStatus = tt.tenderStatus
+			r := &RepoLayer{Client: db}
+			closed, reason, err := r.CloseEnded(context.Background(), now)
+			if err != nil {
+				t.Fatalf("CloseEnded() error = %v", err)
+			}
+			if closed == nil || closed.TenderID != 5 || closed.WinnerBidID != tt.wantWinner || reason != tt.wantReason {
+				t.Fatalf("CloseEnded() = %+v, %q, want winner %d, %q", closed, reason, tt.wantWinner, tt.wantReason)
+			}
+			if !db.committed {
+				t.Error("transaction is not committed")
+			}
+			closes := db.executed(sqlRowCloseAuction)
+			wantClose := sql.NullInt32{Int32: int32(tt.wantWinner), Valid: tt.wantWinner != 0}
+			if len(closes) != 1 || closes[0].args[2] != wantClose {
+				t.Errorf("auction closed %v, want winner %v", closes, wantClose)
+			}
+			var approved []any
+			for _, exec := range db.execs {
+				if strings.HasPrefix(strings.TrimSpace(exec.sql), "UPDATE bids SET status='Approved'") {
+					approved = append(approved, exec.args[0])
+				}
+			}
+			if tt.wantWinner != 0 && (len(approved) != 1 || approved[0] != tt.wantWinner) {
+				t.Errorf("approved bids %v, want %d", approved, tt.wantWinner)
+			}
+			if tt.wantWinner == 0 && len(approved) != 0 {
+				t.Errorf("approved bids %v, want none", approved)
+			}
+			if ended := len(db.executed(sqlRowEndAuctionTender)) != 0; ended != (tt.wantReason == mc.ClosedAuctionEnded) {
+				t.Errorf("tender closed without winner = %v", ended)
+			}
+		})
+	}
+}
+
+func TestCloseEndedNothingToClose(t *testing.T) {
+	db := newDBStub(offer(1, 11, "900", "Published"))
+	r := &RepoLayer{Client: db}
+	closed, reason, err := r.CloseEnded(context.Background(), now)
+	if closed != nil || reason != "" || err != nil {
+		t.Fatalf("CloseEnded() = %+v, %q, %v, want nothing closed", closed, reason, err)
+	}
+	if db.committed || len(db.execs) != 0 {
+		t.Errorf("running auction is changed: %v", db.execs)
+	}
+}
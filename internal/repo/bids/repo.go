@@ -6,7 +6,13 @@ import (
 	"errors"
 	"fmt"
 	ent "tender-workspace/internal/entity"
+	"tender-workspace/internal/utils/metrics"
 	mc "tender-workspace/internal/utils/myconstants"
+	e "tender-workspace/internal/utils/myerrors"
+	"tender-workspace/internal/utils/pagination"
+	sm "tender-workspace/internal/utils/statemachine"
+	"tender-workspace/internal/utils/tracing"
+	"tender-workspace/services/postgres"
 	"time"
 
 	"github.com/huandu/go-sqlbuilder"
@@ -18,6 +24,35 @@ type UpdateBid struct {
 	BidID       int
 	Name        string
 	Description string
+	// Offer новое ценовое предложение, nil - предложение не меняется
+	Offer *ent.BidOffer
+	// RestoreOffer возвращает предложение из снимка версии: Offer ссылается на уже сохраненные позиции,
+	// nil означает версию без предложения
+	RestoreOffer bool
+	// OverBudget отметка о превышении бюджета, меняется вместе с предложением
+	OverBudget bool
+	// RevealedAt время раскрытия закрытого предложения, nil - не раскрывается
+	RevealedAt *time.Time
+}
+
+type UserBidsProps struct {
+	UserID          int
+	OrganizationIDs []int
+	Page            pagination.Page
+}
+
+type TenderBidsProps struct {
+	TenderID int
+	LotID    int // 0 - предложения на все лоты
+	Page     pagination.Page
+}
+
+type SubmitDecisionProps struct {
+	BidID    int
+	LotID    int // 0 - решение по предложению на тендер без лотов
+	UserID   int
+	Decision string
+	Quorum   int
 }
 
 var (
@@ -31,10 +66,84 @@ var (
 		author_type,
 		organization_id,
 		created_at,
-		updated_at)
+		updated_at,
+		currency,
+		total_net,
+		total_vat,
+		total,
+		offer_version,
+		over_budget,
+		commitment)
 	VALUES (
-		$1, $2, $3, $4, $5, $6, $7, $8, $9, $9
-	) RETURNING id, name, description, status, version, tender_id, creator_id, author_type, organization_id, created_at`
+		$1, $2, $3, $4, $5, $6, $7, $8, $9, $9, $10, $11, $12, $13, $14, $15, $16
+	) RETURNING id, name, description, status, version, tender_id, creator_id, author_type, organization_id, created_at, currency, total_net, total_vat, total, offer_version, over_budget, commitment, revealed_at`
+	sqlRowUpdateBidStatus  = `UPDATE bids SET status=$1, version=$2, updated_at=$3 WHERE id=$4 AND version=$2-1 RETURNING id, name, description, status, version, tender_id, creator_id, author_type, organization_id, created_at, currency, total_net, total_vat, total, offer_version, over_budget, commitment, revealed_at`
+	sqlRowCreateBidHistory = `INSERT INTO bids_history (
+		bid_id,
+		name,
+		description,
+		status,
+		version,
+		created_at,
+		currency,
+		total_net,
+		total_vat,
+		total,
+		offer_version
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+	sqlRowGetBidVersion = `SELECT bid_id, name, description, status, version, created_at, currency, total_net, total_vat, total, offer_version 
+	FROM bids_history WHERE bid_id=$1 AND version=$2`
+	sqlRowLockBid      = `SELECT id, name, description, status, version, tender_id, creator_id, author_type, organization_id, created_at, currency, total_net, total_vat, total, offer_version, over_budget, commitment, revealed_at FROM bids WHERE id=$1 FOR UPDATE`
+	sqlRowSaveDecision = `INSERT INTO bid_decisions (bid_id, user_id, decision, created_at) VALUES ($1, $2, $3, $4) 
+	ON CONFLICT (bid_id, user_id) DO UPDATE SET decision=EXCLUDED.decision, created_at=EXCLUDED.created_at`
+	sqlRowCountDecisions = `SELECT 
+		COUNT(*) FILTER (WHERE decision='Approved'), 
+		COUNT(*) FILTER (WHERE decision='Rejected') 
+	FROM bid_decisions WHERE bid_id=$1`
+	sqlRowAwardTender = `UPDATE tender SET 
+		status='Closed', 
+		closed_reason='Awarded', 
+		version=version+1, 
+		winner_bid_id=$2, 
+		executor_organization_id=$3, 
+		awarded_at=$4, 
+		updated_at=$4 
+	WHERE id=$1 AND status='Published'`
+	sqlRowCreateTenderHistory = `INSERT INTO tender_history (tender_id, name, description, type, status, version, created_at)
+	SELECT id, name, description, type, status, version, $2 FROM tender WHERE id=$1`
+	sqlRowApproveBid = `UPDATE bids SET status='Approved', version=version+1, updated_at=$2 
+	WHERE id=$1 AND status='Published' 
+	RETURNING id, name, description, status, version, tender_id, creator_id, author_type, organization_id, created_at, currency, total_net, total_vat, total, offer_version, over_budget, commitment, revealed_at`
+	sqlRowRejectCompetingBids = `UPDATE bids SET status='Rejected', version=version+1, updated_at=$3 
+	WHERE tender_id=$1 AND id<>$2 AND status='Published' 
+	RETURNING id, name, description, status, version, currency, total_net, total_vat, total, offer_version`
+	sqlRowCreateBidLot    = `INSERT INTO bid_lots (bid_id, lot_id) VALUES ($1, $2)`
+	sqlRowGetBidLots      = `SELECT bid_id, lot_id, status FROM bid_lots WHERE bid_id = ANY($1) ORDER BY bid_id, lot_id`
+	sqlRowLockBidLot      = `SELECT status FROM bid_lots WHERE bid_id=$1 AND lot_id=$2 FOR UPDATE`
+	sqlRowSaveLotDecision = `INSERT INTO bid_lot_decisions (bid_id, lot_id, user_id, decision, created_at) VALUES ($1, $2, $3, $4, $5) 
+	ON CONFLICT (bid_id, lot_id, user_id) DO UPDATE SET decision=EXCLUDED.decision, created_at=EXCLUDED.created_at`
+	sqlRowCountLotDecisions = `SELECT 
+		COUNT(*) FILTER (WHERE decision='Approved'), 
+		COUNT(*) FILTER (WHERE decision='Rejected') 
+	FROM bid_lot_decisions WHERE bid_id=$1 AND lot_id=$2`
+	sqlRowSetBidLotStatus = `UPDATE bid_lots SET status=$3 WHERE bid_id=$1 AND lot_id=$2`
+	// лот присуждается только один раз и только пока тендер опубликован
+	sqlRowAwardLot = `UPDATE tender_lots l SET winner_bid_id=$2, executor_organization_id=$3, awarded_at=$4 
+	FROM tender t 
+	WHERE l.id=$1 AND l.winner_bid_id IS NULL AND t.id = l.tender_id AND t.status='Published'`
+	sqlRowRejectCompetingLotBids = `UPDATE bid_lots SET status='Rejected' WHERE lot_id=$1 AND bid_id<>$2 AND status='Pending' RETURNING bid_id`
+	// предложение без нерешенных лотов принимается, если выиграло хотя бы один лот, иначе отклоняется
+	sqlRowLockSettlement = `SELECT b.status, b.version, 
+		CASE WHEN EXISTS (SELECT 1 FROM bid_lots WHERE bid_id=b.id AND status='Awarded') THEN 'Approved' ELSE 'Rejected' END 
+	FROM bids b 
+	WHERE b.id=$1 AND b.status='Published' AND NOT EXISTS (SELECT 1 FROM bid_lots WHERE bid_id=b.id AND status='Pending') 
+	FOR UPDATE OF b`
+	// тендер закрывается, когда присуждены все его лоты
+	sqlRowCloseLotTender = `UPDATE tender SET status='Closed', closed_reason='Awarded', version=version+1, awarded_at=$2, updated_at=$2 
+	WHERE id=$1 AND status='Published' AND NOT EXISTS (SELECT 1 FROM tender_lots WHERE tender_id=$1 AND winner_bid_id IS NULL)`
+	sqlRowGetBidItems = `SELECT i.position, i.name, i.quantity, i.unit, i.unit_price, i.vat_rate, i.currency, i.net_amount, i.vat_amount, i.amount 
+	FROM bid_items i JOIN bids b ON b.id = i.bid_id AND b.offer_version = i.offer_version 
+	WHERE b.id=$1 ORDER BY i.position`
 )
 
 type Repo interface {
@@ -43,23 +152,31 @@ type Repo interface {
 	GetStatus(ctx context.Context, bidID int) (string, error)
 	UpdateStatus(ctx context.Context, bidID int, status string, newBidVersion int) (*ent.Bid, error)
 	Update(ctx context.Context, newData *UpdateBid, newBidVersion int) (*ent.Bid, error)
-	GetTenderBids(ctx context.Context, tenderID int) ([]*ent.Bid, error)
-	GetOrganizationBids(ctx context.Context, organizationID int) ([]*ent.Bid, error)
-	GetUserBids(ctx context.Context, creatorID int) ([]*ent.Bid, error)
+	// GetTenderBids возвращает страницу опубликованных предложений по тендеру
+	GetTenderBids(ctx context.Context, params *TenderBidsProps) ([]*ent.Bid, *pagination.Cursors, error)
+	// GetUserBids возвращает страницу предложений сотрудника и организаций, за которые он отвечает
+	GetUserBids(ctx context.Context, params *UserBidsProps) ([]*ent.Bid, *pagination.Cursors, error)
 	UserHasBid(ctx context.Context, userID, tenderID int) (bool, error)
 	OrganizationHasBid(ctx context.Context, orgID, tenderID int) (bool, error)
+	GetVersion(ctx context.Context, bidID, version int) (*ent.BidVersion, error)
+	// GetLots возвращает лоты, на которые подано предложение
+	GetLots(ctx context.Context, bidID int) ([]*ent.BidLot, error)
+	// GetItems возвращает позиции текущего ценового предложения
+	GetItems(ctx context.Context, bidID int) ([]*ent.BidItem, error)
+	// SubmitDecision saves decision of the approver and changes bid status once quorum is reached
+	SubmitDecision(ctx context.Context, props *SubmitDecisionProps) (*ent.BidDecisionResult, error)
 }
 
 var _ Repo = (*RepoLayer)(nil)
 
 type RepoLayer struct {
-	Client *pgx.Conn
+	Client postgres.Client
 	Logger *zap.Logger
 }
 
-func NewRepoLayer(client *pgx.Conn, logger *zap.Logger) *RepoLayer {
+func NewRepoLayer(client postgres.Client, logger *zap.Logger) *RepoLayer {
 	return &RepoLayer{
-		Client: client,
+		Client: postgres.Instrument(client, "bids", metrics.ObserveQuery, tracing.TraceQuery),
 		Logger: logger,
 	}
 }
@@ -67,7 +184,17 @@ func NewRepoLayer(client *pgx.Conn, logger *zap.Logger) *RepoLayer {
 func (r *RepoLayer) Create(ctx context.Context, initData *ent.Bid) (*ent.Bid, error) {
 	timeNow := time.Now()
 	initDataDB := newBidDB(initData)
-	row := r.Client.QueryRow(ctx, sqlRowCreateBid,
+	tx, err := r.Client.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Откат транзакции в случае ошибки
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+	row := tx.QueryRow(ctx, sqlRowCreateBid,
 		initDataDB.Name,
 		initDataDB.Description,
 		initDataDB.Status,
@@ -77,24 +204,108 @@ func (r *RepoLayer) Create(ctx context.Context, initData *ent.Bid) (*ent.Bid, er
 		initDataDB.AuthorType,
 		initDataDB.OrganizationID,
 		timeNow,
+		initDataDB.Currency,
+		initDataDB.TotalNet,
+		initDataDB.TotalVat,
+		initDataDB.Total,
+		initDataDB.OfferVersion,
+		initDataDB.OverBudget,
+		initDataDB.Commitment,
 	)
 	var bidDB bidDB
-	err := row.Scan(
-		&bidDB.ID,
-		&bidDB.Name,
-		&bidDB.Description,
-		&bidDB.Status,
-		&bidDB.Version,
-		&bidDB.TenderID,
-		&bidDB.CreatorID,
-		&bidDB.AuthorType,
-		&bidDB.OrganizationID,
-		&bidDB.CreatedAt,
-	)
+	err = scanBid(row, &bidDB)
 	if err != nil {
 		return nil, err
 	}
-	return newBid(&bidDB), nil
+	if initData.Offer != nil {
+		if err = createItems(ctx, tx, bidDB.ID, initDataDB.Version, initData.Offer.Items); err != nil {
+			return nil, err
+		}
+	}
+	if err = createLots(ctx, tx, bidDB.ID, initData.Lots); err != nil {
+		return nil, err
+	}
+	if err = createHistory(ctx, tx, &bidDB, timeNow); err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	bid := newBid(&bidDB)
+	bid.Lots = initData.Lots
+	return bid, nil
+}
+
+// createLots
+// Saves lots targeted by the new bid, they aren't changed later.
+func createLots(ctx context.Context, tx pgx.Tx, bidID int, lots []*ent.BidLot) error {
+	for _, lot := range lots {
+		if _, err := tx.Exec(ctx, sqlRowCreateBidLot, bidID, lot.LotID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createItems
+// Saves line items of the offer set in the bid version. Items are never changed,
+// new offer gets new rows, so older versions can still be restored.
+func createItems(ctx context.Context, tx pgx.Tx, bidID, offerVersion int, items []*ent.BidItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	ib := sqlbuilder.PostgreSQL.NewInsertBuilder().InsertInto("bid_items").
+		Cols("bid_id", "offer_version", "position", "name", "quantity", "unit", "unit_price", "vat_rate", "currency", "net_amount", "vat_amount", "amount")
+	for _, item := range items {
+		ib.Values(bidID, offerVersion, item.Position, item.Name, item.Quantity, item.Unit, item.UnitPrice,
+			item.VatRate, item.Currency, item.NetAmount, item.VatAmount, item.Amount)
+	}
+	query, args := ib.Build()
+	_, err := tx.Exec(ctx, query, args...)
+	return err
+}
+
+// scanBid читает колонки предложения в том порядке, в котором они выбираются во всем репозитории
+func scanBid(row pgx.Row, bid *bidDB) error {
+	return row.Scan(
+		&bid.ID,
+		&bid.Name,
+		&bid.Description,
+		&bid.Status,
+		&bid.Version,
+		&bid.TenderID,
+		&bid.CreatorID,
+		&bid.AuthorType,
+		&bid.OrganizationID,
+		&bid.CreatedAt,
+		&bid.Currency,
+		&bid.TotalNet,
+		&bid.TotalVat,
+		&bid.Total,
+		&bid.OfferVersion,
+		&bid.OverBudget,
+		&bid.Commitment,
+		&bid.RevealedAt,
+	)
+}
+
+// createHistory
+// Saves snapshot of the bid version, so it can be restored or compared later.
+func createHistory(ctx context.Context, tx pgx.Tx, bid *bidDB, createdAt time.Time) error {
+	_, err := tx.Exec(ctx, sqlRowCreateBidHistory,
+		bid.ID,
+		bid.Name,
+		bid.Description,
+		bid.Status,
+		bid.Version,
+		createdAt,
+		bid.Currency,
+		bid.TotalNet,
+		bid.TotalVat,
+		bid.Total,
+		bid.OfferVersion,
+	)
+	return err
 }
 
 func (r *RepoLayer) GetStatus(ctx context.Context, bidId int) (string, error) {
@@ -108,28 +319,39 @@ func (r *RepoLayer) GetStatus(ctx context.Context, bidId int) (string, error) {
 }
 
 func (r *RepoLayer) UpdateStatus(ctx context.Context, bidId int, status string, newBidVersion int) (*ent.Bid, error) {
-	row := r.Client.QueryRow(ctx, `UPDATE bids SET status=$1, version=$2 WHERE id=$3 RETURNING id, name, description, status, version, tender_id, creator_id, author_type, organization_id, created_at`, status, newBidVersion, bidId)
+	timeNow := time.Now()
+	tx, err := r.Client.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Откат транзакции в случае ошибки
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+	row := tx.QueryRow(ctx, sqlRowUpdateBidStatus, status, newBidVersion, timeNow, bidId)
 	var bidDB bidDB
-	err := row.Scan(
-		&bidDB.ID,
-		&bidDB.Name,
-		&bidDB.Description,
-		&bidDB.Status,
-		&bidDB.Version,
-		&bidDB.TenderID,
-		&bidDB.CreatorID,
-		&bidDB.AuthorType,
-		&bidDB.OrganizationID,
-		&bidDB.CreatedAt,
-	)
+	err = scanBid(row, &bidDB)
 	if err != nil {
+		// bid existence is checked by the caller, so the version has been changed concurrently
+		if errors.Is(err, sql.ErrNoRows) {
+			err = e.ErrPrecondition
+		}
+		return nil, err
+	}
+	if err = createHistory(ctx, tx, &bidDB, timeNow); err != nil {
 		return nil, err
 	}
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
 	return newBid(&bidDB), nil
 }
 
 func (r *RepoLayer) Update(ctx context.Context, newData *UpdateBid, newBidVersion int) (*ent.Bid, error) {
-	query, args := updateSqlQuery(newData, newBidVersion)
+	timeNow := time.Now()
+	query, args := updateSqlQuery(newData, newBidVersion, timeNow)
 	tx, err := r.Client.Begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
@@ -145,32 +367,30 @@ func (r *RepoLayer) Update(ctx context.Context, newData *UpdateBid, newBidVersio
 		return nil, err
 	}
 	if tag.RowsAffected() == 0 {
+		err = e.ErrPrecondition
 		return nil, err
 	}
-	row := tx.QueryRow(ctx, `SELECT id, name, description, status, version, tender_id, creator_id, author_type, organization_id, created_at FROM bids WHERE id=$1`, newData.BidID)
+	row := tx.QueryRow(ctx, `SELECT id, name, description, status, version, tender_id, creator_id, author_type, organization_id, created_at, currency, total_net, total_vat, total, offer_version, over_budget, commitment, revealed_at FROM bids WHERE id=$1`, newData.BidID)
 	var bidDB bidDB
-	err = row.Scan(
-		&bidDB.ID,
-		&bidDB.Name,
-		&bidDB.Description,
-		&bidDB.Status,
-		&bidDB.Version,
-		&bidDB.TenderID,
-		&bidDB.CreatorID,
-		&bidDB.AuthorType,
-		&bidDB.OrganizationID,
-		&bidDB.CreatedAt,
-	)
+	err = scanBid(row, &bidDB)
 	if err != nil {
 		return nil, err
 	}
+	if newData.Offer != nil && !newData.RestoreOffer {
+		if err = createItems(ctx, tx, bidDB.ID, newBidVersion, newData.Offer.Items); err != nil {
+			return nil, err
+		}
+	}
+	if err = createHistory(ctx, tx, &bidDB, timeNow); err != nil {
+		return nil, err
+	}
 	if err = tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 	return newBid(&bidDB), nil
 }
 
-func updateSqlQuery(newData *UpdateBid, newBidVersion int) (string, []any) {
+func updateSqlQuery(newData *UpdateBid, newBidVersion int, updatedAt time.Time) (string, []any) {
 	sb := sqlbuilder.PostgreSQL.NewUpdateBuilder().Update("bids")
 	// Собираем все изменения
 	var updates []string
@@ -181,119 +401,145 @@ func updateSqlQuery(newData *UpdateBid, newBidVersion int) (string, []any) {
 	if newData.Description != "" {
 		updates = append(updates, sb.Assign("description", newData.Description))
 	}
-	updates = append(updates, sb.Assign("updated_at", time.Now()), sb.Assign("version", newBidVersion))
+	if newData.Offer != nil || newData.RestoreOffer {
+		offer := &bidDB{}
+		setOfferDB(offer, newData.Offer)
+		// новые позиции сохраняются под новой версией предложения
+		if newData.Offer != nil && !newData.RestoreOffer {
+			offer.OfferVersion = sql.NullInt32{Int32: int32(newBidVersion), Valid: true}
+		}
+		updates = append(updates,
+			sb.Assign("currency", offer.Currency),
+			sb.Assign("total_net", offer.TotalNet),
+			sb.Assign("total_vat", offer.TotalVat),
+			sb.Assign("total", offer.Total),
+			sb.Assign("offer_version", offer.OfferVersion),
+			sb.Assign("over_budget", newData.OverBudget),
+		)
+	}
+	if newData.RevealedAt != nil {
+		updates = append(updates, sb.Assign("revealed_at", *newData.RevealedAt))
+	}
+	updates = append(updates, sb.Assign("updated_at", updatedAt), sb.Assign("version", newBidVersion))
 	sb.Set(updates...)
-	sb.Where(sb.Equal("id", newData.BidID))
+	// update is applied only to the version read by the caller
+	sb.Where(sb.Equal("id", newData.BidID), sb.Equal("version", newBidVersion-1))
 	return sb.Build()
 }
 
-func (r *RepoLayer) GetTenderBids(ctx context.Context, tenderID int) ([]*ent.Bid, error) {
-	rows, err := r.Client.Query(ctx, `SELECT id, name, description, status, version, tender_id, creator_id, author_type, organization_id, created_at FROM bids WHERE tender_id=$1 AND status='Published'`, tenderID)
+func (r *RepoLayer) GetTenderBids(ctx context.Context, params *TenderBidsProps) ([]*ent.Bid, *pagination.Cursors, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder().
+		Select("id, name, description, status, version, tender_id, creator_id, author_type, organization_id, created_at, currency, total_net, total_vat, total, offer_version, over_budget, commitment, revealed_at").
+		From("bids")
+	sb = sb.Where(sb.Equal("tender_id", params.TenderID), sb.Equal("status", "Published"))
+	if params.LotID != 0 {
+		sb = sb.Where(fmt.Sprintf("EXISTS (SELECT 1 FROM bid_lots WHERE bid_lots.bid_id = bids.id AND bid_lots.lot_id = %s)", sb.Var(params.LotID)))
+	}
+	params.Page.Apply(sb)
+	query, args := sb.Build()
+	bids, err := r.getBids(ctx, query, args)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	var bidsDB []*bidDB
-	for rows.Next() {
-		var bidDB bidDB
-		err := rows.Scan(
-			&bidDB.ID,
-			&bidDB.Name,
-			&bidDB.Description,
-			&bidDB.Status,
-			&bidDB.Version,
-			&bidDB.TenderID,
-			&bidDB.CreatorID,
-			&bidDB.AuthorType,
-			&bidDB.OrganizationID,
-			&bidDB.CreatedAt,
-		)
-		if err != nil {
-			requestId := ctx.Value(mc.ContextKey(mc.RequestID)).(string)
-			r.Logger.Error(fmt.Sprintf("error while scanning sql result: %v", err), zap.String(mc.RequestID, requestId))
-			continue
-		}
-		bidsDB = append(bidsDB, &bidDB)
+	bids, cursors := pagination.Cut(&params.Page, bids, bidKey)
+	if err = r.loadLots(ctx, bids); err != nil {
+		return nil, nil, err
 	}
-	return getArrayBidFromDB(bidsDB), nil
+	return bids, cursors, nil
 }
 
-func (r *RepoLayer) GetOrganizationBids(ctx context.Context, organizationId int) ([]*ent.Bid, error) {
-	rows, err := r.Client.Query(ctx, `SELECT id, name, description, status, version, tender_id, creator_id, author_type, organization_id, created_at FROM bids WHERE organization_id=$1`, organizationId)
+func (r *RepoLayer) GetUserBids(ctx context.Context, params *UserBidsProps) ([]*ent.Bid, *pagination.Cursors, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder().
+		Select("id, name, description, status, version, tender_id, creator_id, author_type, organization_id, created_at, currency, total_net, total_vat, total, offer_version, over_budget, commitment, revealed_at").
+		From("bids")
+	// собственные предложения сотрудника и предложения его организаций
+	sb = sb.Where(sb.Or(
+		sb.And(sb.Equal("creator_id", params.UserID), sb.IsNull("organization_id")),
+		fmt.Sprintf("organization_id = ANY(%s)", sb.Var(params.OrganizationIDs)),
+	))
+	params.Page.Apply(sb)
+	query, args := sb.Build()
+	bids, err := r.getBids(ctx, query, args)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	bids, cursors := pagination.Cut(&params.Page, bids, bidKey)
+	if err = r.loadLots(ctx, bids); err != nil {
+		return nil, nil, err
+	}
+	return bids, cursors, nil
+}
 
-	var bids []*ent.Bid
+// loadLots
+// Sets targeted lots to the bids of the page with a single query,
+// bids to the tender without lots get none.
+func (r *RepoLayer) loadLots(ctx context.Context, bids []*ent.Bid) error {
+	if len(bids) == 0 {
+		return nil
+	}
+	byID := make(map[int]*ent.Bid, len(bids))
+	ids := make([]int, 0, len(bids))
+	for _, b := range bids {
+		byID[b.ID] = b
+		ids = append(ids, b.ID)
+	}
+	rows, err := r.Client.Query(ctx, sqlRowGetBidLots, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
 	for rows.Next() {
-		var bid ent.Bid
-		err := rows.Scan(
-			&bid.ID,
-			&bid.Name,
-			&bid.Description,
-			&bid.Status,
-			&bid.Version,
-			&bid.TenderID,
-			&bid.CreatorID,
-			&bid.AuthorType,
-			&bid.OrganizationID,
-			&bid.CreatedAt,
-		)
-		if err != nil {
-			requestId := ctx.Value(mc.ContextKey(mc.RequestID)).(string)
-			r.Logger.Error(fmt.Sprintf("error while scanning sql result: %v", err), zap.String(mc.RequestID, requestId))
-			continue
+		var bidID int
+		var lot ent.BidLot
+		if err = rows.Scan(&bidID, &lot.LotID, &lot.Status); err != nil {
+			return err
 		}
-		bids = append(bids, &bid)
+		byID[bidID].Lots = append(byID[bidID].Lots, &lot)
 	}
-	return bids, nil
+	return rows.Err()
 }
 
-func (r *RepoLayer) GetUserBids(ctx context.Context, creatorID int) ([]*ent.Bid, error) {
-	rows, err := r.Client.Query(ctx, `SELECT id, name, description, status, version, tender_id, creator_id, author_type, organization_id, created_at FROM bids WHERE creator_id=$1 AND organization_id IS NULL`, creatorID)
-	if err != nil {
+func (r *RepoLayer) GetLots(ctx context.Context, bidID int) ([]*ent.BidLot, error) {
+	bid := &ent.Bid{ID: bidID}
+	if err := r.loadLots(ctx, []*ent.Bid{bid}); err != nil {
 		return nil, err
 	}
+	return bid.Lots, nil
+}
 
-	var bids []*bidDB
+// getBids выполняет запрос списка предложений, строки с ошибкой сканирования пропускаются
+func (r *RepoLayer) getBids(ctx context.Context, query string, args []any) ([]*ent.Bid, error) {
+	rows, err := r.Client.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var bidsDB []*bidDB
 	for rows.Next() {
-		var bid bidDB
-		err := rows.Scan(
-			&bid.ID,
-			&bid.Name,
-			&bid.Description,
-			&bid.Status,
-			&bid.Version,
-			&bid.TenderID,
-			&bid.CreatorID,
-			&bid.AuthorType,
-			&bid.OrganizationID,
-			&bid.CreatedAt,
-		)
+		var bidDB bidDB
+		err := scanBid(rows, &bidDB)
 		if err != nil {
 			requestId := ctx.Value(mc.ContextKey(mc.RequestID)).(string)
 			r.Logger.Error(fmt.Sprintf("error while scanning sql result: %v", err), zap.String(mc.RequestID, requestId))
 			continue
 		}
-		bids = append(bids, &bid)
+		bidsDB = append(bidsDB, &bidDB)
 	}
-	return getArrayBidFromDB(bids), nil
+	return getArrayBidFromDB(bidsDB), nil
+}
+
+func bidKey(b *ent.Bid) pagination.Key {
+	key := pagination.Key{ID: b.ID, Name: b.Name, CreatedAt: b.CreatedAt, Version: b.Version}
+	if b.Offer != nil {
+		key.Total = b.Offer.Total
+	}
+	return key
 }
 
 func (r *RepoLayer) GetBid(ctx context.Context, bidId int) (*ent.Bid, error) {
-	row := r.Client.QueryRow(ctx, `SELECT id, name, description, status, version, tender_id, creator_id, author_type, organization_id, created_at FROM bids WHERE id=$1`, bidId)
+	row := r.Client.QueryRow(ctx, `SELECT id, name, description, status, version, tender_id, creator_id, author_type, organization_id, created_at, currency, total_net, total_vat, total, offer_version, over_budget, commitment, revealed_at FROM bids WHERE id=$1`, bidId)
 	var bid bidDB
-	err := row.Scan(
-		&bid.ID,
-		&bid.Name,
-		&bid.Description,
-		&bid.Status,
-		&bid.Version,
-		&bid.TenderID,
-		&bid.CreatorID,
-		&bid.AuthorType,
-		&bid.OrganizationID,
-		&bid.CreatedAt,
-	)
+	err := scanBid(row, &bid)
 	if err != nil {
 		return nil, err
 	}
@@ -325,3 +571,331 @@ func (r *RepoLayer) OrganizationHasBid(ctx context.Context, orgID, tenderID int)
 	}
 	return true, nil
 }
+
+func (r *RepoLayer) GetVersion(ctx context.Context, bidID, version int) (*ent.BidVersion, error) {
+	row := r.Client.QueryRow(ctx, sqlRowGetBidVersion, bidID, version)
+	var v ent.BidVersion
+	var offer bidDB
+	err := row.Scan(
+		&v.BidID,
+		&v.Name,
+		&v.Description,
+		&v.Status,
+		&v.Version,
+		&v.CreatedAt,
+		&offer.Currency,
+		&offer.TotalNet,
+		&offer.TotalVat,
+		&offer.Total,
+		&offer.OfferVersion,
+	)
+	if err != nil {
+		return nil, err
+	}
+	v.Offer = newOffer(offer.Currency, offer.TotalNet, offer.TotalVat, offer.Total, offer.OfferVersion)
+	return &v, nil
+}
+
+func (r *RepoLayer) GetItems(ctx context.Context, bidID int) ([]*ent.BidItem, error) {
+	rows, err := r.Client.Query(ctx, sqlRowGetBidItems, bidID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*ent.BidItem
+	for rows.Next() {
+		var item ent.BidItem
+		err := rows.Scan(
+			&item.Position,
+			&item.Name,
+			&item.Quantity,
+			&item.Unit,
+			&item.UnitPrice,
+			&item.VatRate,
+			&item.Currency,
+			&item.NetAmount,
+			&item.VatAmount,
+			&item.Amount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, &item)
+	}
+	return items, rows.Err()
+}
+
+func (r *RepoLayer) SubmitDecision(ctx context.Context, props *SubmitDecisionProps) (*ent.BidDecisionResult, error) {
+	timeNow := time.Now()
+	tx, err := r.Client.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Откат транзакции в случае ошибки
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+	// lock the bid, so concurrent decisions are counted one by one
+	row := tx.QueryRow(ctx, sqlRowLockBid, props.BidID)
+	var bidDB bidDB
+	err = scanBid(row, &bidDB)
+	if err != nil {
+		return nil, err
+	}
+	if bidDB.Status != "Published" {
+		err = e.ErrDecisionConflict
+		return nil, err
+	}
+	if props.LotID != 0 {
+		var result *ent.BidDecisionResult
+		result, err = submitLotDecision(ctx, tx, &bidDB, props, timeNow)
+		if err != nil {
+			return nil, err
+		}
+		if err = tx.Commit(ctx); err != nil {
+			return nil, fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		return result, nil
+	}
+	if _, err = tx.Exec(ctx, sqlRowSaveDecision, props.BidID, props.UserID, props.Decision, timeNow); err != nil {
+		return nil, err
+	}
+	tally := &ent.BidDecisionTally{Quorum: props.Quorum}
+	if err = tx.QueryRow(ctx, sqlRowCountDecisions, props.BidID).Scan(&tally.Approvals, &tally.Rejections); err != nil {
+		return nil, err
+	}
+	// single rejection rejects the bid, approval requires quorum
+	decided := &bidDB
+	if tally.Rejections > 0 {
+		decided, err = rejectBid(ctx, tx, &bidDB, timeNow)
+	} else if tally.Approvals >= tally.Quorum {
+		decided, err = awardBid(ctx, tx, &bidDB, timeNow)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return &ent.BidDecisionResult{
+		Bid:   newBid(decided),
+		Tally: tally,
+	}, nil
+}
+
+// submitLotDecision
+// Decisions on the bid to the tender with lots are counted per lot: single rejection rejects the bid
+// in the lot, approval by quorum awards the lot. Bid itself is settled once all its lots are decided.
+func submitLotDecision(ctx context.Context, tx pgx.Tx, bid *bidDB, props *SubmitDecisionProps, timeNow time.Time) (*ent.BidDecisionResult, error) {
+	result := &ent.BidDecisionResult{
+		Tally: &ent.BidDecisionTally{Quorum: props.Quorum},
+		LotID: props.LotID,
+	}
+	err := tx.QueryRow(ctx, sqlRowLockBidLot, bid.ID, props.LotID).Scan(&result.LotStatus)
+	if err != nil {
+		return nil, err
+	}
+	if result.LotStatus != mc.BidLotPending {
+		return nil, e.ErrDecisionConflict
+	}
+	if _, err = tx.Exec(ctx, sqlRowSaveLotDecision, bid.ID, props.LotID, props.UserID, props.Decision, timeNow); err != nil {
+		return nil, err
+	}
+	tally := result.Tally
+	if err = tx.QueryRow(ctx, sqlRowCountLotDecisions, bid.ID, props.LotID).Scan(&tally.Approvals, &tally.Rejections); err != nil {
+		return nil, err
+	}
+	if tally.Rejections > 0 {
+		if _, err = tx.Exec(ctx, sqlRowSetBidLotStatus, bid.ID, props.LotID, mc.BidLotRejected); err != nil {
+			return nil, err
+		}
+		result.LotStatus = mc.BidLotRejected
+	} else if tally.Approvals >= tally.Quorum {
+		result.TenderClosed, err = awardLot(ctx, tx, bid, props.LotID, timeNow)
+		if err != nil {
+			return nil, err
+		}
+		result.LotStatus = mc.BidLotAwarded
+	}
+	decided, err := settleBid(ctx, tx, bid.ID, timeNow)
+	if err != nil {
+		return nil, err
+	}
+	if decided == nil {
+		decided = bid
+	}
+	result.Bid = newBid(decided)
+	return result, nil
+}
+
+// awardLot
+// Awards the lot to the bid and rejects other bids in the lot, competitors without
+// undecided lots are settled. Tender is closed when all its lots are awarded.
+func awardLot(ctx context.Context, tx pgx.Tx, bid *bidDB, lotID int, timeNow time.Time) (bool, error) {
+	tag, err := tx.Exec(ctx, sqlRowAwardLot, lotID, bid.ID, bid.OrganizationID, timeNow)
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() == 0 {
+		return false, e.ErrDecisionConflict
+	}
+	if _, err = tx.Exec(ctx, sqlRowSetBidLotStatus, bid.ID, lotID, mc.BidLotAwarded); err != nil {
+		return false, err
+	}
+	rows, err := tx.Query(ctx, sqlRowRejectCompetingLotBids, lotID, bid.ID)
+	if err != nil {
+		return false, err
+	}
+	var competitors []int
+	for rows.Next() {
+		var bidID int
+		if err = rows.Scan(&bidID); err != nil {
+			rows.Close()
+			return false, err
+		}
+		competitors = append(competitors, bidID)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return false, err
+	}
+	for _, bidID := range competitors {
+		if _, err = settleBid(ctx, tx, bidID, timeNow); err != nil {
+			return false, err
+		}
+	}
+	// тендер закрывается только из опубликованного, это условие входит в запрос
+	tag, err = tx.Exec(ctx, sqlRowCloseLotTender, bid.TenderID, timeNow)
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+	if _, err = tx.Exec(ctx, sqlRowCreateTenderHistory, bid.TenderID, timeNow); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// settleBid
+// Approves or rejects the published bid once all its lots are decided,
+// nil means the bid still has undecided lots or isn't published.
+func settleBid(ctx context.Context, tx pgx.Tx, bidID int, timeNow time.Time) (*bidDB, error) {
+	var from, to string
+	var version int
+	err := tx.QueryRow(ctx, sqlRowLockSettlement, bidID).Scan(&from, &version, &to)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err = sm.Bid.Check(from, to, sm.RoleSystem); err != nil {
+		return nil, err
+	}
+	row := tx.QueryRow(ctx, sqlRowUpdateBidStatus, to, version+1, timeNow, bidID)
+	var settled bidDB
+	if err = scanBid(row, &settled); err != nil {
+		return nil, err
+	}
+	if err = createHistory(ctx, tx, &settled, timeNow); err != nil {
+		return nil, err
+	}
+	return &settled, nil
+}
+
+func rejectBid(ctx context.Context, tx pgx.Tx, bid *bidDB, timeNow time.Time) (*bidDB, error) {
+	if err := sm.Bid.Check(bid.Status, "Rejected", sm.RoleSystem); err != nil {
+		return nil, err
+	}
+	row := tx.QueryRow(ctx, sqlRowUpdateBidStatus, "Rejected", bid.Version+1, timeNow, bid.ID)
+	var rejectedDB bidDB
+	err := scanBid(row, &rejectedDB)
+	if err != nil {
+		return nil, err
+	}
+	if err = createHistory(ctx, tx, &rejectedDB, timeNow); err != nil {
+		return nil, err
+	}
+	return &rejectedDB, nil
+}
+
+// AwardInTx
+// Awards the published bid inside the transaction of the caller, so other repos can close
+// the tender together with their own changes.
+func AwardInTx(ctx context.Context, tx pgx.Tx, bidID int, timeNow time.Time) (*ent.Bid, error) {
+	row := tx.QueryRow(ctx, sqlRowLockBid, bidID)
+	var bidDB bidDB
+	err := scanBid(row, &bidDB)
+	if err != nil {
+		return nil, err
+	}
+	if bidDB.Status != "Published" {
+		return nil, e.ErrDecisionConflict
+	}
+	awarded, err := awardBid(ctx, tx, &bidDB, timeNow)
+	if err != nil {
+		return nil, err
+	}
+	return newBid(awarded), nil
+}
+
+// awardBid
+// Approves the bid, closes its tender and rejects other published bids to the tender.
+func awardBid(ctx context.Context, tx pgx.Tx, bid *bidDB, timeNow time.Time) (*bidDB, error) {
+	if err := sm.Bid.Check(bid.Status, "Approved", sm.RoleSystem); err != nil {
+		return nil, err
+	}
+	// тендер и конкуренты меняют статус только из опубликованного, это условие входит в запросы
+	// close tender, only published tender can be awarded
+	tag, err := tx.Exec(ctx, sqlRowAwardTender, bid.TenderID, bid.ID, bid.OrganizationID, timeNow)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, e.ErrDecisionConflict
+	}
+	if _, err = tx.Exec(ctx, sqlRowCreateTenderHistory, bid.TenderID, timeNow); err != nil {
+		return nil, err
+	}
+	// approve winner
+	row := tx.QueryRow(ctx, sqlRowApproveBid, bid.ID, timeNow)
+	var approvedDB bidDB
+	err = scanBid(row, &approvedDB)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrDecisionConflict
+		}
+		return nil, err
+	}
+	if err = createHistory(ctx, tx, &approvedDB, timeNow); err != nil {
+		return nil, err
+	}
+	// reject competitors
+	rows, err := tx.Query(ctx, sqlRowRejectCompetingBids, bid.TenderID, bid.ID, timeNow)
+	if err != nil {
+		return nil, err
+	}
+	var rejected []*bidDB
+	for rows.Next() {
+		var b bidDB
+		if err = rows.Scan(&b.ID, &b.Name, &b.Description, &b.Status, &b.Version, &b.Currency, &b.TotalNet, &b.TotalVat, &b.Total, &b.OfferVersion); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		rejected = append(rejected, &b)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	for _, b := range rejected {
+		if err = createHistory(ctx, tx, b, timeNow); err != nil {
+			return nil, err
+		}
+	}
+	return &approvedDB, nil
+}
@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	ent "tender-workspace/internal/entity"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 type bidDB struct {
@@ -17,6 +19,15 @@ type bidDB struct {
 	AuthorType     string
 	OrganizationID sql.NullInt32
 	CreatedAt      time.Time
+	// без ценового предложения валюта и версия позиций пустые, итоги нулевые
+	Currency     sql.NullString
+	TotalNet     decimal.Decimal
+	TotalVat     decimal.Decimal
+	Total        decimal.Decimal
+	OfferVersion sql.NullInt32
+	OverBudget   bool
+	Commitment   sql.NullString
+	RevealedAt   *time.Time
 }
 
 func newBidDB(bid *ent.Bid) *bidDB {
@@ -27,7 +38,7 @@ func newBidDB(bid *ent.Bid) *bidDB {
 	} else {
 		orgId.Valid = false
 	}
-	return &bidDB{
+	bidDB := &bidDB{
 		ID:             bid.ID,
 		Name:           bid.Name,
 		Description:    bid.Description,
@@ -38,6 +49,37 @@ func newBidDB(bid *ent.Bid) *bidDB {
 		AuthorType:     bid.AuthorType,
 		OrganizationID: orgId,
 		CreatedAt:      bid.CreatedAt,
+		OverBudget:     bid.OverBudget,
+		Commitment:     sql.NullString{String: bid.Commitment, Valid: bid.Commitment != ""},
+		RevealedAt:     bid.RevealedAt,
+	}
+	setOfferDB(bidDB, bid.Offer)
+	return bidDB
+}
+
+// setOfferDB переносит итоги предложения в колонки, nil очищает предложение
+func setOfferDB(bid *bidDB, offer *ent.BidOffer) {
+	if offer == nil {
+		bid.Currency = sql.NullString{}
+		bid.TotalNet, bid.TotalVat, bid.Total = decimal.Zero, decimal.Zero, decimal.Zero
+		bid.OfferVersion = sql.NullInt32{}
+		return
+	}
+	bid.Currency = sql.NullString{String: offer.Currency, Valid: true}
+	bid.TotalNet, bid.TotalVat, bid.Total = offer.TotalNet, offer.TotalVat, offer.Total
+	bid.OfferVersion = sql.NullInt32{Int32: int32(offer.Version), Valid: true}
+}
+
+func newOffer(currency sql.NullString, totalNet, totalVat, total decimal.Decimal, offerVersion sql.NullInt32) *ent.BidOffer {
+	if !currency.Valid {
+		return nil
+	}
+	return &ent.BidOffer{
+		Version:  int(offerVersion.Int32),
+		Currency: currency.String,
+		TotalNet: totalNet,
+		TotalVat: totalVat,
+		Total:    total,
 	}
 }
 
@@ -57,6 +99,10 @@ func newBid(bid *bidDB) *ent.Bid {
 		AuthorType:     bid.AuthorType,
 		OrganizationID: orgId,
 		CreatedAt:      bid.CreatedAt,
+		Offer:          newOffer(bid.Currency, bid.TotalNet, bid.TotalVat, bid.Total, bid.OfferVersion),
+		OverBudget:     bid.OverBudget,
+		Commitment:     bid.Commitment.String,
+		RevealedAt:     bid.RevealedAt,
 	}
 }
 
@@ -0,0 +1,121 @@
+package evaluation
+
+import (
+	"context"
+	"fmt"
+	ent "tender-workspace/internal/entity"
+	"tender-workspace/internal/utils/metrics"
+	"tender-workspace/internal/utils/tracing"
+	"tender-workspace/services/postgres"
+
+	"go.uber.org/zap"
+)
+
+type Repo interface {
+	// SaveScores сохраняет оценки эксперта, повторная оценка по критерию заменяет прежнюю
+	SaveScores(ctx context.Context, scores []*ent.BidScore) error
+	GetUserScores(ctx context.Context, bidID, userID int) ([]*ent.BidScore, error)
+	// GetTenderBids возвращает предложения тендера, которые попадают в матрицу оценок
+	GetTenderBids(ctx context.Context, tenderID int) ([]*ent.Bid, error)
+	// GetTenderScores возвращает все оценки предложений тендера, попадающих в матрицу
+	GetTenderScores(ctx context.Context, tenderID int) ([]*ent.BidScore, error)
+}
+
+var _ Repo = (*RepoLayer)(nil)
+
+type RepoLayer struct {
+	Client postgres.Client
+	Logger *zap.Logger
+}
+
+func NewRepoLayer(client postgres.Client, logger *zap.Logger) *RepoLayer {
+	return &RepoLayer{
+		Client: postgres.Instrument(client, "evaluation", metrics.ObserveQuery, tracing.TraceQuery),
+		Logger: logger,
+	}
+}
+
+var (
+	sqlRowSaveScore = `INSERT INTO bid_scores (bid_id, criterion_id, user_id, score, created_at) VALUES ($1, $2, $3, $4, $5) 
+	ON CONFLICT (bid_id, criterion_id, user_id) DO UPDATE SET score=EXCLUDED.score, created_at=EXCLUDED.created_at`
+	sqlRowGetUserScores = `SELECT s.bid_id, s.criterion_id, s.user_id, s.score, s.created_at 
+	FROM bid_scores s JOIN tender_criteria c ON c.id = s.criterion_id 
+	WHERE s.bid_id=$1 AND s.user_id=$2 ORDER BY c.position`
+	// победитель остается в матрице после выбора, отклоненные и отозванные предложения - нет
+	sqlRowGetTenderBids   = `SELECT id, name FROM bids WHERE tender_id=$1 AND status IN ('Published', 'Approved') ORDER BY id`
+	sqlRowGetTenderScores = `SELECT s.bid_id, s.criterion_id, s.user_id, s.score, s.created_at 
+	FROM bid_scores s JOIN bids b ON b.id = s.bid_id 
+	WHERE b.tender_id=$1 AND b.status IN ('Published', 'Approved')`
+)
+
+func (r *RepoLayer) SaveScores(ctx context.Context, scores []*ent.BidScore) error {
+	tx, err := r.Client.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Откат транзакции в случае ошибки
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+	for _, s := range scores {
+		_, err = tx.Exec(ctx, sqlRowSaveScore, s.BidID, s.CriterionID, s.UserID, s.Score, s.CreatedAt)
+		if err != nil {
+			return err
+		}
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *RepoLayer) GetUserScores(ctx context.Context, bidID, userID int) ([]*ent.BidScore, error) {
+	return r.getScores(ctx, sqlRowGetUserScores, bidID, userID)
+}
+
+func (r *RepoLayer) GetTenderScores(ctx context.Context, tenderID int) ([]*ent.BidScore, error) {
+	return r.getScores(ctx, sqlRowGetTenderScores, tenderID)
+}
+
+// getScores
+// Scores are aggregated by the caller, so any scan error fails the whole request
+// instead of silently changing the result.
+func (r *RepoLayer) getScores(ctx context.Context, query string, args ...any) ([]*ent.BidScore, error) {
+	rows, err := r.Client.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scores []*ent.BidScore
+	for rows.Next() {
+		var s ent.BidScore
+		err = rows.Scan(&s.BidID, &s.CriterionID, &s.UserID, &s.Score, &s.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		scores = append(scores, &s)
+	}
+	return scores, rows.Err()
+}
+
+func (r *RepoLayer) GetTenderBids(ctx context.Context, tenderID int) ([]*ent.Bid, error) {
+	rows, err := r.Client.Query(ctx, sqlRowGetTenderBids, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bids []*ent.Bid
+	for rows.Next() {
+		var b ent.Bid
+		err = rows.Scan(&b.ID, &b.Name)
+		if err != nil {
+			return nil, err
+		}
+		bids = append(bids, &b)
+	}
+	return bids, rows.Err()
+}
@@ -0,0 +1,87 @@
+package feedback
+
+import (
+	"context"
+	"fmt"
+	ent "tender-workspace/internal/entity"
+	"tender-workspace/internal/utils/metrics"
+	mc "tender-workspace/internal/utils/myconstants"
+	"tender-workspace/internal/utils/tracing"
+	"tender-workspace/services/postgres"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// QUERY
+type AuthorFeedbackProps struct {
+	AuthorID int
+	Limit    int
+	Offset   int
+}
+
+type Repo interface {
+	Create(ctx context.Context, initData *ent.Feedback) (*ent.Feedback, error)
+	GetAuthorFeedback(ctx context.Context, params *AuthorFeedbackProps) ([]*ent.Feedback, error)
+}
+
+var _ Repo = (*RepoLayer)(nil)
+
+type RepoLayer struct {
+	Client postgres.Client
+	Logger *zap.Logger
+}
+
+func NewRepoLayer(client postgres.Client, logger *zap.Logger) *RepoLayer {
+	return &RepoLayer{
+		Client: postgres.Instrument(client, "feedback", metrics.ObserveQuery, tracing.TraceQuery),
+		Logger: logger,
+	}
+}
+
+var (
+	sqlRowCreateFeedback = `INSERT INTO feedback (
+		bid_id,
+		author_id,
+		text,
+		created_at
+	) VALUES ($1, $2, $3, $4) RETURNING id, bid_id, author_id, text, created_at`
+	// feedback is collected on all bids of the author across tenders
+	sqlRowGetAuthorFeedback = `SELECT f.id, f.bid_id, f.author_id, f.text, f.created_at
+	FROM feedback f
+	JOIN bids b ON b.id = f.bid_id
+	WHERE b.creator_id = $1
+	ORDER BY f.created_at DESC, f.id DESC
+	LIMIT $2 OFFSET $3`
+)
+
+func (r *RepoLayer) Create(ctx context.Context, initData *ent.Feedback) (*ent.Feedback, error) {
+	row := r.Client.QueryRow(ctx, sqlRowCreateFeedback, initData.BidID, initData.AuthorID, initData.Text, time.Now())
+	var fDB feedbackDB
+	err := row.Scan(&fDB.ID, &fDB.BidID, &fDB.AuthorID, &fDB.Text, &fDB.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return getFeedbackFromDB(&fDB), nil
+}
+
+func (r *RepoLayer) GetAuthorFeedback(ctx context.Context, params *AuthorFeedbackProps) ([]*ent.Feedback, error) {
+	rows, err := r.Client.Query(ctx, sqlRowGetAuthorFeedback, params.AuthorID, params.Limit, params.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rowsDB []*feedbackDB
+	for rows.Next() {
+		var fDB feedbackDB
+		err := rows.Scan(&fDB.ID, &fDB.BidID, &fDB.AuthorID, &fDB.Text, &fDB.CreatedAt)
+		if err != nil {
+			requestId := ctx.Value(mc.ContextKey(mc.RequestID)).(string)
+			r.Logger.Error(fmt.Sprintf("error while scanning sql result: %v", err), zap.String(mc.RequestID, requestId))
+			continue
+		}
+		rowsDB = append(rowsDB, &fDB)
+	}
+	return getArrayFeedbackFromDB(rowsDB), nil
+}
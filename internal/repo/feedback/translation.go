@@ -0,0 +1,37 @@
+package feedback
+
+import (
+	"database/sql"
+	ent "tender-workspace/internal/entity"
+	"time"
+)
+
+type feedbackDB struct {
+	ID        int
+	BidID     int
+	AuthorID  sql.NullInt32
+	Text      string
+	CreatedAt time.Time
+}
+
+func getArrayFeedbackFromDB(rows []*feedbackDB) []*ent.Feedback {
+	feedback := make([]*ent.Feedback, 0, len(rows))
+	for _, row := range rows {
+		feedback = append(feedback, getFeedbackFromDB(row))
+	}
+	return feedback
+}
+
+func getFeedbackFromDB(row *feedbackDB) *ent.Feedback {
+	authorId := 0
+	if row.AuthorID.Valid {
+		authorId = int(row.AuthorID.Int32)
+	}
+	return &ent.Feedback{
+		ID:        row.ID,
+		BidID:     row.BidID,
+		AuthorID:  authorId,
+		Text:      row.Text,
+		CreatedAt: row.CreatedAt,
+	}
+}
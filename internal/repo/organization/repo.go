@@ -7,34 +7,38 @@ import (
 	"fmt"
 	ent "tender-workspace/internal/entity"
 	oqp "tender-workspace/internal/entity/dto/queries/organizations"
+	"tender-workspace/internal/utils/metrics"
 	mc "tender-workspace/internal/utils/myconstants"
 	e "tender-workspace/internal/utils/myerrors"
+	"tender-workspace/internal/utils/pagination"
+	"tender-workspace/internal/utils/tracing"
+	"tender-workspace/services/postgres"
 	"time"
 
 	"github.com/huandu/go-sqlbuilder"
-	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
 type Repo interface {
 	Create(ctx context.Context, initData *ent.Organization) (*ent.Organization, error)
 	Get(ctx context.Context, organizationId int) (*ent.Organization, error)
-	GetAll(ctx context.Context, params *oqp.OrganizationList) ([]*ent.Organization, error)
+	GetAll(ctx context.Context, params *oqp.OrganizationList) ([]*ent.Organization, *pagination.Cursors, error)
 	Update(ctx context.Context, updateData *ent.Organization) (*ent.Organization, error)
 	IsUserResponsible(ctx context.Context, userId, organizationId int) (bool, error)
 	MakeUserResponsible(ctx context.Context, userId, organizationId int) error
+	CountResponsible(ctx context.Context, organizationId int) (int, error)
 }
 
 type RepoLayer struct {
-	Client *pgx.Conn
+	Client postgres.Client
 	Logger *zap.Logger
 }
 
 var _ Repo = (*RepoLayer)(nil)
 
-func NewRepoLayer(client *pgx.Conn, logger *zap.Logger) *RepoLayer {
+func NewRepoLayer(client postgres.Client, logger *zap.Logger) *RepoLayer {
 	return &RepoLayer{
-		Client: client,
+		Client: postgres.Instrument(client, "organization", metrics.ObserveQuery, tracing.TraceQuery),
 		Logger: logger,
 	}
 }
@@ -49,6 +53,7 @@ var (
 											) VALUES($1, $2, $3, $4, $4) RETURNING id, name, description, type, created_at`
 	sqlRowCheckResponsibility = `SELECT 1 FROM organization_responsible WHERE organization_id=$1 AND user_id=$2`
 	sqlRowMakeResponsibility  = `INSERT INTO organization_responsible(organization_id, user_id) VALUES($1, $2)`
+	sqlRowCountResponsible    = `SELECT COUNT(DISTINCT user_id) FROM organization_responsible WHERE organization_id=$1`
 	sqlRowUpdateOrganization  = `UPDATE organization
 	SET name = $1,
 		description = $2,
@@ -58,11 +63,11 @@ var (
 	RETURNING id, name, description, type, created_at`
 )
 
-func (r *RepoLayer) GetAll(ctx context.Context, params *oqp.OrganizationList) ([]*ent.Organization, error) {
+func (r *RepoLayer) GetAll(ctx context.Context, params *oqp.OrganizationList) ([]*ent.Organization, *pagination.Cursors, error) {
 	query, args := getAllSqlQuery(params)
 	rows, err := r.Client.Query(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer rows.Close()
 	var orgsDB []*organizationDB
@@ -77,7 +82,8 @@ func (r *RepoLayer) GetAll(ctx context.Context, params *oqp.OrganizationList) ([
 		orgsDB = append(orgsDB, &o)
 	}
 	orgs := getArrayOrganizationFromDB(orgsDB)
-	return orgs, nil
+	orgs, cursors := pagination.Cut(&params.Page, orgs, organizationKey)
+	return orgs, cursors, nil
 }
 
 func getAllSqlQuery(params *oqp.OrganizationList) (string, []any) {
@@ -85,10 +91,14 @@ func getAllSqlQuery(params *oqp.OrganizationList) (string, []any) {
 	if params.Type != "" {
 		sb = sb.Where(sb.Equal("type", params.Type))
 	}
-	sb = sb.Offset(params.Offset).Limit(params.Limit).OrderBy("name").Asc()
+	params.Page.Apply(sb)
 	return sb.Build()
 }
 
+func organizationKey(o *ent.Organization) pagination.Key {
+	return pagination.Key{ID: o.ID, Name: o.Name, CreatedAt: o.CreatedAt}
+}
+
 func (r *RepoLayer) Create(ctx context.Context, initData *ent.Organization) (*ent.Organization, error) {
 	row := r.Client.QueryRow(ctx, sqlRowCreateOrganization, initData.Name, initData.Description, initData.Type, time.Now())
 	var oDB organizationDB
@@ -147,3 +157,13 @@ func (r *RepoLayer) MakeUserResponsible(ctx context.Context, userID, organizatio
 	}
 	return nil
 }
+
+func (r *RepoLayer) CountResponsible(ctx context.Context, organizationID int) (int, error) {
+	row := r.Client.QueryRow(ctx, sqlRowCountResponsible, organizationID)
+	var count int
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
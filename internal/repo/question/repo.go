@@ -0,0 +1,164 @@
+package question
+
+import (
+	"context"
+	"fmt"
+	ent "tender-workspace/internal/entity"
+	"tender-workspace/internal/repo/tender"
+	"tender-workspace/internal/utils/metrics"
+	mc "tender-workspace/internal/utils/myconstants"
+	"tender-workspace/internal/utils/pagination"
+	"tender-workspace/internal/utils/tracing"
+	"tender-workspace/services/postgres"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"go.uber.org/zap"
+)
+
+// QUERY
+type TenderQuestionsProps struct {
+	TenderID int
+	ViewerID int // 0 - все вопросы, иначе вопросы с опубликованным ответом и вопросы смотрящего
+	Page     pagination.Page
+}
+
+type AnswerProps struct {
+	QuestionID int
+	UserID     int
+	Text       string
+	Publish    bool
+	AnsweredAt time.Time
+	// изменение тендера новой версией вместе с ответом, nil - тендер не меняется
+	Amendment        *ent.UpdateTenderData
+	TenderProps      *tender.UpdateTenderProps
+	TenderNewVersion int
+}
+
+type Repo interface {
+	Create(ctx context.Context, initData *ent.Question) (*ent.Question, error)
+	GetQuestion(ctx context.Context, questionID int) (*ent.Question, error)
+	// GetTenderQuestions возвращает страницу вопросов по тендеру в порядке их поступления
+	GetTenderQuestions(ctx context.Context, params *TenderQuestionsProps) ([]*ent.Question, *pagination.Cursors, error)
+	// Answer saves the answer and amends the tender, if it's requested, in one transaction
+	Answer(ctx context.Context, props *AnswerProps) (*ent.Question, error)
+}
+
+var _ Repo = (*RepoLayer)(nil)
+
+type RepoLayer struct {
+	Client postgres.Client
+	Logger *zap.Logger
+}
+
+func NewRepoLayer(client postgres.Client, logger *zap.Logger) *RepoLayer {
+	return &RepoLayer{
+		Client: postgres.Instrument(client, "question", metrics.ObserveQuery, tracing.TraceQuery),
+		Logger: logger,
+	}
+}
+
+var (
+	sqlRowCreateQuestion = `INSERT INTO tender_questions (
+		tender_id,
+		author_id,
+		anonymous,
+		text,
+		created_at
+	) VALUES ($1, $2, $3, $4, $5) 
+	RETURNING id, tender_id, author_id, anonymous, text, created_at, answer, answered_by, answered_at, published, tender_version`
+	sqlRowGetQuestion = `SELECT id, tender_id, author_id, anonymous, text, created_at, answer, answered_by, answered_at, published, tender_version 
+	FROM tender_questions WHERE id=$1`
+	// опубликованный ответ остается опубликованным, версия тендера меняется, только если тендер изменен
+	sqlRowAnswerQuestion = `UPDATE tender_questions SET 
+		answer=$2, 
+		answered_by=$3, 
+		answered_at=$4, 
+		published=published OR $5, 
+		tender_version=COALESCE($6, tender_version) 
+	WHERE id=$1 
+	RETURNING id, tender_id, author_id, anonymous, text, created_at, answer, answered_by, answered_at, published, tender_version`
+)
+
+func (r *RepoLayer) Create(ctx context.Context, initData *ent.Question) (*ent.Question, error) {
+	row := r.Client.QueryRow(ctx, sqlRowCreateQuestion, initData.TenderID, initData.AuthorID, initData.Anonymous, initData.Text, initData.CreatedAt)
+	var qDB questionDB
+	if err := scanQuestion(row, &qDB); err != nil {
+		return nil, err
+	}
+	return newQuestion(&qDB), nil
+}
+
+func (r *RepoLayer) GetQuestion(ctx context.Context, questionID int) (*ent.Question, error) {
+	row := r.Client.QueryRow(ctx, sqlRowGetQuestion, questionID)
+	var qDB questionDB
+	if err := scanQuestion(row, &qDB); err != nil {
+		return nil, err
+	}
+	return newQuestion(&qDB), nil
+}
+
+func (r *RepoLayer) GetTenderQuestions(ctx context.Context, params *TenderQuestionsProps) ([]*ent.Question, *pagination.Cursors, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder().
+		Select("id, tender_id, author_id, anonymous, text, created_at, answer, answered_by, answered_at, published, tender_version").
+		From("tender_questions")
+	sb = sb.Where(sb.Equal("tender_id", params.TenderID))
+	if params.ViewerID != 0 {
+		sb = sb.Where(sb.Or(sb.Equal("published", true), sb.Equal("author_id", params.ViewerID)))
+	}
+	params.Page.Apply(sb)
+	query, args := sb.Build()
+	rows, err := r.Client.Query(ctx, query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	questions := make([]*ent.Question, 0)
+	for rows.Next() {
+		var qDB questionDB
+		if err := scanQuestion(rows, &qDB); err != nil {
+			requestId := ctx.Value(mc.ContextKey(mc.RequestID)).(string)
+			r.Logger.Error(fmt.Sprintf("error while scanning sql result: %v", err), zap.String(mc.RequestID, requestId))
+			continue
+		}
+		questions = append(questions, newQuestion(&qDB))
+	}
+	questions, cursors := pagination.Cut(&params.Page, questions, questionKey)
+	return questions, cursors, nil
+}
+
+func questionKey(q *ent.Question) pagination.Key {
+	return pagination.Key{ID: q.ID, CreatedAt: q.CreatedAt}
+}
+
+func (r *RepoLayer) Answer(ctx context.Context, props *AnswerProps) (*ent.Question, error) {
+	tx, err := r.Client.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Откат транзакции в случае ошибки
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+	var tenderVersion *int
+	if props.Amendment != nil {
+		var t *ent.Tender
+		t, err = tender.UpdateInTx(ctx, tx, props.Amendment, props.TenderProps, props.TenderNewVersion, props.AnsweredAt)
+		if err != nil {
+			return nil, err
+		}
+		tenderVersion = &t.Version
+	}
+	row := tx.QueryRow(ctx, sqlRowAnswerQuestion, props.QuestionID, props.Text, props.UserID, props.AnsweredAt, props.Publish, tenderVersion)
+	var qDB questionDB
+	if err = scanQuestion(row, &qDB); err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return newQuestion(&qDB), nil
+}
@@ -0,0 +1,60 @@
+package question
+
+import (
+	"database/sql"
+	ent "tender-workspace/internal/entity"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type questionDB struct {
+	ID            int
+	TenderID      int
+	AuthorID      int
+	Anonymous     bool
+	Text          string
+	CreatedAt     time.Time
+	Answer        sql.NullString
+	AnsweredBy    sql.NullInt32
+	AnsweredAt    sql.NullTime
+	Published     bool
+	TenderVersion sql.NullInt32
+}
+
+func scanQuestion(row pgx.Row, q *questionDB) error {
+	return row.Scan(
+		&q.ID,
+		&q.TenderID,
+		&q.AuthorID,
+		&q.Anonymous,
+		&q.Text,
+		&q.CreatedAt,
+		&q.Answer,
+		&q.AnsweredBy,
+		&q.AnsweredAt,
+		&q.Published,
+		&q.TenderVersion,
+	)
+}
+
+func newQuestion(row *questionDB) *ent.Question {
+	q := &ent.Question{
+		ID:        row.ID,
+		TenderID:  row.TenderID,
+		AuthorID:  row.AuthorID,
+		Anonymous: row.Anonymous,
+		Text:      row.Text,
+		CreatedAt: row.CreatedAt,
+	}
+	if row.Answer.Valid {
+		q.Answer = &ent.QuestionAnswer{
+			Text:          row.Answer.String,
+			AnsweredBy:    int(row.AnsweredBy.Int32),
+			AnsweredAt:    row.AnsweredAt.Time,
+			Published:     row.Published,
+			TenderVersion: int(row.TenderVersion.Int32),
+		}
+	}
+	return q
+}
@@ -2,10 +2,17 @@ package tender
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	ent "tender-workspace/internal/entity"
 	tqp "tender-workspace/internal/entity/dto/queries/tenders"
+	"tender-workspace/internal/utils/metrics"
 	mc "tender-workspace/internal/utils/myconstants"
+	e "tender-workspace/internal/utils/myerrors"
+	"tender-workspace/internal/utils/pagination"
+	"tender-workspace/internal/utils/tracing"
+	"tender-workspace/services/postgres"
 	"time"
 
 	"github.com/huandu/go-sqlbuilder"
@@ -15,9 +22,8 @@ import (
 
 // QUERY
 type UserTendersProps struct {
-	Limit  int
-	Offset int
-	UserID int
+	OrganizationIDs []int
+	Page            pagination.Page
 }
 
 type UpdateTenderProps struct {
@@ -25,29 +31,40 @@ type UpdateTenderProps struct {
 	UserID   int
 }
 
-// необходимо сделать откат версии
-// /tenders/{tenderId}/rollback/{version}:
 type Repo interface {
-	GetAll(ctx context.Context, params *tqp.ListTenders) ([]*ent.Tender, error)
+	GetAll(ctx context.Context, params *tqp.ListTenders) ([]*ent.Tender, *pagination.Cursors, error)
+	// Search ищет тендеры полнотекстовым поиском по названию и описанию
+	Search(ctx context.Context, params *tqp.SearchTenders) ([]*ent.TenderSearchResult, *pagination.Cursors, error)
 	Create(ctx context.Context, initData *ent.Tender) (*ent.Tender, error)
-	GetUserTenders(ctx context.Context, params *UserTendersProps) ([]*ent.Tender, error)
+	// GetUserTenders возвращает страницу тендеров организаций, за которые отвечает сотрудник
+	GetUserTenders(ctx context.Context, params *UserTendersProps) ([]*ent.Tender, *pagination.Cursors, error)
 	ChangeStatus(ctx context.Context, tenderId, tenderNewVersion int, status string) (*ent.Tender, error)
 	Update(ctx context.Context, newTenderData *ent.UpdateTenderData, params *UpdateTenderProps, tenderNewVersion int) (*ent.Tender, error)
 	GetTenderStatus(ctx context.Context, tenderId int) (string, error)
 	GetTender(ctx context.Context, tenderId int) (*ent.Tender, error)
-	GetOrganizationTenders(ctx context.Context, organizationId int) ([]*ent.Tender, error)
+	GetVersions(ctx context.Context, tenderId int) ([]*ent.TenderVersion, error)
+	GetVersion(ctx context.Context, tenderId, version int) (*ent.TenderVersion, error)
+	GetAward(ctx context.Context, tenderId int) (*ent.TenderAward, error)
+	// GetCriteria возвращает критерии оценки тендера в порядке их задания
+	GetCriteria(ctx context.Context, tenderId int) ([]*ent.Criterion, error)
+	// GetLots возвращает лоты тендера в порядке их задания
+	GetLots(ctx context.Context, tenderId int) ([]*ent.Lot, error)
+	// GetAuction возвращает торги тендера, sql.ErrNoRows - тендер без торгов
+	GetAuction(ctx context.Context, tenderId int) (*ent.Auction, error)
+	// CloseOverdue закрывает до limit опубликованных тендеров с истекшим сроком решения
+	CloseOverdue(ctx context.Context, now time.Time, limit int) ([]*ent.Tender, error)
 }
 
 type RepoLayer struct {
-	Client *pgx.Conn
+	Client postgres.Client
 	Logger *zap.Logger
 }
 
 var _ Repo = (*RepoLayer)(nil)
 
-func NewRepoLayer(client *pgx.Conn, logger *zap.Logger) *RepoLayer {
+func NewRepoLayer(client postgres.Client, logger *zap.Logger) *RepoLayer {
 	return &RepoLayer{
-		Client: client,
+		Client: postgres.Instrument(client, "tender", metrics.ObserveQuery, tracing.TraceQuery),
 		Logger: logger,
 	}
 }
@@ -62,16 +79,87 @@ var (
         organization_id, 
         creator_id, 
 		created_at,
-		updated_at
+		updated_at,
+		submission_deadline,
+		decision_deadline,
+		budget,
+		budget_currency,
+		budget_hidden,
+		budget_policy,
+		score_aggregation,
+		sealed
     ) 
     VALUES (
-        $1, $2, $3, $4, $5, $6, $7, $8, $8
-    ) RETURNING id, name, description, type, status, version, organization_id, creator_id, created_at`
-	sqlRowUpdateTenderStatus = `UPDATE tender SET status=$1, version=$2 WHERE id=$3 RETURNING id, name, description, type, status, version, organization_id, creator_id, created_at`
+        $1, $2, $3, $4, $5, $6, $7, $8, $8, $9, $10, $11, $12, $13, $14, $15, $16
+    ) RETURNING id, name, description, type, status, version, organization_id, creator_id, created_at, submission_deadline, decision_deadline, closed_reason, budget, budget_currency, budget_hidden, budget_policy, score_aggregation, sealed`
+	sqlRowUpdateTenderStatus  = `UPDATE tender SET status=$1, version=$2, updated_at=$3, closed_reason=CASE WHEN $1::text = 'Closed' THEN 'Manual' END WHERE id=$4 AND version=$2-1 RETURNING id, name, description, type, status, version, organization_id, creator_id, created_at, submission_deadline, decision_deadline, closed_reason, budget, budget_currency, budget_hidden, budget_policy, score_aggregation, sealed`
+	sqlRowCreateTenderHistory = `INSERT INTO tender_history (
+		tender_id,
+		name,
+		description,
+		type,
+		status,
+		version,
+		created_at
+	) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	sqlRowGetTenderVersions = `SELECT tender_id, name, description, type, status, version, created_at FROM tender_history WHERE tender_id=$1 ORDER BY version DESC`
+	sqlRowGetTenderVersion  = `SELECT tender_id, name, description, type, status, version, created_at FROM tender_history WHERE tender_id=$1 AND version=$2`
+	sqlRowGetTenderAward    = `SELECT t.id, b.id, b.name, b.version, b.author_type, b.creator_id, t.executor_organization_id, t.awarded_at, 
+		b.currency, b.total_net, b.total_vat, b.total, b.offer_version 
+	FROM tender t JOIN bids b ON b.id = t.winner_bid_id 
+	WHERE t.id=$1`
+	sqlRowCreateCriterion = `INSERT INTO tender_criteria (tender_id, position, name, weight) VALUES ($1, $2, $3, $4) RETURNING id`
+	sqlRowGetCriteria     = `SELECT id, tender_id, position, name, weight FROM tender_criteria WHERE tender_id=$1 ORDER BY position`
+	sqlRowCreateLot       = `INSERT INTO tender_lots (tender_id, position, name, description, budget, budget_currency) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	sqlRowGetLots         = `SELECT id, tender_id, position, name, description, budget, budget_currency, winner_bid_id, executor_organization_id, awarded_at 
+	FROM tender_lots WHERE tender_id=$1 ORDER BY position`
+	sqlRowCreateAuction = `INSERT INTO tender_auctions (tender_id, currency, start_price, min_decrement, starts_at, ends_at, extension_window, extension) 
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	sqlRowGetAuction = `SELECT tender_id, currency, start_price, min_decrement, starts_at, ends_at, extension_window, extension, closed_at, winner_bid_id 
+	FROM tender_auctions WHERE tender_id=$1`
+	// строки, заблокированные другой репликой, пропускаются, поэтому тендер закрывается ровно один раз;
+	// после срока подачи тендер остается опубликованным, чтобы по предложениям успели принять решение
+	sqlRowCloseOverdueTenders = `WITH overdue AS (
+		SELECT id FROM tender
+		WHERE status='Published' AND decision_deadline <= $1
+		ORDER BY decision_deadline
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	)
+	UPDATE tender t SET
+		status='Closed',
+		version=t.version+1,
+		closed_reason='DecisionDeadline',
+		updated_at=$3
+	FROM overdue WHERE t.id=overdue.id
+	RETURNING t.id, t.name, t.description, t.type, t.status, t.version, t.organization_id, t.creator_id, t.created_at, t.submission_deadline, t.decision_deadline, t.closed_reason, t.budget, t.budget_currency, t.budget_hidden, t.budget_policy, t.score_aggregation, t.sealed`
 )
 
-func (r *RepoLayer) GetAll(ctx context.Context, params *tqp.ListTenders) ([]*ent.Tender, error) {
+func (r *RepoLayer) GetAll(ctx context.Context, params *tqp.ListTenders) ([]*ent.Tender, *pagination.Cursors, error) {
 	query, args := getAllSqlQuery(params)
+	tenders, err := r.getTenders(ctx, query, args)
+	if err != nil {
+		return nil, nil, err
+	}
+	tenders, cursors := pagination.Cut(&params.Page, tenders, tenderKey)
+	return tenders, cursors, nil
+}
+
+func getAllSqlQuery(params *tqp.ListTenders) (string, []any) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder().
+		Select("id, name, description, type, status, version, organization_id, creator_id, created_at, submission_deadline, decision_deadline, closed_reason, budget, budget_currency, budget_hidden, budget_policy, score_aggregation, sealed").
+		From("tender")
+	if params.ServiceType != "" {
+		sb = sb.Where(sb.Equal("type", params.ServiceType))
+	}
+	sb = sb.Where(sb.NotEqual("status", "Created"))
+	sb = sb.Where(sb.NotEqual("status", "Closed"))
+	params.Page.Apply(sb)
+	return sb.Build()
+}
+
+// getTenders выполняет запрос списка тендеров, строки с ошибкой сканирования пропускаются
+func (r *RepoLayer) getTenders(ctx context.Context, query string, args []any) ([]*ent.Tender, error) {
 	rows, err := r.Client.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
@@ -81,17 +169,7 @@ func (r *RepoLayer) GetAll(ctx context.Context, params *tqp.ListTenders) ([]*ent
 	var tenders []*ent.Tender
 	for rows.Next() {
 		var t ent.Tender
-		err = rows.Scan(
-			&t.ID,
-			&t.Name,
-			&t.Description,
-			&t.Type,
-			&t.Status,
-			&t.Version,
-			&t.OrganizationID,
-			&t.CreatorID,
-			&t.CreatedAt,
-		)
+		err = scanTender(rows, &t)
 		if err != nil {
 			requestId := ctx.Value(mc.ContextKey(mc.RequestID)).(string)
 			r.Logger.Error(fmt.Sprintf("error while scanning sql result: %v", err), zap.String(mc.RequestID, requestId))
@@ -102,22 +180,135 @@ func (r *RepoLayer) GetAll(ctx context.Context, params *tqp.ListTenders) ([]*ent
 	return tenders, nil
 }
 
-func getAllSqlQuery(params *tqp.ListTenders) (string, []any) {
-	sb := sqlbuilder.PostgreSQL.NewSelectBuilder().
-		Select("id, name, description, type, status, version, organization_id, creator_id, created_at").
-		From("tender")
-	if params.ServiceType != "" {
-		sb = sb.Where(sb.Equal("type", params.ServiceType))
+// scanTender
+// Reads tender columns in the order they are selected everywhere in the repo,
+// extra destinations are scanned after them.
+func scanTender(row pgx.Row, t *ent.Tender, extra ...any) error {
+	var closedReason sql.NullString
+	var budget budgetDB
+	dest := append([]any{
+		&t.ID,
+		&t.Name,
+		&t.Description,
+		&t.Type,
+		&t.Status,
+		&t.Version,
+		&t.OrganizationID,
+		&t.CreatorID,
+		&t.CreatedAt,
+		&t.SubmissionDeadline,
+		&t.DecisionDeadline,
+		&closedReason,
+		&budget.Amount,
+		&budget.Currency,
+		&budget.Hidden,
+		&budget.Policy,
+		&t.ScoreAggregation,
+		&t.Sealed,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return err
 	}
-	sb = sb.Where(sb.NotEqual("status", "Created"))
-	sb = sb.Where(sb.NotEqual("status", "Closed"))
-	sb = sb.Offset(params.Offset).Limit(params.Limit).OrderBy("name").Asc()
-	return sb.Build()
+	t.ClosedReason = closedReason.String
+	t.Budget = newBudget(&budget)
+	return nil
+}
+
+func tenderKey(t *ent.Tender) pagination.Key {
+	return pagination.Key{ID: t.ID, Name: t.Name, CreatedAt: t.CreatedAt, Version: t.Version}
+}
+
+func (r *RepoLayer) Search(ctx context.Context, params *tqp.SearchTenders) ([]*ent.TenderSearchResult, *pagination.Cursors, error) {
+	query, args := getSearchSqlQuery(params)
+	rows, err := r.Client.Query(ctx, query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var results []*ent.TenderSearchResult
+	for rows.Next() {
+		var res ent.TenderSearchResult
+		err = scanTender(rows, &res.Tender, &res.Relevance)
+		if err != nil {
+			requestId := ctx.Value(mc.ContextKey(mc.RequestID)).(string)
+			r.Logger.Error(fmt.Sprintf("error while scanning sql result: %v", err), zap.String(mc.RequestID, requestId))
+			continue
+		}
+		results = append(results, &res)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	results, cursors := pagination.Cut(&params.Page, results, searchResultKey)
+	return results, cursors, nil
+}
+
+// getSearchSqlQuery
+// Filters and ranks tenders in the subquery, so that the page can be
+// built over the computed relevance column as over any other one.
+func getSearchSqlQuery(params *tqp.SearchTenders) (string, []any) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	relevance := "CAST(0 AS real)"
+	from := []string{"tender"}
+	if params.Query != "" {
+		var tsQuery string
+		if params.Language != "" {
+			tsQuery = fmt.Sprintf("websearch_to_tsquery(%s::regconfig, %s)", sb.Var(params.Language), sb.Var(params.Query))
+		} else {
+			// без указания языка запрос разбирается обеими конфигурациями
+			query := sb.Var(params.Query)
+			tsQuery = fmt.Sprintf("websearch_to_tsquery('russian', %s) || websearch_to_tsquery('english', %s)", query, query)
+		}
+		from = append(from, fmt.Sprintf("(SELECT %s AS query) AS q", tsQuery))
+		relevance = "ts_rank_cd(search_vector, query)"
+		sb.Where("search_vector @@ query")
+	}
+	sb.Select("id, name, description, type, status, version, organization_id, creator_id, created_at, submission_deadline, decision_deadline, closed_reason, budget, budget_currency, budget_hidden, budget_policy, score_aggregation, sealed",
+		relevance+" AS relevance").
+		From(from...)
+	// enum колонки сравниваются с массивом строк через явное приведение
+	sb.Where(fmt.Sprintf("status = ANY(%s::text[]::tender_status[])", sb.Var(params.Statuses)))
+	if len(params.ServiceTypes) != 0 {
+		sb.Where(fmt.Sprintf("type = ANY(%s::text[]::tender_type[])", sb.Var(params.ServiceTypes)))
+	}
+	if params.OrganizationID != 0 {
+		sb.Where(sb.Equal("organization_id", params.OrganizationID))
+	}
+	if !params.CreatedFrom.IsZero() {
+		sb.Where(sb.GreaterEqualThan("created_at", params.CreatedFrom))
+	}
+	if !params.CreatedTo.IsZero() {
+		sb.Where(sb.LessThan("created_at", params.CreatedTo))
+	}
+
+	page := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	page.Select("id, name, description, type, status, version, organization_id, creator_id, created_at, submission_deadline, decision_deadline, closed_reason, budget, budget_currency, budget_hidden, budget_policy, score_aggregation, sealed, relevance").
+		From(page.BuilderAs(sb, "found"))
+	params.Page.Apply(page)
+	return page.Build()
+}
+
+func searchResultKey(res *ent.TenderSearchResult) pagination.Key {
+	key := tenderKey(&res.Tender)
+	key.Rank = res.Relevance
+	return key
 }
 
 func (r *RepoLayer) Create(ctx context.Context, initData *ent.Tender) (*ent.Tender, error) {
 	timeNow := time.Now()
-	row := r.Client.QueryRow(ctx, sqlRowCreateTender,
+	tx, err := r.Client.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Откат транзакции в случае ошибки
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+	budget := newBudgetDB(initData.Budget)
+	row := tx.QueryRow(ctx, sqlRowCreateTender,
 		initData.Name,
 		initData.Description,
 		initData.Type,
@@ -126,47 +317,135 @@ func (r *RepoLayer) Create(ctx context.Context, initData *ent.Tender) (*ent.Tend
 		initData.OrganizationID,
 		initData.CreatorID,
 		timeNow,
+		initData.SubmissionDeadline,
+		initData.DecisionDeadline,
+		budget.Amount,
+		budget.Currency,
+		budget.Hidden,
+		budget.Policy,
+		initData.ScoreAggregation,
+		initData.Sealed,
 	)
 	var t ent.Tender
-	err := row.Scan(
-		&t.ID,
-		&t.Name,
-		&t.Description,
-		&t.Type,
-		&t.Status,
-		&t.Version,
-		&t.OrganizationID,
-		&t.CreatorID,
-		&t.CreatedAt,
-	)
+	err = scanTender(row, &t)
 	if err != nil {
 		return nil, err
 	}
+	if err = createCriteria(ctx, tx, t.ID, initData.Criteria); err != nil {
+		return nil, err
+	}
+	t.Criteria = initData.Criteria
+	if err = createLots(ctx, tx, t.ID, initData.Lots); err != nil {
+		return nil, err
+	}
+	t.Lots = initData.Lots
+	if initData.Auction != nil {
+		if err = createAuction(ctx, tx, t.ID, initData.Auction); err != nil {
+			return nil, err
+		}
+		t.Auction = initData.Auction
+	}
+	if err = createHistory(ctx, tx, &t, timeNow); err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
 	return &t, nil
 }
 
+// createCriteria
+// Saves evaluation criteria of the new tender, ids are set to the passed criteria.
+func createCriteria(ctx context.Context, tx pgx.Tx, tenderId int, criteria []*ent.Criterion) error {
+	for _, criterion := range criteria {
+		criterion.TenderID = tenderId
+		err := tx.QueryRow(ctx, sqlRowCreateCriterion, tenderId, criterion.Position, criterion.Name, criterion.Weight).Scan(&criterion.ID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createLots
+// Saves lots of the new tender, ids are set to the passed lots.
+func createLots(ctx context.Context, tx pgx.Tx, tenderId int, lots []*ent.Lot) error {
+	for _, lot := range lots {
+		lot.TenderID = tenderId
+		budget := newLotBudgetDB(lot)
+		err := tx.QueryRow(ctx, sqlRowCreateLot, tenderId, lot.Position, lot.Name, lot.Description, budget.Amount, budget.Currency).Scan(&lot.ID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createAuction
+// Saves settings of the reverse auction, the tender id is set to the passed auction.
+func createAuction(ctx context.Context, tx pgx.Tx, tenderId int, auction *ent.Auction) error {
+	auction.TenderID = tenderId
+	_, err := tx.Exec(ctx, sqlRowCreateAuction,
+		tenderId,
+		auction.Currency,
+		auction.StartPrice,
+		auction.MinDecrement,
+		auction.StartsAt,
+		auction.EndsAt,
+		int(auction.ExtensionWindow.Seconds()),
+		int(auction.Extension.Seconds()),
+	)
+	return err
+}
+
+// createHistory
+// Saves snapshot of the tender version, so it can be restored later.
+func createHistory(ctx context.Context, tx pgx.Tx, t *ent.Tender, createdAt time.Time) error {
+	_, err := tx.Exec(ctx, sqlRowCreateTenderHistory,
+		t.ID,
+		t.Name,
+		t.Description,
+		t.Type,
+		t.Status,
+		t.Version,
+		createdAt,
+	)
+	return err
+}
+
 func (r *RepoLayer) ChangeStatus(ctx context.Context, tenderId, tenderNewVersion int, status string) (*ent.Tender, error) {
-	row := r.Client.QueryRow(ctx, sqlRowUpdateTenderStatus, status, tenderNewVersion, tenderId)
+	timeNow := time.Now()
+	tx, err := r.Client.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Откат транзакции в случае ошибки
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+	row := tx.QueryRow(ctx, sqlRowUpdateTenderStatus, status, tenderNewVersion, timeNow, tenderId)
 	var t ent.Tender
-	err := row.Scan(
-		&t.ID,
-		&t.Name,
-		&t.Description,
-		&t.Type,
-		&t.Status,
-		&t.Version,
-		&t.OrganizationID,
-		&t.CreatorID,
-		&t.CreatedAt,
-	)
+	err = scanTender(row, &t)
 	if err != nil {
+		// tender existence is checked by the caller, so the version has been changed concurrently
+		if errors.Is(err, sql.ErrNoRows) {
+			err = e.ErrPrecondition
+		}
 		return nil, err
 	}
+	if err = createHistory(ctx, tx, &t, timeNow); err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
 	return &t, nil
 }
 
 func (r *RepoLayer) Update(ctx context.Context, newTenderData *ent.UpdateTenderData, params *UpdateTenderProps, tenderNewVersion int) (*ent.Tender, error) {
-	query, args := updateSqlQuery(newTenderData, params, tenderNewVersion)
+	timeNow := time.Now()
 	tx, err := r.Client.Begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
@@ -177,36 +456,40 @@ func (r *RepoLayer) Update(ctx context.Context, newTenderData *ent.UpdateTenderD
 			tx.Rollback(ctx)
 		}
 	}()
+	t, err := UpdateInTx(ctx, tx, newTenderData, params, tenderNewVersion, timeNow)
+	if err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return t, nil
+}
+
+// UpdateInTx
+// Saves the new version of the tender inside the transaction of the caller, so other repos
+// can amend the tender together with their own changes.
+func UpdateInTx(ctx context.Context, tx pgx.Tx, newTenderData *ent.UpdateTenderData, params *UpdateTenderProps, tenderNewVersion int, timeNow time.Time) (*ent.Tender, error) {
+	query, args := updateSqlQuery(newTenderData, params, tenderNewVersion, timeNow)
 	tag, err := tx.Exec(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	if tag.RowsAffected() == 0 {
-		return nil, err
+		return nil, e.ErrPrecondition
 	}
-	row := tx.QueryRow(ctx, `SELECT id, name, description, type, status, version, organization_id, creator_id, created_at FROM tender WHERE id=$1`, params.TenderID)
+	row := tx.QueryRow(ctx, `SELECT id, name, description, type, status, version, organization_id, creator_id, created_at, submission_deadline, decision_deadline, closed_reason, budget, budget_currency, budget_hidden, budget_policy, score_aggregation, sealed FROM tender WHERE id=$1`, params.TenderID)
 	var t ent.Tender
-	err = row.Scan(
-		&t.ID,
-		&t.Name,
-		&t.Description,
-		&t.Type,
-		&t.Status,
-		&t.Version,
-		&t.OrganizationID,
-		&t.CreatorID,
-		&t.CreatedAt,
-	)
-	if err != nil {
+	if err = scanTender(row, &t); err != nil {
 		return nil, err
 	}
-	if err = tx.Commit(ctx); err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	if err = createHistory(ctx, tx, &t, timeNow); err != nil {
+		return nil, err
 	}
 	return &t, nil
 }
 
-func updateSqlQuery(newTenderData *ent.UpdateTenderData, params *UpdateTenderProps, newTenderVersion int) (string, []any) {
+func updateSqlQuery(newTenderData *ent.UpdateTenderData, params *UpdateTenderProps, newTenderVersion int, updatedAt time.Time) (string, []any) {
 	sb := sqlbuilder.PostgreSQL.NewUpdateBuilder().Update("tender")
 	// Собираем все изменения
 	var updates []string
@@ -220,47 +503,29 @@ func updateSqlQuery(newTenderData *ent.UpdateTenderData, params *UpdateTenderPro
 	if newTenderData.Description != "" {
 		updates = append(updates, sb.Assign("description", newTenderData.Description))
 	}
-	updates = append(updates, sb.Assign("updated_at", time.Now()), sb.Assign("version", newTenderVersion))
+	updates = append(updates, sb.Assign("updated_at", updatedAt), sb.Assign("version", newTenderVersion))
 	sb.Set(updates...)
-	sb.Where(sb.Equal("id", params.TenderID))
+	// update is applied only to the version read by the caller
+	sb.Where(sb.Equal("id", params.TenderID), sb.Equal("version", newTenderVersion-1))
 	return sb.Build()
 }
 
-func (r *RepoLayer) GetUserTenders(ctx context.Context, params *UserTendersProps) ([]*ent.Tender, error) {
-	query, args := getGetUserTendersSqlQuery(params)
-	rows, err := r.Client.Query(ctx, query, args...)
+func (r *RepoLayer) GetUserTenders(ctx context.Context, params *UserTendersProps) ([]*ent.Tender, *pagination.Cursors, error) {
+	query, args := getUserTendersSqlQuery(params)
+	tenders, err := r.getTenders(ctx, query, args)
 	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	tenders := make([]*ent.Tender, 0)
-	for rows.Next() {
-		var t ent.Tender
-		err = rows.Scan(
-			&t.ID,
-			&t.Name,
-			&t.Description,
-			&t.Type,
-			&t.Status,
-			&t.Version,
-			&t.OrganizationID,
-			&t.CreatedAt,
-		)
-		if err != nil {
-			requestId := ctx.Value(mc.ContextKey(mc.RequestID)).(string)
-			r.Logger.Error(fmt.Sprintf("error while scanning sql result: %v", err), zap.String(mc.RequestID, requestId))
-			continue
-		}
-		tenders = append(tenders, &t)
+		return nil, nil, err
 	}
-	return tenders, nil
+	tenders, cursors := pagination.Cut(&params.Page, tenders, tenderKey)
+	return tenders, cursors, nil
 }
 
-func getGetUserTendersSqlQuery(params *UserTendersProps) (string, []any) {
-	sb := sqlbuilder.NewSelectBuilder().Select("id, name, description, type, status, version, organization_id, creator_id, created_at").From("tender")
-	sb = sb.Where(sb.Equal("creator_id", params.UserID))
-	sb = sb.Offset(int(params.Offset)).Limit(int(params.Limit)).OrderBy("name").Asc()
+func getUserTendersSqlQuery(params *UserTendersProps) (string, []any) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder().
+		Select("id, name, description, type, status, version, organization_id, creator_id, created_at, submission_deadline, decision_deadline, closed_reason, budget, budget_currency, budget_hidden, budget_policy, score_aggregation, sealed").
+		From("tender")
+	sb = sb.Where(fmt.Sprintf("organization_id = ANY(%s)", sb.Var(params.OrganizationIDs)))
+	params.Page.Apply(sb)
 	return sb.Build()
 }
 
@@ -274,52 +539,199 @@ func (r *RepoLayer) GetTenderStatus(ctx context.Context, tenderId int) (string,
 	return status, nil
 }
 
-func (r *RepoLayer) GetOrganizationTenders(ctx context.Context, organizationId int) ([]*ent.Tender, error) {
-	rows, err := r.Client.Query(ctx, `SELECT id, name, description, type, status, version, organization_id, creator_id, created_at FROM tender WHERE organization_id=$1`, organizationId)
+func (r *RepoLayer) GetTender(ctx context.Context, tenderId int) (*ent.Tender, error) {
+	row := r.Client.QueryRow(ctx, `SELECT id, name, description, type, status, version, organization_id, creator_id, created_at, submission_deadline, decision_deadline, closed_reason, budget, budget_currency, budget_hidden, budget_policy, score_aggregation, sealed FROM tender WHERE id=$1`, tenderId)
+	var t ent.Tender
+	err := scanTender(row, &t)
 	if err != nil {
 		return nil, err
 	}
+	return &t, nil
+}
 
-	var tenders []*ent.Tender
+func (r *RepoLayer) GetVersions(ctx context.Context, tenderId int) ([]*ent.TenderVersion, error) {
+	rows, err := r.Client.Query(ctx, sqlRowGetTenderVersions, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var versions []*ent.TenderVersion
 	for rows.Next() {
-		var t ent.Tender
+		var v ent.TenderVersion
 		err := rows.Scan(
-			&t.ID,
-			&t.Name,
-			&t.Description,
-			&t.Type,
-			&t.Status,
-			&t.Version,
-			&t.OrganizationID,
-			&t.CreatorID,
-			&t.CreatedAt,
+			&v.TenderID,
+			&v.Name,
+			&v.Description,
+			&v.Type,
+			&v.Status,
+			&v.Version,
+			&v.CreatedAt,
 		)
 		if err != nil {
 			requestId := ctx.Value(mc.ContextKey(mc.RequestID)).(string)
 			r.Logger.Error(fmt.Sprintf("error while scanning sql result: %v", err), zap.String(mc.RequestID, requestId))
 			continue
 		}
+		versions = append(versions, &v)
+	}
+	return versions, nil
+}
+
+func (r *RepoLayer) GetVersion(ctx context.Context, tenderId, version int) (*ent.TenderVersion, error) {
+	row := r.Client.QueryRow(ctx, sqlRowGetTenderVersion, tenderId, version)
+	var v ent.TenderVersion
+	err := row.Scan(
+		&v.TenderID,
+		&v.Name,
+		&v.Description,
+		&v.Type,
+		&v.Status,
+		&v.Version,
+		&v.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (r *RepoLayer) GetAward(ctx context.Context, tenderId int) (*ent.TenderAward, error) {
+	row := r.Client.QueryRow(ctx, sqlRowGetTenderAward, tenderId)
+	var award ent.TenderAward
+	var executorID sql.NullInt32
+	var offer offerDB
+	err := row.Scan(
+		&award.TenderID,
+		&award.BidID,
+		&award.BidName,
+		&award.BidVersion,
+		&award.AuthorType,
+		&award.CreatorID,
+		&executorID,
+		&award.AwardedAt,
+		&offer.Currency,
+		&offer.TotalNet,
+		&offer.TotalVat,
+		&offer.Total,
+		&offer.OfferVersion,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if executorID.Valid {
+		award.ExecutorOrganizationID = int(executorID.Int32)
+	}
+	award.BidOffer = newOffer(&offer)
+	return &award, nil
+}
+
+func (r *RepoLayer) CloseOverdue(ctx context.Context, now time.Time, limit int) ([]*ent.Tender, error) {
+	tx, err := r.Client.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Откат транзакции в случае ошибки
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+	// запрос закрывает только опубликованные тендеры
+	rows, err := tx.Query(ctx, sqlRowCloseOverdueTenders, now, limit, now)
+	if err != nil {
+		return nil, err
+	}
+	var tenders []*ent.Tender
+	for rows.Next() {
+		var t ent.Tender
+		if err = scanTender(rows, &t); err != nil {
+			rows.Close()
+			return nil, err
+		}
 		tenders = append(tenders, &t)
 	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	for _, t := range tenders {
+		if err = createHistory(ctx, tx, t, now); err != nil {
+			return nil, err
+		}
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
 	return tenders, nil
 }
 
-func (r *RepoLayer) GetTender(ctx context.Context, tenderId int) (*ent.Tender, error) {
-	row := r.Client.QueryRow(ctx, `SELECT id, name, description, type, status, version, organization_id, creator_id, created_at FROM tender WHERE id=$1`, tenderId)
-	var t ent.Tender
+func (r *RepoLayer) GetCriteria(ctx context.Context, tenderId int) ([]*ent.Criterion, error) {
+	rows, err := r.Client.Query(ctx, sqlRowGetCriteria, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var criteria []*ent.Criterion
+	for rows.Next() {
+		var c ent.Criterion
+		err = rows.Scan(&c.ID, &c.TenderID, &c.Position, &c.Name, &c.Weight)
+		if err != nil {
+			return nil, err
+		}
+		criteria = append(criteria, &c)
+	}
+	return criteria, rows.Err()
+}
+
+func (r *RepoLayer) GetAuction(ctx context.Context, tenderId int) (*ent.Auction, error) {
+	row := r.Client.QueryRow(ctx, sqlRowGetAuction, tenderId)
+	var auction auctionDB
 	err := row.Scan(
-		&t.ID,
-		&t.Name,
-		&t.Description,
-		&t.Type,
-		&t.Status,
-		&t.Version,
-		&t.OrganizationID,
-		&t.CreatorID,
-		&t.CreatedAt,
+		&auction.TenderID,
+		&auction.Currency,
+		&auction.StartPrice,
+		&auction.MinDecrement,
+		&auction.StartsAt,
+		&auction.EndsAt,
+		&auction.ExtensionWindow,
+		&auction.Extension,
+		&auction.ClosedAt,
+		&auction.WinnerBidID,
 	)
 	if err != nil {
 		return nil, err
 	}
-	return &t, nil
+	return newAuction(&auction), nil
+}
+
+func (r *RepoLayer) GetLots(ctx context.Context, tenderId int) ([]*ent.Lot, error) {
+	rows, err := r.Client.Query(ctx, sqlRowGetLots, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lots []*ent.Lot
+	for rows.Next() {
+		var lot lotDB
+		err = rows.Scan(
+			&lot.ID,
+			&lot.TenderID,
+			&lot.Position,
+			&lot.Name,
+			&lot.Description,
+			&lot.Budget.Amount,
+			&lot.Budget.Currency,
+			&lot.WinnerBidID,
+			&lot.ExecutorOrganizationID,
+			&lot.AwardedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		lots = append(lots, newLot(&lot))
+	}
+	return lots, rows.Err()
 }
@@ -0,0 +1,134 @@
+package tender
+
+import (
+	"database/sql"
+	ent "tender-workspace/internal/entity"
+	mc "tender-workspace/internal/utils/myconstants"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// budgetDB колонки бюджета, без бюджета сумма и валюта пустые
+type budgetDB struct {
+	Amount   decimal.NullDecimal
+	Currency sql.NullString
+	Hidden   bool
+	Policy   string
+}
+
+func newBudgetDB(budget *ent.TenderBudget) *budgetDB {
+	if budget == nil {
+		return &budgetDB{Policy: mc.BudgetPolicyReject}
+	}
+	return &budgetDB{
+		Amount:   decimal.NullDecimal{Decimal: budget.Amount, Valid: true},
+		Currency: sql.NullString{String: budget.Currency, Valid: true},
+		Hidden:   budget.Hidden,
+		Policy:   budget.Policy,
+	}
+}
+
+func newBudget(budget *budgetDB) *ent.TenderBudget {
+	if !budget.Amount.Valid {
+		return nil
+	}
+	return &ent.TenderBudget{
+		Amount:   budget.Amount.Decimal,
+		Currency: budget.Currency.String,
+		Hidden:   budget.Hidden,
+		Policy:   budget.Policy,
+	}
+}
+
+// offerDB итоги ценового предложения победителя
+type offerDB struct {
+	Currency     sql.NullString
+	TotalNet     decimal.Decimal
+	TotalVat     decimal.Decimal
+	Total        decimal.Decimal
+	OfferVersion sql.NullInt32
+}
+
+func newOffer(offer *offerDB) *ent.BidOffer {
+	if !offer.Currency.Valid {
+		return nil
+	}
+	return &ent.BidOffer{
+		Version:  int(offer.OfferVersion.Int32),
+		Currency: offer.Currency.String,
+		TotalNet: offer.TotalNet,
+		TotalVat: offer.TotalVat,
+		Total:    offer.Total,
+	}
+}
+
+// auctionDB колонки торгов, продления хранятся в секундах
+type auctionDB struct {
+	TenderID        int
+	Currency        string
+	StartPrice      decimal.Decimal
+	MinDecrement    decimal.Decimal
+	StartsAt        time.Time
+	EndsAt          time.Time
+	ExtensionWindow int
+	Extension       int
+	ClosedAt        *time.Time
+	WinnerBidID     sql.NullInt32
+}
+
+func newAuction(auction *auctionDB) *ent.Auction {
+	return &ent.Auction{
+		TenderID:        auction.TenderID,
+		Currency:        auction.Currency,
+		StartPrice:      auction.StartPrice,
+		MinDecrement:    auction.MinDecrement,
+		StartsAt:        auction.StartsAt,
+		EndsAt:          auction.EndsAt,
+		ExtensionWindow: time.Duration(auction.ExtensionWindow) * time.Second,
+		Extension:       time.Duration(auction.Extension) * time.Second,
+		ClosedAt:        auction.ClosedAt,
+		WinnerBidID:     int(auction.WinnerBidID.Int32),
+	}
+}
+
+// lotDB колонки лота, бюджет и победитель необязательны
+type lotDB struct {
+	ID                     int
+	TenderID               int
+	Position               int
+	Name                   string
+	Description            string
+	Budget                 budgetDB
+	WinnerBidID            sql.NullInt32
+	ExecutorOrganizationID sql.NullInt32
+	AwardedAt              *time.Time
+}
+
+func newLotBudgetDB(lot *ent.Lot) *budgetDB {
+	if lot.Budget == nil {
+		return &budgetDB{}
+	}
+	return &budgetDB{
+		Amount:   decimal.NullDecimal{Decimal: *lot.Budget, Valid: true},
+		Currency: sql.NullString{String: lot.BudgetCurrency, Valid: true},
+	}
+}
+
+func newLot(lot *lotDB) *ent.Lot {
+	res := &ent.Lot{
+		ID:                     lot.ID,
+		TenderID:               lot.TenderID,
+		Position:               lot.Position,
+		Name:                   lot.Name,
+		Description:            lot.Description,
+		BudgetCurrency:         lot.Budget.Currency.String,
+		WinnerBidID:            int(lot.WinnerBidID.Int32),
+		ExecutorOrganizationID: int(lot.ExecutorOrganizationID.Int32),
+		AwardedAt:              lot.AwardedAt,
+	}
+	if lot.Budget.Amount.Valid {
+		res.Budget = &lot.Budget.Amount.Decimal
+	}
+	return res
+}
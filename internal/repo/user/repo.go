@@ -2,31 +2,35 @@ package user
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	ent "tender-workspace/internal/entity"
+	"tender-workspace/internal/utils/metrics"
 	mc "tender-workspace/internal/utils/myconstants"
+	"tender-workspace/internal/utils/tracing"
+	"tender-workspace/services/postgres"
 	"time"
 
-	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
 type Repo interface {
-	Create(ctx context.Context, initData *ent.Employee) (*ent.Employee, error)
+	Create(ctx context.Context, initData *ent.Employee, passwordHash string) (*ent.Employee, error)
 	GetData(ctx context.Context, username string) (*ent.Employee, error)
+	GetCredentials(ctx context.Context, username string) (*ent.Employee, string, error)
 	GetUserOrganizationsIds(ctx context.Context, userId int) ([]int, error)
 }
 
 var _ Repo = (*RepoLayer)(nil)
 
 type RepoLayer struct {
-	Client *pgx.Conn
+	Client postgres.Client
 	Logger *zap.Logger
 }
 
-func NewRepoLayer(client *pgx.Conn, logger *zap.Logger) *RepoLayer {
+func NewRepoLayer(client postgres.Client, logger *zap.Logger) *RepoLayer {
 	return &RepoLayer{
-		Client: client,
+		Client: postgres.Instrument(client, "user", metrics.ObserveQuery, tracing.TraceQuery),
 		Logger: logger,
 	}
 }
@@ -36,10 +40,12 @@ var (
 											username, 
 											first_name, 
 											last_name,
+											password_hash,
 											created_at,
 											updated_at
-										) VALUES ($1, $2, $3, $4, $4) RETURNING id, username, first_name, last_name, created_at`
-	sqlRowGetUser = `SELECT id, username, first_name, last_name, created_at FROM employee WHERE username=$1`
+										) VALUES ($1, $2, $3, $4, $5, $5) RETURNING id, username, first_name, last_name, created_at`
+	sqlRowGetUser            = `SELECT id, username, first_name, last_name, created_at FROM employee WHERE username=$1`
+	sqlRowGetUserCredentials = `SELECT id, username, first_name, last_name, created_at, password_hash FROM employee WHERE username=$1`
 )
 
 func (r *RepoLayer) GetData(ctx context.Context, username string) (*ent.Employee, error) {
@@ -52,9 +58,22 @@ func (r *RepoLayer) GetData(ctx context.Context, username string) (*ent.Employee
 	return getUserFromDB(&uDB), nil
 }
 
-func (r *RepoLayer) Create(ctx context.Context, initData *ent.Employee) (*ent.Employee, error) {
+// GetCredentials
+// Returns employee with his password hash. Employees without password have empty hash.
+func (r *RepoLayer) GetCredentials(ctx context.Context, username string) (*ent.Employee, string, error) {
+	row := r.Client.QueryRow(ctx, sqlRowGetUserCredentials, username)
+	var uDB userDB
+	var passwordHash sql.NullString
+	err := row.Scan(&uDB.ID, &uDB.Username, &uDB.FirstName, &uDB.LastName, &uDB.CreatedAt, &passwordHash)
+	if err != nil {
+		return nil, "", err
+	}
+	return getUserFromDB(&uDB), passwordHash.String, nil
+}
+
+func (r *RepoLayer) Create(ctx context.Context, initData *ent.Employee, passwordHash string) (*ent.Employee, error) {
 	timeNow := time.Now()
-	row := r.Client.QueryRow(ctx, sqlRowCreateUser, initData.Username, initData.FirstName, initData.LastName, timeNow)
+	row := r.Client.QueryRow(ctx, sqlRowCreateUser, initData.Username, initData.FirstName, initData.LastName, passwordHash, timeNow)
 	var u ent.Employee
 	err := row.Scan(&u.ID, &u.Username, &u.FirstName, &u.LastName, &u.CreatedAt)
 	if err != nil {
@@ -68,6 +87,7 @@ func (r *RepoLayer) GetUserOrganizationsIds(ctx context.Context, userId int) ([]
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 	var ids []int
 	for rows.Next() {
 		var id int
@@ -0,0 +1,95 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	repoAuction "tender-workspace/internal/repo/auction"
+	repoBids "tender-workspace/internal/repo/bids"
+	repoOrg "tender-workspace/internal/repo/organization"
+	repoTender "tender-workspace/internal/repo/tender"
+	repoUser "tender-workspace/internal/repo/user"
+	usecaseAuction "tender-workspace/internal/usecase/auction"
+	usecaseTender "tender-workspace/internal/usecase/tender"
+	mc "tender-workspace/internal/utils/myconstants"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/satori/uuid"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+const defaultDeadlineInterval = time.Minute
+
+// Deadlines
+// Periodically closes published tenders whose deadline has passed and awards reverse auctions
+// whose bidding has ended. Deadlines are stored in PSQL only, so after restart overdue tenders are
+// picked up by the first run, and row locks keep several replicas from closing the same tender twice.
+type Deadlines struct {
+	ucTender  usecaseTender.Usecase
+	ucAuction usecaseAuction.Usecase
+	interval  time.Duration
+	logger    *zap.Logger
+}
+
+func NewDeadlines(ucTender usecaseTender.Usecase, ucAuction usecaseAuction.Usecase, interval time.Duration, logger *zap.Logger) *Deadlines {
+	return &Deadlines{
+		ucTender:  ucTender,
+		ucAuction: ucAuction,
+		interval:  interval,
+		logger:    logger,
+	}
+}
+
+// InitDeadlines собирает планировщик над пулом PSQL, период задается TENDER_DEADLINE_CHECK_INTERVAL
+func InitDeadlines(psqlPool *pgxpool.Pool, logger *zap.Logger) *Deadlines {
+	tRepo := repoTender.NewRepoLayer(psqlPool, logger)
+	uRepo := repoUser.NewRepoLayer(psqlPool, logger)
+	oRepo := repoOrg.NewRepoLayer(psqlPool, logger)
+	aRepo := repoAuction.NewRepoLayer(psqlPool, logger)
+	bRepo := repoBids.NewRepoLayer(psqlPool, logger)
+	tUsecase := usecaseTender.NewTracingLayer(usecaseTender.NewUsecaseLayer(tRepo, uRepo, oRepo))
+	aUsecase := usecaseAuction.NewTracingLayer(usecaseAuction.NewUsecaseLayer(aRepo, bRepo, uRepo, oRepo, tRepo))
+	interval := viper.GetDuration("TENDER_DEADLINE_CHECK_INTERVAL")
+	if interval <= 0 {
+		interval = defaultDeadlineInterval
+	}
+	return NewDeadlines(tUsecase, aUsecase, interval, logger)
+}
+
+// Run
+// Closes overdue tenders right away and then every interval until ctx is done.
+func (s *Deadlines) Run(ctx context.Context) {
+	s.logger.Info(fmt.Sprintf("deadline scheduler has started with interval %s", s.interval))
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		s.closeOverdue(ctx)
+		select {
+		case <-ctx.Done():
+			s.logger.Info("deadline scheduler has stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Deadlines) closeOverdue(ctx context.Context) {
+	// у запусков планировщика свой идентификатор, как у HTTP запросов
+	requestId := "scheduler-" + uuid.NewV4().String()
+	ctx = context.WithValue(ctx, mc.ContextKey(mc.RequestID), requestId)
+	closed, err := s.ucTender.CloseOverdueTenders(ctx)
+	if err != nil && ctx.Err() == nil {
+		s.logger.Error(fmt.Sprintf("error while closing overdue tenders: %v", err), zap.String(mc.RequestID, requestId))
+	}
+	if closed > 0 {
+		s.logger.Info(fmt.Sprintf("closed %d overdue tenders", closed), zap.String(mc.RequestID, requestId))
+	}
+	ended, err := s.ucAuction.CloseEndedAuctions(ctx)
+	if err != nil && ctx.Err() == nil {
+		s.logger.Error(fmt.Sprintf("error while closing ended auctions: %v", err), zap.String(mc.RequestID, requestId))
+	}
+	if ended > 0 {
+		s.logger.Info(fmt.Sprintf("closed %d ended auctions", ended), zap.String(mc.RequestID, requestId))
+	}
+}
@@ -0,0 +1,67 @@
+package attachment
+
+import (
+	"context"
+	"io"
+	ent "tender-workspace/internal/entity"
+	"tender-workspace/internal/entity/dto"
+	bqp "tender-workspace/internal/entity/dto/queries/bids"
+	tqp "tender-workspace/internal/entity/dto/queries/tenders"
+	"tender-workspace/internal/utils/tracing"
+)
+
+// TracingLayer
+// Usecase decorator that wraps every call into a span.
+type TracingLayer struct {
+	next Usecase
+}
+
+var _ Usecase = (*TracingLayer)(nil)
+
+func NewTracingLayer(next Usecase) *TracingLayer {
+	return &TracingLayer{
+		next: next,
+	}
+}
+
+func (t *TracingLayer) UploadTenderAttachment(ctx context.Context, input *dto.AttachmentInput, params *tqp.TenderAttachments) (*ent.Attachment, error) {
+	ctx, span := tracing.Start(ctx, "usecase.attachment.UploadTenderAttachment")
+	result, err := t.next.UploadTenderAttachment(ctx, input, params)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *TracingLayer) GetTenderAttachments(ctx context.Context, params *tqp.TenderAttachments) ([]*ent.Attachment, error) {
+	ctx, span := tracing.Start(ctx, "usecase.attachment.GetTenderAttachments")
+	result, err := t.next.GetTenderAttachments(ctx, params)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *TracingLayer) DownloadTenderAttachment(ctx context.Context, params *tqp.TenderAttachment) (*ent.Attachment, io.ReadCloser, error) {
+	ctx, span := tracing.Start(ctx, "usecase.attachment.DownloadTenderAttachment")
+	result, content, err := t.next.DownloadTenderAttachment(ctx, params)
+	tracing.End(span, err)
+	return result, content, err
+}
+
+func (t *TracingLayer) UploadBidAttachment(ctx context.Context, input *dto.AttachmentInput, params *bqp.BidAttachments) (*ent.Attachment, error) {
+	ctx, span := tracing.Start(ctx, "usecase.attachment.UploadBidAttachment")
+	result, err := t.next.UploadBidAttachment(ctx, input, params)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *TracingLayer) GetBidAttachments(ctx context.Context, params *bqp.BidAttachments) ([]*ent.Attachment, error) {
+	ctx, span := tracing.Start(ctx, "usecase.attachment.GetBidAttachments")
+	result, err := t.next.GetBidAttachments(ctx, params)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *TracingLayer) DownloadBidAttachment(ctx context.Context, params *bqp.BidAttachment) (*ent.Attachment, io.ReadCloser, error) {
+	ctx, span := tracing.Start(ctx, "usecase.attachment.DownloadBidAttachment")
+	result, content, err := t.next.DownloadBidAttachment(ctx, params)
+	tracing.End(span, err)
+	return result, content, err
+}
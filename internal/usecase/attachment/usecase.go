@@ -0,0 +1,375 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	ent "tender-workspace/internal/entity"
+	"tender-workspace/internal/entity/dto"
+	bqp "tender-workspace/internal/entity/dto/queries/bids"
+	tqp "tender-workspace/internal/entity/dto/queries/tenders"
+	"tender-workspace/internal/repo/attachment"
+	"tender-workspace/internal/repo/bids"
+	"tender-workspace/internal/repo/organization"
+	"tender-workspace/internal/repo/tender"
+	"tender-workspace/internal/repo/user"
+	mc "tender-workspace/internal/utils/myconstants"
+	e "tender-workspace/internal/utils/myerrors"
+	"tender-workspace/services/blob"
+	"time"
+	"unicode/utf8"
+
+	"github.com/satori/uuid"
+	"go.uber.org/zap"
+)
+
+type Usecase interface {
+	// UploadTenderAttachment прикладывает файл к тендеру от лица ответственного за него
+	UploadTenderAttachment(ctx context.Context, input *dto.AttachmentInput, params *tqp.TenderAttachments) (*ent.Attachment, error)
+	// GetTenderAttachments возвращает файлы тендера всем, кому виден тендер
+	GetTenderAttachments(ctx context.Context, params *tqp.TenderAttachments) ([]*ent.Attachment, error)
+	// DownloadTenderAttachment возвращает файл тендера с содержимым, его нужно закрыть после чтения
+	DownloadTenderAttachment(ctx context.Context, params *tqp.TenderAttachment) (*ent.Attachment, io.ReadCloser, error)
+	// UploadBidAttachment прикладывает файл к предложению от лица его автора, пока прием предложений открыт
+	UploadBidAttachment(ctx context.Context, input *dto.AttachmentInput, params *bqp.BidAttachments) (*ent.Attachment, error)
+	// GetBidAttachments возвращает файлы предложения всем, у кого есть доступ к предложению
+	GetBidAttachments(ctx context.Context, params *bqp.BidAttachments) ([]*ent.Attachment, error)
+	// DownloadBidAttachment возвращает файл предложения с содержимым, его нужно закрыть после чтения
+	DownloadBidAttachment(ctx context.Context, params *bqp.BidAttachment) (*ent.Attachment, io.ReadCloser, error)
+}
+
+var _ Usecase = (*UsecaseLayer)(nil)
+
+type UsecaseLayer struct {
+	repoAttachment   attachment.Repo
+	repoBids         bids.Repo
+	repoUser         user.Repo
+	repoOrganization organization.Repo
+	repoTender       tender.Repo
+	storage          blob.Storage
+	logger           *zap.Logger
+}
+
+func NewUsecaseLayer(repoAttachment attachment.Repo, repoBids bids.Repo, repoUser user.Repo, repoOrganization organization.Repo, repoTender tender.Repo, storage blob.Storage, logger *zap.Logger) *UsecaseLayer {
+	return &UsecaseLayer{
+		repoAttachment:   repoAttachment,
+		repoBids:         repoBids,
+		repoUser:         repoUser,
+		repoOrganization: repoOrganization,
+		repoTender:       repoTender,
+		storage:          storage,
+		logger:           logger,
+	}
+}
+
+func (u *UsecaseLayer) UploadTenderAttachment(ctx context.Context, input *dto.AttachmentInput, params *tqp.TenderAttachments) (*ent.Attachment, error) {
+	if err := checkName(input.Name); err != nil {
+		return nil, err
+	}
+	// get user id
+	userData, err := u.repoUser.GetData(ctx, params.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrUserExist
+		}
+		return nil, err
+	}
+	// check that tender exists
+	t, err := u.repoTender.GetTender(ctx, params.TenderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrNoTenders
+		}
+		return nil, err
+	}
+	// only responsible employees of the tender organization attach files
+	isResponsible, err := u.repoOrganization.IsUserResponsible(ctx, userData.ID, t.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible {
+		return nil, e.ErrResponsibilty
+	}
+	attachments, err := u.repoAttachment.GetTenderAttachments(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(attachments) >= mc.MaxAttachments {
+		return nil, e.ErrAttachmentsLimit
+	}
+	a := &ent.Attachment{
+		TenderID:  t.ID,
+		CreatorID: userData.ID,
+	}
+	return u.upload(ctx, a, input, fmt.Sprintf("tenders/%d", t.ID))
+}
+
+func (u *UsecaseLayer) GetTenderAttachments(ctx context.Context, params *tqp.TenderAttachments) ([]*ent.Attachment, error) {
+	t, err := u.getVisibleTender(ctx, params.TenderID, params.Username)
+	if err != nil {
+		return nil, err
+	}
+	return u.repoAttachment.GetTenderAttachments(ctx, t.ID)
+}
+
+func (u *UsecaseLayer) DownloadTenderAttachment(ctx context.Context, params *tqp.TenderAttachment) (*ent.Attachment, io.ReadCloser, error) {
+	t, err := u.getVisibleTender(ctx, params.TenderID, params.Username)
+	if err != nil {
+		return nil, nil, err
+	}
+	a, err := u.repoAttachment.GetAttachment(ctx, params.AttachmentID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, e.ErrNoAttachment
+		}
+		return nil, nil, err
+	}
+	if a.TenderID != t.ID {
+		return nil, nil, e.ErrNoAttachment
+	}
+	return u.download(ctx, a)
+}
+
+func (u *UsecaseLayer) UploadBidAttachment(ctx context.Context, input *dto.AttachmentInput, params *bqp.BidAttachments) (*ent.Attachment, error) {
+	if err := checkName(input.Name); err != nil {
+		return nil, err
+	}
+	// get user id
+	userData, err := u.repoUser.GetData(ctx, params.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrUserExist
+		}
+		return nil, err
+	}
+	// check that bid exists
+	bid, err := u.repoBids.GetBid(ctx, params.BidID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrNoBids
+		}
+		return nil, err
+	}
+	// files are attached by the author side on the same terms as the bid is edited
+	if bid.CreatorID != userData.ID {
+		isResponsible, err := u.repoOrganization.IsUserResponsible(ctx, userData.ID, bid.OrganizationID)
+		if err != nil {
+			return nil, err
+		}
+		if !isResponsible {
+			return nil, e.ErrResponsibilty
+		}
+	}
+	if bid.Status != "Created" && bid.Status != "Published" {
+		return nil, e.ErrBidNotEditable
+	}
+	t, err := u.repoTender.GetTender(ctx, bid.TenderID)
+	if err != nil {
+		return nil, err
+	}
+	if t.SubmissionDeadline != nil && !time.Now().Before(*t.SubmissionDeadline) {
+		return nil, e.ErrSubmissionClosed
+	}
+	// content of the sealed bid is given only by reveal
+	if t.Sealed {
+		return nil, e.ErrSealedTender
+	}
+	attachments, err := u.repoAttachment.GetBidAttachments(ctx, bid.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(attachments) >= mc.MaxAttachments {
+		return nil, e.ErrAttachmentsLimit
+	}
+	a := &ent.Attachment{
+		BidID:     bid.ID,
+		CreatorID: userData.ID,
+	}
+	return u.upload(ctx, a, input, fmt.Sprintf("bids/%d", bid.ID))
+}
+
+func (u *UsecaseLayer) GetBidAttachments(ctx context.Context, params *bqp.BidAttachments) ([]*ent.Attachment, error) {
+	bid, err := u.getAccessibleBid(ctx, params.BidID, params.Username)
+	if err != nil {
+		return nil, err
+	}
+	return u.repoAttachment.GetBidAttachments(ctx, bid.ID)
+}
+
+func (u *UsecaseLayer) DownloadBidAttachment(ctx context.Context, params *bqp.BidAttachment) (*ent.Attachment, io.ReadCloser, error) {
+	bid, err := u.getAccessibleBid(ctx, params.BidID, params.Username)
+	if err != nil {
+		return nil, nil, err
+	}
+	a, err := u.repoAttachment.GetAttachment(ctx, params.AttachmentID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, e.ErrNoAttachment
+		}
+		return nil, nil, err
+	}
+	if a.BidID != bid.ID {
+		return nil, nil, e.ErrNoAttachment
+	}
+	return u.download(ctx, a)
+}
+
+// upload
+// Reads the file up to the size limit, checks its type by the content and saves it
+// to the blob storage under the new key with the given prefix, then saves its metadata.
+func (u *UsecaseLayer) upload(ctx context.Context, a *ent.Attachment, input *dto.AttachmentInput, keyPrefix string) (*ent.Attachment, error) {
+	content, err := io.ReadAll(io.LimitReader(input.Content, mc.MaxAttachmentSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) == 0 || len(content) > mc.MaxAttachmentSize {
+		return nil, e.ErrAttachmentSize
+	}
+	contentType, err := checkContentType(input.ContentType, content)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(content)
+	a.Name = input.Name
+	a.ContentType = contentType
+	a.Size = int64(len(content))
+	a.SHA256 = hex.EncodeToString(hash[:])
+	a.StorageKey = keyPrefix + "/" + uuid.NewV4().String()
+	a.CreatedAt = time.Now()
+	if err = u.storage.Put(ctx, a.StorageKey, bytes.NewReader(content), a.Size, a.ContentType); err != nil {
+		return nil, err
+	}
+	created, err := u.repoAttachment.Create(ctx, a)
+	if err != nil {
+		// файл без метаданных никто не скачает, поэтому он удаляется
+		if delErr := u.storage.Delete(ctx, a.StorageKey); delErr != nil {
+			requestId, _ := ctx.Value(mc.ContextKey(mc.RequestID)).(string)
+			u.logger.Error(fmt.Sprintf("error while deleting orphan blob %s: %v", a.StorageKey, delErr), zap.String(mc.RequestID, requestId))
+		}
+		return nil, err
+	}
+	return created, nil
+}
+
+func (u *UsecaseLayer) download(ctx context.Context, a *ent.Attachment) (*ent.Attachment, io.ReadCloser, error) {
+	content, err := u.storage.Get(ctx, a.StorageKey)
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			return nil, nil, e.ErrNoAttachment
+		}
+		return nil, nil, err
+	}
+	return a, content, nil
+}
+
+// getVisibleTender
+// Tender is visible to every employee once it's published, before that only to the tender side.
+func (u *UsecaseLayer) getVisibleTender(ctx context.Context, tenderID int, username string) (*ent.Tender, error) {
+	// get user id
+	userData, err := u.repoUser.GetData(ctx, username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrUserExist
+		}
+		return nil, err
+	}
+	// check that tender exists
+	t, err := u.repoTender.GetTender(ctx, tenderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrNoTenders
+		}
+		return nil, err
+	}
+	if t.Status != "Created" {
+		return t, nil
+	}
+	isResponsible, err := u.repoOrganization.IsUserResponsible(ctx, userData.ID, t.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible {
+		return nil, e.ErrBadPermission
+	}
+	return t, nil
+}
+
+// getAccessibleBid
+// Author side (creator or responsible of the bid organization) always has access to the bid,
+// tender side has access only when the bid is published.
+func (u *UsecaseLayer) getAccessibleBid(ctx context.Context, bidID int, username string) (*ent.Bid, error) {
+	// get user id
+	userData, err := u.repoUser.GetData(ctx, username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrUserExist
+		}
+		return nil, err
+	}
+	// check that bid exists
+	bid, err := u.repoBids.GetBid(ctx, bidID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrNoBids
+		}
+		return nil, err
+	}
+	if bid.CreatorID == userData.ID {
+		return bid, nil
+	}
+	isResponsible, err := u.repoOrganization.IsUserResponsible(ctx, userData.ID, bid.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if isResponsible {
+		return bid, nil
+	}
+	// for tender side
+	t, err := u.repoTender.GetTender(ctx, bid.TenderID)
+	if err != nil {
+		return nil, err
+	}
+	isResponsible, err = u.repoOrganization.IsUserResponsible(ctx, userData.ID, t.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible || bid.Status != "Published" {
+		return nil, e.ErrBadPermission
+	}
+	return bid, nil
+}
+
+func checkName(name string) error {
+	length := utf8.RuneCountInString(name)
+	if length == 0 || length > mc.MaxAttachmentName || strings.ContainsAny(name, "/\\") {
+		return e.ErrAttachment
+	}
+	return nil
+}
+
+// checkContentType
+// Declared type must be in the allow-list and agree with the type detected by the content,
+// so renamed executables aren't accepted as documents. Returns declared type without parameters.
+func checkContentType(declared string, content []byte) (string, error) {
+	mediaType, _, err := mime.ParseMediaType(declared)
+	if err != nil {
+		return "", e.ErrAttachmentType
+	}
+	expected, ok := mc.AvaliableAttachmentType[mediaType]
+	if !ok {
+		return "", e.ErrAttachmentType
+	}
+	detected, _, err := mime.ParseMediaType(http.DetectContentType(content))
+	if err != nil || detected != expected {
+		return "", e.ErrAttachmentType
+	}
+	return mediaType, nil
+}
@@ -0,0 +1,151 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	ent "tender-workspace/internal/entity"
+	"tender-workspace/internal/entity/dto"
+	"tender-workspace/internal/repo/attachment"
+	mc "tender-workspace/internal/utils/myconstants"
+	e "tender-workspace/internal/utils/myerrors"
+	"tender-workspace/services/blob"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestCheckContentType(t *testing.T) {
+	pdf := []byte("%PDF-1.7\n1 0 obj\n")
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	jpeg := []byte("\xff\xd8\xff\xe0\x00\x10JFIF\x00")
+	zip := []byte("PK\x03\x04\x14\x00\x00\x00\x08\x00")
+	exe := []byte("MZ\x90\x00\x03\x00\x00\x00\x04\x00")
+	text := []byte("name;price\nboard;100\n")
+	tests := []struct {
+		name     string
+		declared string
+		content  []byte
+		want     string
+		wantErr  bool
+	}{
+		{name: "pdf", declared: "application/pdf", content: pdf, want: "application/pdf"},
+		{name: "png", declared: "image/png", content: png, want: "image/png"},
+		{name: "jpeg", declared: "image/jpeg", content: jpeg, want: "image/jpeg"},
+		{name: "text with charset", declared: "text/plain; charset=utf-8", content: text, want: "text/plain"},
+		{name: "csv is text", declared: "text/csv; charset=utf-8", content: text, want: "text/csv"},
+		{name: "upper case type", declared: "Application/PDF", content: pdf, want: "application/pdf"},
+		{name: "zip", declared: "application/zip", content: zip, want: "application/zip"},
+		{name: "docx is zip", declared: "application/vnd.openxmlformats-officedocument.wordprocessingml.document", content: zip,
+			want: "application/vnd.openxmlformats-officedocument.wordprocessingml.document"},
+		{name: "xlsx is zip", declared: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", content: zip,
+			want: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+		{name: "executable declared as pdf", declared: "application/pdf", content: exe, wantErr: true},
+		{name: "executable declared as text", declared: "text/plain", content: exe, wantErr: true},
+		{name: "pdf declared as image", declared: "image/png", content: pdf, wantErr: true},
+		{name: "executable type", declared: "application/x-msdownload", content: exe, wantErr: true},
+		{name: "html", declared: "text/html", content: []byte("<html><script></script></html>"), wantErr: true},
+		{name: "octet stream", declared: "application/octet-stream", content: pdf, wantErr: true},
+		{name: "empty type", declared: "", content: pdf, wantErr: true},
+		{name: "malformed type", declared: "application/pdf; =", content: pdf, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := checkContentType(tt.declared, tt.content)
+			if tt.wantErr {
+				if !errors.Is(err, e.ErrAttachmentType) {
+					t.Errorf("checkContentType() = %q, %v, want ErrAttachmentType", got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("checkContentType() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckName(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		wantErr bool
+	}{
+		{name: "plain", file: "offer.pdf"},
+		{name: "cyrillic at the limit", file: strings.Repeat("ф", mc.MaxAttachmentName)},
+		{name: "empty", file: "", wantErr: true},
+		{name: "too long", file: strings.Repeat("a", mc.MaxAttachmentName+1), wantErr: true},
+		{name: "slash", file: "../offer.pdf", wantErr: true},
+		{name: "backslash", file: `..\offer.pdf`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkName(tt.file); (err != nil) != tt.wantErr {
+				t.Errorf("checkName() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// repoStub сохраняет метаданные в памяти, остальные методы репозитория тесту не нужны
+type repoStub struct {
+	attachment.Repo
+	created []*ent.Attachment
+}
+
+func (r *repoStub) Create(ctx context.Context, initData *ent.Attachment) (*ent.Attachment, error) {
+	initData.ID = len(r.created) + 1
+	r.created = append(r.created, initData)
+	return initData, nil
+}
+
+func TestUploadSizeLimit(t *testing.T) {
+	storage, err := blob.NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocal() error = %v", err)
+	}
+	tests := []struct {
+		name string
+		size int
+		want error
+	}{
+		{name: "empty", size: 0, want: e.ErrAttachmentSize},
+		{name: "one byte", size: 1},
+		{name: "at the limit", size: mc.MaxAttachmentSize},
+		{name: "over the limit", size: mc.MaxAttachmentSize + 1, want: e.ErrAttachmentSize},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &repoStub{}
+			u := NewUsecaseLayer(repo, nil, nil, nil, nil, storage, zap.NewNop())
+			input := &dto.AttachmentInput{
+				Name:        "spec.txt",
+				ContentType: "text/plain",
+				Content:     bytes.NewReader(bytes.Repeat([]byte("a"), tt.size)),
+			}
+			created, err := u.upload(context.Background(), &ent.Attachment{TenderID: 1}, input, "tenders/1")
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("upload() error = %v, want %v", err, tt.want)
+			}
+			if tt.want != nil {
+				if len(repo.created) != 0 {
+					t.Errorf("rejected file is saved")
+				}
+				return
+			}
+			if created.Size != int64(tt.size) || created.ContentType != "text/plain" || !strings.HasPrefix(created.StorageKey, "tenders/1/") {
+				t.Errorf("upload() = %+v", created)
+			}
+			reader, err := storage.Get(context.Background(), created.StorageKey)
+			if err != nil {
+				t.Fatalf("stored file: %v", err)
+			}
+			defer reader.Close()
+			if stored, _ := io.ReadAll(reader); len(stored) != tt.size {
+				t.Errorf("stored %d bytes, want %d", len(stored), tt.size)
+			}
+		})
+	}
+}
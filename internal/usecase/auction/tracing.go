@@ -0,0 +1,45 @@
+package auction
+
+import (
+	"context"
+	ent "tender-workspace/internal/entity"
+	"tender-workspace/internal/entity/dto"
+	bqp "tender-workspace/internal/entity/dto/queries/bids"
+	tqp "tender-workspace/internal/entity/dto/queries/tenders"
+	"tender-workspace/internal/utils/tracing"
+)
+
+// TracingLayer
+// Usecase decorator that wraps every call into a span.
+type TracingLayer struct {
+	next Usecase
+}
+
+var _ Usecase = (*TracingLayer)(nil)
+
+func NewTracingLayer(next Usecase) *TracingLayer {
+	return &TracingLayer{
+		next: next,
+	}
+}
+
+func (t *TracingLayer) PlaceOffer(ctx context.Context, input *dto.AuctionOfferInput, params *bqp.AuctionOffer) (*ent.AuctionRanking, error) {
+	ctx, span := tracing.Start(ctx, "usecase.auction.PlaceOffer")
+	result, err := t.next.PlaceOffer(ctx, input, params)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *TracingLayer) GetRanking(ctx context.Context, params *tqp.AuctionRanking) (*ent.AuctionRanking, error) {
+	ctx, span := tracing.Start(ctx, "usecase.auction.GetRanking")
+	result, err := t.next.GetRanking(ctx, params)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *TracingLayer) CloseEndedAuctions(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "usecase.auction.CloseEndedAuctions")
+	result, err := t.next.CloseEndedAuctions(ctx)
+	tracing.End(span, err)
+	return result, err
+}
@@ -0,0 +1,16 @@
+package auction
+
+import (
+	ent "tender-workspace/internal/entity"
+	"tender-workspace/internal/entity/dto"
+	"time"
+)
+
+func newAuctionOffer(bid *ent.Bid, input *dto.AuctionOfferInput, createdAt time.Time) *ent.AuctionOffer {
+	return &ent.AuctionOffer{
+		TenderID:  bid.TenderID,
+		BidID:     bid.ID,
+		Price:     input.Price,
+		CreatedAt: createdAt,
+	}
+}
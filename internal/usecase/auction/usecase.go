@@ -0,0 +1,166 @@
+package auction
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	ent "tender-workspace/internal/entity"
+	"tender-workspace/internal/entity/dto"
+	bqp "tender-workspace/internal/entity/dto/queries/bids"
+	tqp "tender-workspace/internal/entity/dto/queries/tenders"
+	"tender-workspace/internal/repo/auction"
+	"tender-workspace/internal/repo/bids"
+	"tender-workspace/internal/repo/organization"
+	"tender-workspace/internal/repo/tender"
+	"tender-workspace/internal/repo/user"
+	f "tender-workspace/internal/utils/functions"
+	"tender-workspace/internal/utils/metrics"
+	mc "tender-workspace/internal/utils/myconstants"
+	e "tender-workspace/internal/utils/myerrors"
+	"time"
+)
+
+type Usecase interface {
+	// PlaceOffer снижает цену опубликованного предложения от лица его автора и возвращает рейтинг торгов
+	PlaceOffer(ctx context.Context, input *dto.AuctionOfferInput, params *bqp.AuctionOffer) (*ent.AuctionRanking, error)
+	// GetRanking возвращает текущий рейтинг торгов по лучшим ставкам предложений
+	GetRanking(ctx context.Context, params *tqp.AuctionRanking) (*ent.AuctionRanking, error)
+	// CloseEndedAuctions присуждает тендеры аукционов с истекшими торгами, вызывается планировщиком
+	CloseEndedAuctions(ctx context.Context) (int, error)
+}
+
+var _ Usecase = (*UsecaseLayer)(nil)
+
+type UsecaseLayer struct {
+	repoAuction      auction.Repo
+	repoBids         bids.Repo
+	repoUser         user.Repo
+	repoOrganization organization.Repo
+	repoTender       tender.Repo
+}
+
+func NewUsecaseLayer(repoAuction auction.Repo, repoBids bids.Repo, repoUser user.Repo, repoOrganization organization.Repo, repoTender tender.Repo) *UsecaseLayer {
+	return &UsecaseLayer{
+		repoAuction:      repoAuction,
+		repoBids:         repoBids,
+		repoUser:         repoUser,
+		repoOrganization: repoOrganization,
+		repoTender:       repoTender,
+	}
+}
+
+func (u *UsecaseLayer) PlaceOffer(ctx context.Context, input *dto.AuctionOfferInput, params *bqp.AuctionOffer) (*ent.AuctionRanking, error) {
+	// get user id
+	userData, err := u.repoUser.GetData(ctx, params.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrUserExist
+		}
+		return nil, err
+	}
+	// check that bid exists
+	bid, err := u.repoBids.GetBid(ctx, params.BidID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrNoBids
+		}
+		return nil, err
+	}
+	// only author side lowers the price
+	if bid.CreatorID != userData.ID {
+		isResponsible, err := u.repoOrganization.IsUserResponsible(ctx, userData.ID, bid.OrganizationID)
+		if err != nil {
+			return nil, err
+		}
+		if !isResponsible {
+			return nil, e.ErrResponsibilty
+		}
+	}
+	if _, err := u.repoTender.GetAuction(ctx, bid.TenderID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrNoAuction
+		}
+		return nil, err
+	}
+	if !input.Price.IsPositive() || !f.FitsNumeric(input.Price, 2) {
+		return nil, e.ErrAuctionPrice
+	}
+	t, err := u.repoTender.GetTender(ctx, bid.TenderID)
+	if err != nil {
+		return nil, err
+	}
+	if bid.Status != "Published" || t.Status != "Published" {
+		return nil, e.ErrAuctionNotActive
+	}
+	// валюта торгов совпадает с валютой бюджета, поэтому ставки сравниваются с ним напрямую
+	if t.Budget != nil && t.Budget.Policy == mc.BudgetPolicyReject && input.Price.GreaterThan(t.Budget.Amount) {
+		return nil, e.ErrOverBudget
+	}
+	_, err = u.repoAuction.PlaceOffer(ctx, newAuctionOffer(bid, input, time.Now()))
+	if err != nil {
+		return nil, err
+	}
+	return u.getRanking(ctx, bid.TenderID)
+}
+
+// GetRanking
+// Ranking of the reverse auction is open to every employee, as the published tender itself.
+func (u *UsecaseLayer) GetRanking(ctx context.Context, params *tqp.AuctionRanking) (*ent.AuctionRanking, error) {
+	// get user id
+	_, err := u.repoUser.GetData(ctx, params.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrUserExist
+		}
+		return nil, err
+	}
+	// check that tender exists
+	_, err = u.repoTender.GetTender(ctx, params.TenderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrNoTenders
+		}
+		return nil, err
+	}
+	return u.getRanking(ctx, params.TenderID)
+}
+
+func (u *UsecaseLayer) getRanking(ctx context.Context, tenderID int) (*ent.AuctionRanking, error) {
+	a, err := u.repoTender.GetAuction(ctx, tenderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrNoAuction
+		}
+		return nil, err
+	}
+	ranks, err := u.repoAuction.GetRanking(ctx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	ranking := &ent.AuctionRanking{
+		Auction: a,
+		Ranks:   ranks,
+	}
+	if len(ranks) != 0 {
+		ranking.BestPrice = &ranks[0].Price
+	}
+	return ranking, nil
+}
+
+func (u *UsecaseLayer) CloseEndedAuctions(ctx context.Context) (int, error) {
+	closed := 0
+	for {
+		a, closedReason, err := u.repoAuction.CloseEnded(ctx, time.Now())
+		if err != nil {
+			return closed, err
+		}
+		if a == nil {
+			return closed, nil
+		}
+		// тендер, закрытый раньше окончания торгов, уже учтен
+		if closedReason != "" {
+			metrics.TenderClosed(closedReason)
+		}
+		closed++
+	}
+}
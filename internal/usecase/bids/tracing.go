@@ -0,0 +1,108 @@
+package bids
+
+import (
+	"context"
+	ent "tender-workspace/internal/entity"
+	"tender-workspace/internal/entity/dto"
+	bqp "tender-workspace/internal/entity/dto/queries/bids"
+	"tender-workspace/internal/utils/pagination"
+	"tender-workspace/internal/utils/tracing"
+)
+
+// TracingLayer
+// Usecase decorator that wraps every call into a span.
+type TracingLayer struct {
+	next Usecase
+}
+
+var _ Usecase = (*TracingLayer)(nil)
+
+func NewTracingLayer(next Usecase) *TracingLayer {
+	return &TracingLayer{
+		next: next,
+	}
+}
+
+func (t *TracingLayer) CreateBid(ctx context.Context, initData *dto.BidInput) (*ent.Bid, error) {
+	ctx, span := tracing.Start(ctx, "usecase.bids.CreateBid")
+	result, err := t.next.CreateBid(ctx, initData)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *TracingLayer) CreateSealedBid(ctx context.Context, initData *dto.SealedBidInput) (*ent.Bid, error) {
+	ctx, span := tracing.Start(ctx, "usecase.bids.CreateSealedBid")
+	result, err := t.next.CreateSealedBid(ctx, initData)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *TracingLayer) RevealBid(ctx context.Context, revealData *dto.BidRevealInput, params *bqp.BidReveal) (*ent.Bid, error) {
+	ctx, span := tracing.Start(ctx, "usecase.bids.RevealBid")
+	result, err := t.next.RevealBid(ctx, revealData, params)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *TracingLayer) GetUserBids(ctx context.Context, params *bqp.ListUserBids) ([]*ent.Bid, *pagination.Cursors, error) {
+	ctx, span := tracing.Start(ctx, "usecase.bids.GetUserBids")
+	result, cursors, err := t.next.GetUserBids(ctx, params)
+	tracing.End(span, err)
+	return result, cursors, err
+}
+
+func (t *TracingLayer) GetTenderBids(ctx context.Context, params *bqp.TenderBidList) ([]*ent.Bid, *pagination.Cursors, error) {
+	ctx, span := tracing.Start(ctx, "usecase.bids.GetTenderBids")
+	result, cursors, err := t.next.GetTenderBids(ctx, params)
+	tracing.End(span, err)
+	return result, cursors, err
+}
+
+func (t *TracingLayer) GetBidStatus(ctx context.Context, params *bqp.BidStatus) (*ent.Bid, error) {
+	ctx, span := tracing.Start(ctx, "usecase.bids.GetBidStatus")
+	result, err := t.next.GetBidStatus(ctx, params)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *TracingLayer) UpdateBidStatus(ctx context.Context, params *bqp.UpdateBidStatus) (*ent.Bid, error) {
+	ctx, span := tracing.Start(ctx, "usecase.bids.UpdateBidStatus")
+	result, err := t.next.UpdateBidStatus(ctx, params)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *TracingLayer) UpdateBid(ctx context.Context, updateData *dto.BidUpdateDataInput, params *bqp.UpdateBidData) (*ent.Bid, error) {
+	ctx, span := tracing.Start(ctx, "usecase.bids.UpdateBid")
+	result, err := t.next.UpdateBid(ctx, updateData, params)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *TracingLayer) RollbackBid(ctx context.Context, params *bqp.BidRollback) (*ent.Bid, error) {
+	ctx, span := tracing.Start(ctx, "usecase.bids.RollbackBid")
+	result, err := t.next.RollbackBid(ctx, params)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *TracingLayer) GetBidDiff(ctx context.Context, params *bqp.BidDiff) (*ent.BidDiff, error) {
+	ctx, span := tracing.Start(ctx, "usecase.bids.GetBidDiff")
+	result, err := t.next.GetBidDiff(ctx, params)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *TracingLayer) SubmitDecision(ctx context.Context, params *bqp.SubmitDecision) (*ent.BidDecisionResult, error) {
+	ctx, span := tracing.Start(ctx, "usecase.bids.SubmitDecision")
+	result, err := t.next.SubmitDecision(ctx, params)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *TracingLayer) GetBidOffer(ctx context.Context, params *bqp.BidOffer) (*ent.BidOffer, error) {
+	ctx, span := tracing.Start(ctx, "usecase.bids.GetBidOffer")
+	result, err := t.next.GetBidOffer(ctx, params)
+	tracing.End(span, err)
+	return result, err
+}
@@ -1,12 +1,19 @@
 package bids
 
 import (
+	"strings"
 	ent "tender-workspace/internal/entity"
 	"tender-workspace/internal/entity/dto"
 	bqp "tender-workspace/internal/entity/dto/queries/bids"
 	b "tender-workspace/internal/repo/bids"
+	mc "tender-workspace/internal/utils/myconstants"
+	"time"
+
+	"github.com/shopspring/decimal"
 )
 
+var percent = decimal.NewFromInt(100)
+
 func newBid(initData *dto.BidInput, user *ent.Employee) *ent.Bid {
 	return &ent.Bid{
 		Name:           initData.Name,
@@ -16,13 +23,146 @@ func newBid(initData *dto.BidInput, user *ent.Employee) *ent.Bid {
 		TenderID:       initData.TenderID,
 		OrganizationID: initData.OrganizationID,
 		CreatorID:      user.ID,
+		Offer:          newBidOffer(initData.Items, 1),
+	}
+}
+
+// newSealedBid содержимое закрытого предложения задается при раскрытии
+func newSealedBid(initData *dto.SealedBidInput, user *ent.Employee) *ent.Bid {
+	return &ent.Bid{
+		Status:         initData.Status,
+		Version:        1,
+		TenderID:       initData.TenderID,
+		OrganizationID: initData.OrganizationID,
+		CreatorID:      user.ID,
+		Commitment:     strings.ToLower(initData.Commitment),
 	}
 }
 
+func newBidLots(lots []*ent.Lot) []*ent.BidLot {
+	res := make([]*ent.BidLot, 0, len(lots))
+	for _, lot := range lots {
+		res = append(res, &ent.BidLot{
+			LotID:  lot.ID,
+			Status: mc.BidLotPending,
+		})
+	}
+	return res
+}
+
+// newBidOffer
+// Computes amounts of the line items and totals of the offer. Each line is rounded
+// to cents separately, so totals match the sum of the lines as printed in documents.
+func newBidOffer(items []dto.BidItemInput, version int) *ent.BidOffer {
+	if len(items) == 0 {
+		return nil
+	}
+	offer := &ent.BidOffer{
+		Version:  version,
+		Currency: items[0].Currency,
+		Items:    make([]*ent.BidItem, 0, len(items)),
+	}
+	for i, item := range items {
+		netAmount := item.Quantity.Mul(item.UnitPrice).Round(2)
+		vatAmount := netAmount.Mul(item.VatRate).Div(percent).Round(2)
+		offer.Items = append(offer.Items, &ent.BidItem{
+			Position:  i + 1,
+			Name:      item.Name,
+			Quantity:  item.Quantity,
+			Unit:      item.Unit,
+			UnitPrice: item.UnitPrice,
+			VatRate:   item.VatRate,
+			Currency:  item.Currency,
+			NetAmount: netAmount,
+			VatAmount: vatAmount,
+			Amount:    netAmount.Add(vatAmount),
+		})
+		offer.TotalNet = offer.TotalNet.Add(netAmount)
+		offer.TotalVat = offer.TotalVat.Add(vatAmount)
+	}
+	offer.Total = offer.TotalNet.Add(offer.TotalVat)
+	return offer
+}
+
 func newUpdateBidProps(updateProps *bqp.UpdateBidData, updateData *dto.BidUpdateDataInput) *b.UpdateBid {
 	return &b.UpdateBid{
 		BidID:       updateProps.BidID,
 		Name:        updateData.Name,
 		Description: updateData.Description,
+		// версия предложения назначается репозиторием
+		Offer: newBidOffer(updateData.Items, 0),
+	}
+}
+
+func newRevealBidProps(bidID int, revealData *dto.BidUpdateDataInput, revealedAt time.Time) *b.UpdateBid {
+	return &b.UpdateBid{
+		BidID:       bidID,
+		Name:        revealData.Name,
+		Description: revealData.Description,
+		Offer:       newBidOffer(revealData.Items, 0),
+		RevealedAt:  &revealedAt,
+	}
+}
+
+func newRollbackBidProps(version *ent.BidVersion) *b.UpdateBid {
+	return &b.UpdateBid{
+		BidID:        version.BidID,
+		Name:         version.Name,
+		Description:  version.Description,
+		Offer:        version.Offer,
+		RestoreOffer: true,
+	}
+}
+
+func newSubmitDecisionProps(params *bqp.SubmitDecision, user *ent.Employee, quorum int) *b.SubmitDecisionProps {
+	return &b.SubmitDecisionProps{
+		BidID:    params.BidID,
+		LotID:    params.LotID,
+		UserID:   user.ID,
+		Decision: params.Decision,
+		Quorum:   quorum,
+	}
+}
+
+func newBidDiff(from, to *ent.BidVersion) *ent.BidDiff {
+	diff := &ent.BidDiff{
+		BidID:       from.BidID,
+		FromVersion: from.Version,
+		ToVersion:   to.Version,
+		Changes:     make([]*ent.BidFieldChange, 0),
+	}
+	fields := []struct {
+		name     string
+		from, to string
+	}{
+		{"name", from.Name, to.Name},
+		{"description", from.Description, to.Description},
+		{"status", from.Status, to.Status},
+		{"currency", offerCurrency(from.Offer), offerCurrency(to.Offer)},
+		{"total", offerTotal(from.Offer), offerTotal(to.Offer)},
+	}
+	for _, field := range fields {
+		if field.from != field.to {
+			diff.Changes = append(diff.Changes, &ent.BidFieldChange{
+				Field: field.name,
+				From:  field.from,
+				To:    field.to,
+			})
+		}
+	}
+	return diff
+}
+
+func offerCurrency(offer *ent.BidOffer) string {
+	if offer == nil {
+		return ""
+	}
+	return offer.Currency
+}
+
+func offerTotal(offer *ent.BidOffer) string {
+	if offer == nil {
+		return ""
 	}
+	return offer.Total.StringFixed(2)
 }
@@ -2,7 +2,10 @@ package bids
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/subtle"
 	"database/sql"
+	"encoding/hex"
 	"errors"
 	"strings"
 	ent "tender-workspace/internal/entity"
@@ -12,20 +15,37 @@ import (
 	"tender-workspace/internal/repo/organization"
 	"tender-workspace/internal/repo/tender"
 	"tender-workspace/internal/repo/user"
+	"tender-workspace/internal/utils/metrics"
 	mc "tender-workspace/internal/utils/myconstants"
 	e "tender-workspace/internal/utils/myerrors"
+	"tender-workspace/internal/utils/pagination"
+	sm "tender-workspace/internal/utils/statemachine"
+	"time"
+	"unicode/utf8"
 )
 
+// minRevealNonce минимальная длина nonce, с которым вычислен хэш закрытого предложения
+const minRevealNonce = 16
+
 type Usecase interface {
 	CreateBid(ctx context.Context, initData *dto.BidInput) (*ent.Bid, error)
-	GetUserBids(ctx context.Context, params *bqp.ListUserBids) ([]*ent.Bid, error)
-	GetTenderBids(ctx context.Context, params *bqp.TenderBidList) ([]*ent.Bid, error)
+	// CreateSealedBid принимает хэш предложения на закрытый тендер
+	CreateSealedBid(ctx context.Context, initData *dto.SealedBidInput) (*ent.Bid, error)
+	// RevealBid раскрывает содержимое закрытого предложения после срока подачи
+	RevealBid(ctx context.Context, revealData *dto.BidRevealInput, params *bqp.BidReveal) (*ent.Bid, error)
+	GetUserBids(ctx context.Context, params *bqp.ListUserBids) ([]*ent.Bid, *pagination.Cursors, error)
+	GetTenderBids(ctx context.Context, params *bqp.TenderBidList) ([]*ent.Bid, *pagination.Cursors, error)
 
-	GetBidStatus(ctx context.Context, params *bqp.BidStatus) (string, error)
+	GetBidStatus(ctx context.Context, params *bqp.BidStatus) (*ent.Bid, error)
 	UpdateBidStatus(ctx context.Context, params *bqp.UpdateBidStatus) (*ent.Bid, error)
 	UpdateBid(ctx context.Context, updateData *dto.BidUpdateDataInput, params *bqp.UpdateBidData) (*ent.Bid, error)
-	// SubmitBidDecision(ctx context.Context, bidID, decision, username string) (Bid, error)
-	// SubmitBidFeedback(ctx context.Context, bidID string, feedback BidFeedback, username string) (Bid, error)
+	// RollbackBid откатывает параметры предложения к указанной версии
+	RollbackBid(ctx context.Context, params *bqp.BidRollback) (*ent.Bid, error)
+	GetBidDiff(ctx context.Context, params *bqp.BidDiff) (*ent.BidDiff, error)
+	// SubmitDecision учитывает решение ответственного за тендер, итоговый статус определяется кворумом
+	SubmitDecision(ctx context.Context, params *bqp.SubmitDecision) (*ent.BidDecisionResult, error)
+	// GetBidOffer возвращает ценовое предложение с позициями
+	GetBidOffer(ctx context.Context, params *bqp.BidOffer) (*ent.BidOffer, error)
 }
 
 var _ Usecase = (*UsecaseLayer)(nil)
@@ -70,171 +90,254 @@ func (u *UsecaseLayer) CreateBid(ctx context.Context, initData *dto.BidInput) (*
 		}
 		return nil, err
 	}
-	isUser := true
-	if initData.OrganizationID != 0 {
-		// check org existing
-		_, err = u.repoOrganization.Get(ctx, initData.OrganizationID)
-		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				return nil, e.ErrOrganizationExist
-			}
-			return nil, err
-		}
-		isResponsible, err := u.repoOrganization.IsUserResponsible(ctx, userData.ID, initData.OrganizationID)
-		if err != nil {
+	if t.Sealed {
+		return nil, e.ErrSealedTender
+	}
+	if err := checkSubmissionOpen(t); err != nil {
+		return nil, err
+	}
+	if len(initData.Items) != 0 {
+		if err := u.checkNotAuction(ctx, t); err != nil {
 			return nil, err
 		}
-		if !isResponsible {
-			return nil, e.ErrUserAndOrg
+	}
+	authorType, err := u.checkBidAuthor(ctx, userData.ID, t, initData.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	lots, err := u.checkBidLots(ctx, t, initData.LotIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	props := newBid(initData, userData)
+	props.Lots = newBidLots(lots)
+	props.OverBudget, err = checkBudget(t, lots, props.Offer)
+	if err != nil {
+		return nil, err
+	}
+	props.AuthorType = authorType
+	bid, err := u.repoBids.Create(ctx, props)
+	if err != nil {
+		return nil, err
+	}
+	metrics.BidSubmitted()
+	return bid, nil
+}
+
+func (u *UsecaseLayer) CreateSealedBid(ctx context.Context, initData *dto.SealedBidInput) (*ent.Bid, error) {
+	// check validation of req body fields
+	bidStatus := strings.ToLower(initData.Status)
+	if bidStatus != "created" {
+		return nil, e.ErrBadStatusCreate
+	}
+	initData.Status = "Created"
+	// get user id
+	userData, err := u.repoUser.GetData(ctx, initData.CreatorUsername)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrUserExist
 		}
-		isUser = false
+		return nil, err
 	}
-	if isUser {
-		has, err := u.repoBids.UserHasBid(ctx, userData.ID, initData.TenderID)
-		if err != nil {
-			return nil, err
+	// check tender existing
+	t, err := u.repoTender.GetTender(ctx, initData.TenderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrTenderExist
 		}
-		if has {
-			return nil, e.ErrUserAlreadyHasBid
+		return nil, err
+	}
+	if !t.Sealed {
+		return nil, e.ErrNotSealedTender
+	}
+	if err := checkSubmissionOpen(t); err != nil {
+		return nil, err
+	}
+	authorType, err := u.checkBidAuthor(ctx, userData.ID, t, initData.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	lots, err := u.checkBidLots(ctx, t, initData.LotIDs)
+	if err != nil {
+		return nil, err
+	}
+	props := newSealedBid(initData, userData)
+	props.Lots = newBidLots(lots)
+	props.AuthorType = authorType
+	bid, err := u.repoBids.Create(ctx, props)
+	if err != nil {
+		return nil, err
+	}
+	metrics.BidSubmitted()
+	return bid, nil
+}
+
+func (u *UsecaseLayer) RevealBid(ctx context.Context, revealData *dto.BidRevealInput, params *bqp.BidReveal) (*ent.Bid, error) {
+	// check that bid exists
+	bid, err := u.repoBids.GetBid(ctx, params.BidID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrNoBids
 		}
-	} else {
-		if t.OrganizationID == initData.OrganizationID {
-			return nil, e.ErrBidYourself
+		return nil, err
+	}
+	if params.ExpectedVersion != 0 && params.ExpectedVersion != bid.Version {
+		return nil, e.ErrPrecondition
+	}
+	// get user id
+	user, err := u.repoUser.GetData(ctx, params.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrUserExist
 		}
-		has, err := u.repoBids.OrganizationHasBid(ctx, initData.OrganizationID, initData.TenderID)
+		return nil, err
+	}
+	// only author side knows the content
+	if bid.CreatorID != user.ID {
+		isResponsible, err := u.repoOrganization.IsUserResponsible(ctx, user.ID, bid.OrganizationID)
 		if err != nil {
 			return nil, err
 		}
-		if has {
-			return nil, e.ErrOrgAlreadyHasBid
+		if !isResponsible {
+			return nil, e.ErrResponsibilty
 		}
 	}
-
-	props := newBid(initData, userData)
-	if isUser {
-		props.AuthorType = "User"
-	} else {
-		props.AuthorType = "Responsible"
+	if !bid.IsSealed() {
+		return nil, e.ErrNothingToReveal
 	}
-	return u.repoBids.Create(ctx, props)
+	t, err := u.repoTender.GetTender(ctx, bid.TenderID)
+	if err != nil {
+		return nil, err
+	}
+	// content is revealed only for submitted bids, when no more bids can be submitted
+	if bid.Status != "Published" || t.Status != "Published" || checkSubmissionOpen(t) == nil {
+		return nil, e.ErrRevealNotOpen
+	}
+	if !checkCommitment(bid.Commitment, revealData.Nonce, revealData.Content) {
+		return nil, e.ErrRevealMismatch
+	}
+	lots, err := u.getBidLots(ctx, t, bid.ID)
+	if err != nil {
+		return nil, err
+	}
+	bidData := newRevealBidProps(bid.ID, &revealData.Data, time.Now())
+	bidData.OverBudget, err = checkBudget(t, lots, bidData.Offer)
+	if err != nil {
+		return nil, err
+	}
+	return u.repoBids.Update(ctx, bidData, bid.Version+1)
 }
 
-func (u *UsecaseLayer) GetUserBids(ctx context.Context, params *bqp.ListUserBids) ([]*ent.Bid, error) {
+func (u *UsecaseLayer) GetUserBids(ctx context.Context, params *bqp.ListUserBids) ([]*ent.Bid, *pagination.Cursors, error) {
 	// get user id
 	userData, err := u.repoUser.GetData(ctx, params.Username)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, e.ErrUserExist
+			return nil, nil, e.ErrUserExist
 		}
-		return nil, err
+		return nil, nil, err
 	}
-	var userBids []*ent.Bid
 	// get array of organizations ids
 	organizationsIds, err := u.repoUser.GetUserOrganizationsIds(ctx, userData.ID)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
-		return nil, err
-	}
-	if len(organizationsIds) != 0 {
-		for _, organizationId := range organizationsIds {
-			bids, err := u.repoBids.GetOrganizationBids(ctx, organizationId)
-			if err != nil {
-				if errors.Is(err, sql.ErrNoRows) {
-					continue
-				}
-				return nil, err
-			}
-			if len(bids) != 0 {
-				userBids = append(userBids, bids...)
-			}
-		}
-	}
-	bs, err := u.repoBids.GetUserBids(ctx, userData.ID)
-	if err != nil && !errors.Is(err, sql.ErrNoRows) {
-		return nil, err
-	}
-	if len(bs) != 0 {
-		userBids = append(userBids, bs...)
-	}
-	if params.Offset >= len(userBids) {
-		return nil, nil
+		return nil, nil, err
 	}
-	return userBids[params.Offset:min(params.Offset+params.Limit, len(userBids))], nil
+	return u.repoBids.GetUserBids(ctx, &bids.UserBidsProps{
+		UserID:          userData.ID,
+		OrganizationIDs: organizationsIds,
+		Page:            params.Page,
+	})
 }
 
-func (u *UsecaseLayer) GetTenderBids(ctx context.Context, params *bqp.TenderBidList) ([]*ent.Bid, error) {
+func (u *UsecaseLayer) GetTenderBids(ctx context.Context, params *bqp.TenderBidList) ([]*ent.Bid, *pagination.Cursors, error) {
 	// get user id
 	userData, err := u.repoUser.GetData(ctx, params.Username)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, e.ErrUserExist
+			return nil, nil, e.ErrUserExist
 		}
-		return nil, err
+		return nil, nil, err
 	}
 	// check tender existing
 	t, err := u.repoTender.GetTender(ctx, params.TenderID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, e.ErrTenderExist
+			return nil, nil, e.ErrTenderExist
 		}
-		return nil, err
+		return nil, nil, err
 	}
 	// check user responsibility
 	isResponsible, err := u.repoOrganization.IsUserResponsible(ctx, userData.ID, t.OrganizationID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if !isResponsible {
-		return nil, e.ErrResponsibilty
+		return nil, nil, e.ErrResponsibilty
+	}
+	if params.LotID != 0 {
+		lots, err := u.repoTender.GetLots(ctx, t.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if findLot(lots, params.LotID) == nil {
+			return nil, nil, e.ErrNoLot
+		}
 	}
 	// get tender bids
-	return u.repoBids.GetTenderBids(ctx, params.TenderID)
+	return u.repoBids.GetTenderBids(ctx, &bids.TenderBidsProps{
+		TenderID: params.TenderID,
+		LotID:    params.LotID,
+		Page:     params.Page,
+	})
 }
 
-func (u *UsecaseLayer) GetBidStatus(ctx context.Context, params *bqp.BidStatus) (string, error) {
+func (u *UsecaseLayer) GetBidStatus(ctx context.Context, params *bqp.BidStatus) (*ent.Bid, error) {
 	// get user id
 	userData, err := u.repoUser.GetData(ctx, params.Username)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return "", e.ErrUserExist
+			return nil, e.ErrUserExist
 		}
-		return "", err
+		return nil, err
 	}
 	// check that bid exists
 	bid, err := u.repoBids.GetBid(ctx, params.BidID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return "", e.ErrNoBids
+			return nil, e.ErrNoBids
 		}
-		return "", err
+		return nil, err
 	}
 	// check responsibility
 	isResponsible, err := u.repoOrganization.IsUserResponsible(ctx, userData.ID, bid.OrganizationID)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if isResponsible {
-		return bid.Status, nil
+		return bid, nil
 	}
 	// if authorType is 'User'
 	if bid.CreatorID == userData.ID {
-		return bid.Status, nil
+		return bid, nil
 	}
 	// for tender side
 	t, err := u.repoTender.GetTender(ctx, bid.TenderID)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	isResponsible, err = u.repoOrganization.IsUserResponsible(ctx, userData.ID, t.OrganizationID)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if isResponsible {
 		if bid.Status == "Published" {
-			return bid.Status, nil
+			return bid, nil
 		}
-		return "", e.ErrBadPermission
+		return nil, e.ErrBadPermission
 	}
-	return "", e.ErrBadPermission
+	return nil, e.ErrBadPermission
 }
 
 func (u *UsecaseLayer) UpdateBidStatus(ctx context.Context, params *bqp.UpdateBidStatus) (*ent.Bid, error) {
@@ -254,44 +357,49 @@ func (u *UsecaseLayer) UpdateBidStatus(ctx context.Context, params *bqp.UpdateBi
 		}
 		return nil, err
 	}
-	hasCreatorPrivilege := false
-	// if authorType is 'User'
-	if bid.CreatorID == userData.ID {
-		hasCreatorPrivilege = true
+	if params.ExpectedVersion != 0 && params.ExpectedVersion != bid.Version {
+		return nil, e.ErrPrecondition
 	}
-	// check responsibility
-	isResponsible, err := u.repoOrganization.IsUserResponsible(ctx, userData.ID, bid.OrganizationID)
-	if err != nil {
-		return nil, err
-	}
-	if isResponsible || hasCreatorPrivilege {
-		statusLower := strings.ToLower(params.Status)
-		if _, ok := mc.AvaliableBidStatusCreator[statusLower]; !ok {
-			return nil, e.ErrSetDeprecatedStatus
+	// collect roles of the user in relation to the bid
+	var roles []sm.Role
+	isAuthor := bid.CreatorID == userData.ID
+	if !isAuthor {
+		isAuthor, err = u.repoOrganization.IsUserResponsible(ctx, userData.ID, bid.OrganizationID)
+		if err != nil {
+			return nil, err
 		}
-		runes := []rune(statusLower)
-		params.Status = strings.ToUpper(string(runes[0])) + string(runes[1:])
-		return u.repoBids.UpdateStatus(ctx, params.BidID, params.Status, bid.Version+1)
+	}
+	if isAuthor {
+		roles = append(roles, sm.RoleBidAuthor)
 	}
 	// for tender side
 	t, err := u.repoTender.GetTender(ctx, bid.TenderID)
 	if err != nil {
 		return nil, err
 	}
-	isResponsible, err = u.repoOrganization.IsUserResponsible(ctx, userData.ID, t.OrganizationID)
+	isResponsible, err := u.repoOrganization.IsUserResponsible(ctx, userData.ID, t.OrganizationID)
 	if err != nil {
 		return nil, err
 	}
 	if isResponsible {
-		statusLower := strings.ToLower(params.Status)
-		if _, ok := mc.AvaliableBidStatusApprover[statusLower]; !ok {
-			return nil, e.ErrSetDeprecatedStatus
+		roles = append(roles, sm.RoleTenderResponsible)
+	}
+	if len(roles) == 0 {
+		return nil, e.ErrBadPermission
+	}
+	statusLower := strings.ToLower(params.Status)
+	runes := []rune(statusLower)
+	params.Status = strings.ToUpper(string(runes[0])) + string(runes[1:])
+	if err := sm.Bid.Check(bid.Status, params.Status, roles...); err != nil {
+		return nil, err
+	}
+	// withdrawal is allowed at any time, publication only before the deadline
+	if params.Status == "Published" {
+		if err := checkSubmissionOpen(t); err != nil {
+			return nil, err
 		}
-		runes := []rune(statusLower)
-		params.Status = strings.ToUpper(string(runes[0])) + string(runes[1:])
-		return u.repoBids.UpdateStatus(ctx, params.BidID, params.Status, bid.Version+1)
 	}
-	return nil, e.ErrBadPermission
+	return u.repoBids.UpdateStatus(ctx, params.BidID, params.Status, bid.Version+1)
 }
 
 func (u *UsecaseLayer) UpdateBid(ctx context.Context, updateData *dto.BidUpdateDataInput, params *bqp.UpdateBidData) (*ent.Bid, error) {
@@ -300,6 +408,9 @@ func (u *UsecaseLayer) UpdateBid(ctx context.Context, updateData *dto.BidUpdateD
 	if err != nil {
 		return nil, e.ErrNoBids
 	}
+	if params.ExpectedVersion != 0 && params.ExpectedVersion != bid.Version {
+		return nil, e.ErrPrecondition
+	}
 	// get user id
 	user, err := u.repoUser.GetData(ctx, params.Username)
 	if err != nil {
@@ -314,8 +425,35 @@ func (u *UsecaseLayer) UpdateBid(ctx context.Context, updateData *dto.BidUpdateD
 		return nil, err
 	}
 	if isResponsible || bid.CreatorID == user.ID {
+		if err := checkBidEditable(bid); err != nil {
+			return nil, err
+		}
+		t, err := u.repoTender.GetTender(ctx, bid.TenderID)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkSubmissionOpen(t); err != nil {
+			return nil, err
+		}
+		// content of the sealed bid is given only by reveal
+		if t.Sealed {
+			return nil, e.ErrSealedTender
+		}
 		// update status
 		bidData := newUpdateBidProps(params, updateData)
+		if bidData.Offer != nil {
+			if err := u.checkNotAuction(ctx, t); err != nil {
+				return nil, err
+			}
+			lots, err := u.getBidLots(ctx, t, bid.ID)
+			if err != nil {
+				return nil, err
+			}
+			bidData.OverBudget, err = checkBudget(t, lots, bidData.Offer)
+			if err != nil {
+				return nil, err
+			}
+		}
 		bid, err := u.repoBids.Update(ctx, bidData, bid.Version+1)
 		if err != nil {
 			return nil, err
@@ -324,3 +462,445 @@ func (u *UsecaseLayer) UpdateBid(ctx context.Context, updateData *dto.BidUpdateD
 	}
 	return nil, e.ErrResponsibilty
 }
+
+func (u *UsecaseLayer) RollbackBid(ctx context.Context, params *bqp.BidRollback) (*ent.Bid, error) {
+	// check that bid exists
+	bid, err := u.repoBids.GetBid(ctx, params.BidID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrNoBids
+		}
+		return nil, err
+	}
+	if params.ExpectedVersion != 0 && params.ExpectedVersion != bid.Version {
+		return nil, e.ErrPrecondition
+	}
+	// get user id
+	user, err := u.repoUser.GetData(ctx, params.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrUserExist
+		}
+		return nil, err
+	}
+	// check user responsibility
+	isResponsible, err := u.repoOrganization.IsUserResponsible(ctx, user.ID, bid.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible && bid.CreatorID != user.ID {
+		return nil, e.ErrResponsibilty
+	}
+	if err := checkBidEditable(bid); err != nil {
+		return nil, err
+	}
+	t, err := u.repoTender.GetTender(ctx, bid.TenderID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSubmissionOpen(t); err != nil {
+		return nil, err
+	}
+	if t.Sealed {
+		return nil, e.ErrSealedTender
+	}
+	// get snapshot of the requested version
+	version, err := u.repoBids.GetVersion(ctx, params.BidID, params.Version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrNoBidVersion
+		}
+		return nil, err
+	}
+	// restored offer is checked against the budget as a new one
+	lots, err := u.getBidLots(ctx, t, bid.ID)
+	if err != nil {
+		return nil, err
+	}
+	bidData := newRollbackBidProps(version)
+	if bidData.Offer != nil {
+		if err := u.checkNotAuction(ctx, t); err != nil {
+			return nil, err
+		}
+	}
+	bidData.OverBudget, err = checkBudget(t, lots, bidData.Offer)
+	if err != nil {
+		return nil, err
+	}
+	// rollback is considered as a new edit, so version is incremented
+	return u.repoBids.Update(ctx, bidData, bid.Version+1)
+}
+
+func (u *UsecaseLayer) GetBidDiff(ctx context.Context, params *bqp.BidDiff) (*ent.BidDiff, error) {
+	// get user id
+	userData, err := u.repoUser.GetData(ctx, params.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrUserExist
+		}
+		return nil, err
+	}
+	// check that bid exists
+	bid, err := u.repoBids.GetBid(ctx, params.BidID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrNoBids
+		}
+		return nil, err
+	}
+	hasAccess, err := u.hasBidAccess(ctx, userData.ID, bid)
+	if err != nil {
+		return nil, err
+	}
+	if !hasAccess {
+		return nil, e.ErrBadPermission
+	}
+	fromVersion, err := u.repoBids.GetVersion(ctx, params.BidID, params.FromVersion)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrNoBidVersion
+		}
+		return nil, err
+	}
+	toVersion, err := u.repoBids.GetVersion(ctx, params.BidID, params.ToVersion)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrNoBidVersion
+		}
+		return nil, err
+	}
+	return newBidDiff(fromVersion, toVersion), nil
+}
+
+func (u *UsecaseLayer) SubmitDecision(ctx context.Context, params *bqp.SubmitDecision) (*ent.BidDecisionResult, error) {
+	// get user id
+	userData, err := u.repoUser.GetData(ctx, params.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrUserExist
+		}
+		return nil, err
+	}
+	// check that bid exists
+	bid, err := u.repoBids.GetBid(ctx, params.BidID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrNoBids
+		}
+		return nil, err
+	}
+	t, err := u.repoTender.GetTender(ctx, bid.TenderID)
+	if err != nil {
+		return nil, err
+	}
+	// only tender side makes decisions
+	isResponsible, err := u.repoOrganization.IsUserResponsible(ctx, userData.ID, t.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible {
+		return nil, e.ErrBadPermission
+	}
+	// status itself is changed by the service when quorum is reached
+	if err := sm.Bid.Check(bid.Status, params.Decision, sm.RoleSystem); err != nil {
+		return nil, err
+	}
+	if bid.IsSealed() {
+		return nil, e.ErrBidSealed
+	}
+	// bid to the tender with lots is decided in each of its lots
+	bidLots, err := u.repoBids.GetLots(ctx, bid.ID)
+	if err != nil {
+		return nil, err
+	}
+	if (len(bidLots) == 0) != (params.LotID == 0) {
+		return nil, e.ErrLotDecision
+	}
+	if params.LotID != 0 && !hasBidLot(bidLots, params.LotID) {
+		return nil, e.ErrLotDecision
+	}
+	if params.Decision == "Approved" {
+		// approval awards the tender: it's closed and competing bids are rejected
+		if err := sm.Tender.Check(t.Status, "Closed", sm.RoleSystem); err != nil {
+			return nil, err
+		}
+		// reverse auction is awarded only by its end
+		_, err := u.repoTender.GetAuction(ctx, t.ID)
+		if err == nil {
+			return nil, e.ErrAuctionTender
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+	}
+	responsibleCount, err := u.repoOrganization.CountResponsible(ctx, t.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	result, err := u.repoBids.SubmitDecision(ctx, newSubmitDecisionProps(params, userData, min(mc.DecisionQuorum, responsibleCount)))
+	if err != nil {
+		return nil, err
+	}
+	metrics.BidDecisionMade(params.Decision)
+	if result.TenderClosed || (params.LotID == 0 && result.Bid.Status == "Approved") {
+		metrics.TenderClosed(mc.ClosedAwarded)
+	}
+	return result, nil
+}
+
+func (u *UsecaseLayer) GetBidOffer(ctx context.Context, params *bqp.BidOffer) (*ent.BidOffer, error) {
+	// get user id
+	userData, err := u.repoUser.GetData(ctx, params.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrUserExist
+		}
+		return nil, err
+	}
+	// check that bid exists
+	bid, err := u.repoBids.GetBid(ctx, params.BidID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrNoBids
+		}
+		return nil, err
+	}
+	hasAccess, err := u.hasBidAccess(ctx, userData.ID, bid)
+	if err != nil {
+		return nil, err
+	}
+	if !hasAccess {
+		return nil, e.ErrBadPermission
+	}
+	if bid.Offer == nil {
+		return nil, e.ErrNoBidOffer
+	}
+	bid.Offer.Items, err = u.repoBids.GetItems(ctx, params.BidID)
+	if err != nil {
+		return nil, err
+	}
+	return bid.Offer, nil
+}
+
+// checkNotAuction
+// Bid to the reverse auction enters it without static price, its prices are given by auction offers,
+// so the lowest one is awarded when the auction ends.
+func (u *UsecaseLayer) checkNotAuction(ctx context.Context, t *ent.Tender) error {
+	_, err := u.repoTender.GetAuction(ctx, t.ID)
+	if err == nil {
+		return e.ErrAuctionTender
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	return nil
+}
+
+// checkBidEditable
+// Canceled bid and bid with the decision are final, their content isn't changed.
+func checkBidEditable(bid *ent.Bid) error {
+	if bid.Status != "Created" && bid.Status != "Published" {
+		return e.ErrBidNotEditable
+	}
+	return nil
+}
+
+// checkSubmissionOpen
+// Bids can't be created or changed after submission deadline of the tender.
+func checkSubmissionOpen(t *ent.Tender) error {
+	if t.SubmissionDeadline != nil && !time.Now().Before(*t.SubmissionDeadline) {
+		return e.ErrSubmissionClosed
+	}
+	return nil
+}
+
+// checkBudget
+// Priced offer must be in the budget currency. Offer over the budget is rejected
+// or accepted with the flag, depending on the tender policy. When every targeted lot
+// has a budget, the offer is checked against their sum instead of the tender budget.
+func checkBudget(t *ent.Tender, lots []*ent.Lot, offer *ent.BidOffer) (bool, error) {
+	budget := t.Budget
+	if lotsBudget := newLotsBudget(t, lots); lotsBudget != nil {
+		budget = lotsBudget
+	}
+	if budget == nil || offer == nil {
+		return false, nil
+	}
+	if offer.Currency != budget.Currency {
+		return false, e.ErrBidCurrency
+	}
+	if offer.Total.LessThanOrEqual(budget.Amount) {
+		return false, nil
+	}
+	if budget.Policy == mc.BudgetPolicyFlag {
+		return true, nil
+	}
+	return false, e.ErrOverBudget
+}
+
+// newLotsBudget
+// Sums budgets of the targeted lots, they share one currency. Policy is taken from
+// the tender budget, without it offers over the lots budget are rejected.
+func newLotsBudget(t *ent.Tender, lots []*ent.Lot) *ent.TenderBudget {
+	if len(lots) == 0 {
+		return nil
+	}
+	budget := &ent.TenderBudget{
+		Currency: lots[0].BudgetCurrency,
+		Policy:   mc.BudgetPolicyReject,
+	}
+	if t.Budget != nil {
+		budget.Policy = t.Budget.Policy
+	}
+	for _, lot := range lots {
+		if lot.Budget == nil {
+			return nil
+		}
+		budget.Amount = budget.Amount.Add(*lot.Budget)
+	}
+	return budget
+}
+
+// checkBidLots
+// Bid to the tender with lots targets one or more of its lots that aren't awarded yet,
+// bid to the tender without lots targets none. Returns targeted lots.
+func (u *UsecaseLayer) checkBidLots(ctx context.Context, t *ent.Tender, lotIDs []int) ([]*ent.Lot, error) {
+	lots, err := u.repoTender.GetLots(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	if (len(lots) == 0) != (len(lotIDs) == 0) {
+		return nil, e.ErrBidLots
+	}
+	targeted := make([]*ent.Lot, 0, len(lotIDs))
+	for _, lotID := range lotIDs {
+		lot := findLot(lots, lotID)
+		if lot == nil || findLot(targeted, lotID) != nil {
+			return nil, e.ErrBidLots
+		}
+		if lot.WinnerBidID != 0 {
+			return nil, e.ErrLotAwarded
+		}
+		targeted = append(targeted, lot)
+	}
+	return targeted, nil
+}
+
+// getBidLots возвращает лоты тендера, на которые подано предложение
+func (u *UsecaseLayer) getBidLots(ctx context.Context, t *ent.Tender, bidID int) ([]*ent.Lot, error) {
+	bidLots, err := u.repoBids.GetLots(ctx, bidID)
+	if err != nil || len(bidLots) == 0 {
+		return nil, err
+	}
+	lots, err := u.repoTender.GetLots(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	targeted := make([]*ent.Lot, 0, len(bidLots))
+	for _, lot := range lots {
+		if hasBidLot(bidLots, lot.ID) {
+			targeted = append(targeted, lot)
+		}
+	}
+	return targeted, nil
+}
+
+func findLot(lots []*ent.Lot, lotID int) *ent.Lot {
+	for _, lot := range lots {
+		if lot.ID == lotID {
+			return lot
+		}
+	}
+	return nil
+}
+
+func hasBidLot(bidLots []*ent.BidLot, lotID int) bool {
+	for _, lot := range bidLots {
+		if lot.LotID == lotID {
+			return true
+		}
+	}
+	return false
+}
+
+// checkBidAuthor
+// Employee bids either personally or on behalf of an organization they are responsible for,
+// each author can have only one bid to the tender. Returns author type of the bid.
+func (u *UsecaseLayer) checkBidAuthor(ctx context.Context, userID int, t *ent.Tender, organizationID int) (string, error) {
+	if organizationID == 0 {
+		has, err := u.repoBids.UserHasBid(ctx, userID, t.ID)
+		if err != nil {
+			return "", err
+		}
+		if has {
+			return "", e.ErrUserAlreadyHasBid
+		}
+		return "User", nil
+	}
+	// check org existing
+	_, err := u.repoOrganization.Get(ctx, organizationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", e.ErrOrganizationExist
+		}
+		return "", err
+	}
+	isResponsible, err := u.repoOrganization.IsUserResponsible(ctx, userID, organizationID)
+	if err != nil {
+		return "", err
+	}
+	if !isResponsible {
+		return "", e.ErrUserAndOrg
+	}
+	if t.OrganizationID == organizationID {
+		return "", e.ErrBidYourself
+	}
+	has, err := u.repoBids.OrganizationHasBid(ctx, organizationID, t.ID)
+	if err != nil {
+		return "", err
+	}
+	if has {
+		return "", e.ErrOrgAlreadyHasBid
+	}
+	return "Responsible", nil
+}
+
+// checkCommitment
+// Commitment is SHA-256 of the nonce followed by the content exactly as it was sent.
+// Short nonce would let the tender side guess the content by the hash.
+func checkCommitment(commitment, nonce string, content []byte) bool {
+	if utf8.RuneCountInString(nonce) < minRevealNonce || len(content) == 0 {
+		return false
+	}
+	h := sha256.New()
+	h.Write([]byte(nonce))
+	h.Write(content)
+	expected := hex.EncodeToString(h.Sum(nil))
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(commitment)) == 1
+}
+
+// hasBidAccess
+// Author side (creator or responsible of the bid organization) always has access to the bid,
+// tender side has access only when the bid is published.
+func (u *UsecaseLayer) hasBidAccess(ctx context.Context, userID int, bid *ent.Bid) (bool, error) {
+	if bid.CreatorID == userID {
+		return true, nil
+	}
+	isResponsible, err := u.repoOrganization.IsUserResponsible(ctx, userID, bid.OrganizationID)
+	if err != nil {
+		return false, err
+	}
+	if isResponsible {
+		return true, nil
+	}
+	// for tender side
+	t, err := u.repoTender.GetTender(ctx, bid.TenderID)
+	if err != nil {
+		return false, err
+	}
+	isResponsible, err = u.repoOrganization.IsUserResponsible(ctx, userID, t.OrganizationID)
+	if err != nil {
+		return false, err
+	}
+	return isResponsible && bid.Status == "Published", nil
+}
@@ -0,0 +1,40 @@
+package bids
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestCheckCommitment(t *testing.T) {
+	commit := func(nonce, content string) string {
+		sum := sha256.Sum256([]byte(nonce + content))
+		return hex.EncodeToString(sum[:])
+	}
+	nonce := strings.Repeat("n", minRevealNonce)
+	content := `{"name":"Bid","description":"Sealed bid"}`
+	tests := []struct {
+		name       string
+		commitment string
+		nonce      string
+		content    string
+		want       bool
+	}{
+		{name: "matches", commitment: commit(nonce, content), nonce: nonce, content: content, want: true},
+		{name: "multibyte nonce", commitment: commit(strings.Repeat("ж", minRevealNonce), content), nonce: strings.Repeat("ж", minRevealNonce), content: content, want: true},
+		{name: "other content", commitment: commit(nonce, content), nonce: nonce, content: content + " ", want: false},
+		{name: "other nonce", commitment: commit(nonce, content), nonce: nonce + "x", content: content, want: false},
+		{name: "uppercase hash", commitment: strings.ToUpper(commit(nonce, content)), nonce: nonce, content: content, want: false},
+		{name: "short nonce", commitment: commit(nonce[1:], content), nonce: nonce[1:], content: content, want: false},
+		{name: "empty content", commitment: commit(nonce, ""), nonce: nonce, content: "", want: false},
+		{name: "empty commitment", commitment: "", nonce: nonce, content: content, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := checkCommitment(tt.commitment, tt.nonce, []byte(tt.content)); got != tt.want {
+				t.Errorf("checkCommitment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package evaluation
+
+import (
+	"context"
+	ent "tender-workspace/internal/entity"
+	"tender-workspace/internal/entity/dto"
+	bqp "tender-workspace/internal/entity/dto/queries/bids"
+	tqp "tender-workspace/internal/entity/dto/queries/tenders"
+	"tender-workspace/internal/utils/tracing"
+)
+
+// TracingLayer
+// Usecase decorator that wraps every call into a span.
+type TracingLayer struct {
+	next Usecase
+}
+
+var _ Usecase = (*TracingLayer)(nil)
+
+func NewTracingLayer(next Usecase) *TracingLayer {
+	return &TracingLayer{
+		next: next,
+	}
+}
+
+func (t *TracingLayer) ScoreBid(ctx context.Context, input *dto.BidScoresInput, params *bqp.BidScores) ([]*ent.BidScore, error) {
+	ctx, span := tracing.Start(ctx, "usecase.evaluation.ScoreBid")
+	result, err := t.next.ScoreBid(ctx, input, params)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *TracingLayer) GetTenderScores(ctx context.Context, params *tqp.TenderScores) (*ent.ScoreMatrix, error) {
+	ctx, span := tracing.Start(ctx, "usecase.evaluation.GetTenderScores")
+	result, err := t.next.GetTenderScores(ctx, params)
+	tracing.End(span, err)
+	return result, err
+}
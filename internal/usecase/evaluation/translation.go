@@ -0,0 +1,21 @@
+package evaluation
+
+import (
+	ent "tender-workspace/internal/entity"
+	"tender-workspace/internal/entity/dto"
+	"time"
+)
+
+func newBidScores(scores []dto.ScoreInput, bidID, userID int, createdAt time.Time) []*ent.BidScore {
+	res := make([]*ent.BidScore, 0, len(scores))
+	for _, score := range scores {
+		res = append(res, &ent.BidScore{
+			BidID:       bidID,
+			CriterionID: score.CriterionID,
+			UserID:      userID,
+			Score:       score.Score,
+			CreatedAt:   createdAt,
+		})
+	}
+	return res
+}
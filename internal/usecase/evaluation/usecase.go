@@ -0,0 +1,261 @@
+package evaluation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	ent "tender-workspace/internal/entity"
+	"tender-workspace/internal/entity/dto"
+	bqp "tender-workspace/internal/entity/dto/queries/bids"
+	tqp "tender-workspace/internal/entity/dto/queries/tenders"
+	"tender-workspace/internal/repo/bids"
+	"tender-workspace/internal/repo/evaluation"
+	"tender-workspace/internal/repo/organization"
+	"tender-workspace/internal/repo/tender"
+	"tender-workspace/internal/repo/user"
+	mc "tender-workspace/internal/utils/myconstants"
+	e "tender-workspace/internal/utils/myerrors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type Usecase interface {
+	// ScoreBid сохраняет оценки опубликованного предложения от лица ответственного за тендер
+	ScoreBid(ctx context.Context, input *dto.BidScoresInput, params *bqp.BidScores) ([]*ent.BidScore, error)
+	// GetTenderScores возвращает матрицу оценок предложений, упорядоченную по взвешенному итогу
+	GetTenderScores(ctx context.Context, params *tqp.TenderScores) (*ent.ScoreMatrix, error)
+}
+
+var _ Usecase = (*UsecaseLayer)(nil)
+
+type UsecaseLayer struct {
+	repoEvaluation   evaluation.Repo
+	repoBids         bids.Repo
+	repoUser         user.Repo
+	repoOrganization organization.Repo
+	repoTender       tender.Repo
+}
+
+func NewUsecaseLayer(repoEvaluation evaluation.Repo, repoBids bids.Repo, repoUser user.Repo, repoOrganization organization.Repo, repoTender tender.Repo) *UsecaseLayer {
+	return &UsecaseLayer{
+		repoEvaluation:   repoEvaluation,
+		repoBids:         repoBids,
+		repoUser:         repoUser,
+		repoOrganization: repoOrganization,
+		repoTender:       repoTender,
+	}
+}
+
+func (u *UsecaseLayer) ScoreBid(ctx context.Context, input *dto.BidScoresInput, params *bqp.BidScores) ([]*ent.BidScore, error) {
+	// get user id
+	userData, err := u.repoUser.GetData(ctx, params.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrUserExist
+		}
+		return nil, err
+	}
+	// check that bid exists
+	bid, err := u.repoBids.GetBid(ctx, params.BidID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrNoBids
+		}
+		return nil, err
+	}
+	// only responsible employees of the tender organization can score bids
+	t, err := u.repoTender.GetTender(ctx, bid.TenderID)
+	if err != nil {
+		return nil, err
+	}
+	isResponsible, err := u.repoOrganization.IsUserResponsible(ctx, userData.ID, t.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible {
+		return nil, e.ErrResponsibilty
+	}
+	if bid.Status != "Published" || t.Status != "Published" {
+		return nil, e.ErrBidNotPublished
+	}
+	if bid.IsSealed() {
+		return nil, e.ErrBidSealed
+	}
+	criteria, err := u.repoTender.GetCriteria(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(criteria) == 0 {
+		return nil, e.ErrNoCriteria
+	}
+	if err := checkScores(input.Scores, criteria); err != nil {
+		return nil, err
+	}
+	err = u.repoEvaluation.SaveScores(ctx, newBidScores(input.Scores, bid.ID, userData.ID, time.Now()))
+	if err != nil {
+		return nil, err
+	}
+	return u.repoEvaluation.GetUserScores(ctx, bid.ID, userData.ID)
+}
+
+func (u *UsecaseLayer) GetTenderScores(ctx context.Context, params *tqp.TenderScores) (*ent.ScoreMatrix, error) {
+	// check that tender exists
+	t, err := u.repoTender.GetTender(ctx, params.TenderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrNoTenders
+		}
+		return nil, err
+	}
+	// get user id
+	userData, err := u.repoUser.GetData(ctx, params.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrUserExist
+		}
+		return nil, err
+	}
+	// check if user is responsible for the organization
+	isResponsible, err := u.repoOrganization.IsUserResponsible(ctx, userData.ID, t.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible {
+		return nil, e.ErrResponsibilty
+	}
+	criteria, err := u.repoTender.GetCriteria(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(criteria) == 0 {
+		return nil, e.ErrNoCriteria
+	}
+	tenderBids, err := u.repoEvaluation.GetTenderBids(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	scores, err := u.repoEvaluation.GetTenderScores(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	aggregation := params.Aggregation
+	if aggregation == "" {
+		aggregation = t.ScoreAggregation
+	}
+	return newScoreMatrix(t.ID, aggregation, criteria, tenderBids, scores), nil
+}
+
+// checkScores
+// Each criterion can be scored once per request, criteria without
+// scores in the request keep previous scores of the user.
+func checkScores(scores []dto.ScoreInput, criteria []*ent.Criterion) error {
+	if len(scores) == 0 {
+		return e.ErrScores
+	}
+	known := make(map[int]bool, len(criteria))
+	for _, criterion := range criteria {
+		known[criterion.ID] = false
+	}
+	for _, score := range scores {
+		scored, ok := known[score.CriterionID]
+		if !ok || scored || score.Score < 0 || score.Score > mc.MaxScore {
+			return e.ErrScores
+		}
+		known[score.CriterionID] = true
+	}
+	return nil
+}
+
+// newScoreMatrix
+// Scores of every criterion are aggregated across the scorers, total is the mean of
+// aggregated scores weighted by the criteria. Bids scored by every criterion are
+// ranked by total, equal totals share the rank, the rest follow unranked.
+func newScoreMatrix(tenderID int, aggregation string, criteria []*ent.Criterion, tenderBids []*ent.Bid, scores []*ent.BidScore) *ent.ScoreMatrix {
+	byBid := make(map[int]map[int][]int, len(tenderBids))
+	for _, s := range scores {
+		if byBid[s.BidID] == nil {
+			byBid[s.BidID] = make(map[int][]int, len(criteria))
+		}
+		byBid[s.BidID][s.CriterionID] = append(byBid[s.BidID][s.CriterionID], s.Score)
+	}
+	totalWeight := decimal.Zero
+	for _, criterion := range criteria {
+		totalWeight = totalWeight.Add(criterion.Weight)
+	}
+
+	rows := make([]*ent.ScoreMatrixRow, 0, len(tenderBids))
+	for _, bid := range tenderBids {
+		row := &ent.ScoreMatrixRow{
+			BidID:   bid.ID,
+			BidName: bid.Name,
+			Scores:  make([]*ent.CriterionScore, 0, len(criteria)),
+		}
+		weighted, complete := decimal.Zero, true
+		for _, criterion := range criteria {
+			values := byBid[bid.ID][criterion.ID]
+			score := &ent.CriterionScore{CriterionID: criterion.ID, Scorers: len(values)}
+			if len(values) == 0 {
+				complete = false
+			} else {
+				value := aggregate(aggregation, values)
+				weighted = weighted.Add(value.Mul(criterion.Weight))
+				value = value.Round(2)
+				score.Score = &value
+			}
+			row.Scores = append(row.Scores, score)
+		}
+		if complete {
+			total := weighted.Div(totalWeight).Round(2)
+			row.Total = &total
+		}
+		rows = append(rows, row)
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].Total == nil || rows[j].Total == nil {
+			return rows[j].Total == nil && rows[i].Total != nil
+		}
+		return rows[i].Total.GreaterThan(*rows[j].Total)
+	})
+	for i, row := range rows {
+		if row.Total == nil {
+			break
+		}
+		row.Rank = i + 1
+		if i > 0 && rows[i-1].Total.Equal(*row.Total) {
+			row.Rank = rows[i-1].Rank
+		}
+	}
+	return &ent.ScoreMatrix{
+		TenderID:    tenderID,
+		Aggregation: aggregation,
+		Criteria:    criteria,
+		Rows:        rows,
+	}
+}
+
+// aggregate сворачивает оценки экспертов по одному критерию, values не пустой
+func aggregate(aggregation string, values []int) decimal.Decimal {
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+	switch aggregation {
+	case mc.AggregationMedian:
+		mid := len(sorted) / 2
+		if len(sorted)%2 == 1 {
+			return decimal.NewFromInt(int64(sorted[mid]))
+		}
+		return decimal.NewFromInt(int64(sorted[mid-1] + sorted[mid])).Div(decimal.NewFromInt(2))
+	case mc.AggregationTrimmedMean:
+		// при двух оценках и меньше отбрасывать нечего
+		if len(sorted) > 2 {
+			sorted = sorted[1 : len(sorted)-1]
+		}
+	}
+	sum := 0
+	for _, v := range sorted {
+		sum += v
+	}
+	return decimal.NewFromInt(int64(sum)).Div(decimal.NewFromInt(int64(len(sorted))))
+}
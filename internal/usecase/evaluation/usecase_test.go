@@ -0,0 +1,122 @@
+package evaluation
+
+import (
+	ent "tender-workspace/internal/entity"
+	mc "tender-workspace/internal/utils/myconstants"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestAggregate(t *testing.T) {
+	tests := []struct {
+		name        string
+		aggregation string
+		values      []int
+		want        string
+	}{
+		{name: "mean", aggregation: mc.AggregationMean, values: []int{1, 2, 4}, want: "2.3333333333333333"},
+		{name: "mean of one", aggregation: mc.AggregationMean, values: []int{7}, want: "7"},
+		{name: "median odd", aggregation: mc.AggregationMedian, values: []int{9, 1, 5}, want: "5"},
+		{name: "median even", aggregation: mc.AggregationMedian, values: []int{4, 1, 10, 3}, want: "3.5"},
+		{name: "trimmed mean", aggregation: mc.AggregationTrimmedMean, values: []int{10, 1, 5, 6}, want: "5.5"},
+		{name: "trimmed mean of two", aggregation: mc.AggregationTrimmedMean, values: []int{2, 5}, want: "3.5"},
+		{name: "trimmed mean of one", aggregation: mc.AggregationTrimmedMean, values: []int{3}, want: "3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := decimal.RequireFromString(tt.want)
+			if got := aggregate(tt.aggregation, tt.values); !got.Equal(want) {
+				t.Errorf("aggregate() = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestAggregateKeepsValues(t *testing.T) {
+	values := []int{3, 1, 2}
+	aggregate(mc.AggregationMedian, values)
+	if values[0] != 3 || values[1] != 1 || values[2] != 2 {
+		t.Errorf("aggregate() changed values to %v", values)
+	}
+}
+
+func TestNewScoreMatrix(t *testing.T) {
+	criteria := []*ent.Criterion{
+		{ID: 1, Name: "Price", Weight: decimal.NewFromInt(3)},
+		{ID: 2, Name: "Quality", Weight: decimal.NewFromInt(1)},
+	}
+	bids := []*ent.Bid{
+		{ID: 10, Name: "Partial"},
+		{ID: 11, Name: "Second"},
+		{ID: 12, Name: "First"},
+		{ID: 13, Name: "Tie"},
+		{ID: 14, Name: "Unscored"},
+	}
+	scores := []*ent.BidScore{
+		{BidID: 10, CriterionID: 1, UserID: 1, Score: 10},
+		{BidID: 11, CriterionID: 1, UserID: 1, Score: 6},
+		{BidID: 11, CriterionID: 1, UserID: 2, Score: 8},
+		{BidID: 11, CriterionID: 2, UserID: 1, Score: 4},
+		{BidID: 12, CriterionID: 1, UserID: 1, Score: 9},
+		{BidID: 12, CriterionID: 2, UserID: 1, Score: 5},
+		{BidID: 13, CriterionID: 1, UserID: 2, Score: 7},
+		{BidID: 13, CriterionID: 2, UserID: 2, Score: 4},
+	}
+	matrix := newScoreMatrix(1, mc.AggregationMean, criteria, bids, scores)
+
+	want := []struct {
+		bidID   int
+		rank    int
+		total   string // пусто - итога нет
+		scorers []int
+	}{
+		{bidID: 12, rank: 1, total: "8", scorers: []int{1, 1}},
+		{bidID: 11, rank: 2, total: "6.25", scorers: []int{2, 1}},
+		{bidID: 13, rank: 2, total: "6.25", scorers: []int{1, 1}},
+		{bidID: 10, scorers: []int{1, 0}},
+		{bidID: 14, scorers: []int{0, 0}},
+	}
+	if matrix.TenderID != 1 || matrix.Aggregation != mc.AggregationMean {
+		t.Fatalf("matrix header = %d %s", matrix.TenderID, matrix.Aggregation)
+	}
+	if len(matrix.Rows) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(matrix.Rows), len(want))
+	}
+	for i, w := range want {
+		row := matrix.Rows[i]
+		if row.BidID != w.bidID || row.Rank != w.rank {
+			t.Errorf("row %d = bid %d rank %d, want bid %d rank %d", i, row.BidID, row.Rank, w.bidID, w.rank)
+		}
+		switch {
+		case w.total == "" && row.Total != nil:
+			t.Errorf("row %d total = %s, want none", i, row.Total)
+		case w.total != "" && (row.Total == nil || !row.Total.Equal(decimal.RequireFromString(w.total))):
+			t.Errorf("row %d total = %v, want %s", i, row.Total, w.total)
+		}
+		for j, score := range row.Scores {
+			if score.CriterionID != criteria[j].ID || score.Scorers != w.scorers[j] {
+				t.Errorf("row %d score %d = criterion %d scorers %d, want criterion %d scorers %d",
+					i, j, score.CriterionID, score.Scorers, criteria[j].ID, w.scorers[j])
+			}
+			if (score.Score == nil) != (w.scorers[j] == 0) {
+				t.Errorf("row %d score %d = %v with %d scorers", i, j, score.Score, score.Scorers)
+			}
+		}
+	}
+}
+
+func TestNewScoreMatrixRoundsScores(t *testing.T) {
+	criteria := []*ent.Criterion{{ID: 1, Weight: decimal.NewFromInt(1)}}
+	bids := []*ent.Bid{{ID: 1}}
+	scores := []*ent.BidScore{
+		{BidID: 1, CriterionID: 1, UserID: 1, Score: 1},
+		{BidID: 1, CriterionID: 1, UserID: 2, Score: 1},
+		{BidID: 1, CriterionID: 1, UserID: 3, Score: 2},
+	}
+	row := newScoreMatrix(1, mc.AggregationMean, criteria, bids, scores).Rows[0]
+	want := decimal.RequireFromString("1.33")
+	if !row.Scores[0].Score.Equal(want) || !row.Total.Equal(want) {
+		t.Errorf("score %s total %s, want %s", row.Scores[0].Score, row.Total, want)
+	}
+}
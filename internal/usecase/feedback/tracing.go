@@ -0,0 +1,36 @@
+package feedback
+
+import (
+	"context"
+	ent "tender-workspace/internal/entity"
+	bqp "tender-workspace/internal/entity/dto/queries/bids"
+	"tender-workspace/internal/utils/tracing"
+)
+
+// TracingLayer
+// Usecase decorator that wraps every call into a span.
+type TracingLayer struct {
+	next Usecase
+}
+
+var _ Usecase = (*TracingLayer)(nil)
+
+func NewTracingLayer(next Usecase) *TracingLayer {
+	return &TracingLayer{
+		next: next,
+	}
+}
+
+func (t *TracingLayer) SubmitFeedback(ctx context.Context, params *bqp.BidFeedback) (*ent.Bid, error) {
+	ctx, span := tracing.Start(ctx, "usecase.feedback.SubmitFeedback")
+	result, err := t.next.SubmitFeedback(ctx, params)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *TracingLayer) GetReviews(ctx context.Context, params *bqp.BidReviews) ([]*ent.Feedback, error) {
+	ctx, span := tracing.Start(ctx, "usecase.feedback.GetReviews")
+	result, err := t.next.GetReviews(ctx, params)
+	tracing.End(span, err)
+	return result, err
+}
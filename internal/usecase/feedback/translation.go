@@ -0,0 +1,23 @@
+package feedback
+
+import (
+	ent "tender-workspace/internal/entity"
+	bqp "tender-workspace/internal/entity/dto/queries/bids"
+	f "tender-workspace/internal/repo/feedback"
+)
+
+func newFeedback(params *bqp.BidFeedback, user *ent.Employee) *ent.Feedback {
+	return &ent.Feedback{
+		BidID:    params.BidID,
+		AuthorID: user.ID,
+		Text:     params.BidFeedback,
+	}
+}
+
+func newAuthorFeedbackProps(params *bqp.BidReviews, author *ent.Employee) *f.AuthorFeedbackProps {
+	return &f.AuthorFeedbackProps{
+		AuthorID: author.ID,
+		Limit:    params.Limit,
+		Offset:   params.Offset,
+	}
+}
@@ -0,0 +1,129 @@
+package feedback
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	ent "tender-workspace/internal/entity"
+	bqp "tender-workspace/internal/entity/dto/queries/bids"
+	"tender-workspace/internal/repo/bids"
+	"tender-workspace/internal/repo/feedback"
+	"tender-workspace/internal/repo/organization"
+	"tender-workspace/internal/repo/tender"
+	"tender-workspace/internal/repo/user"
+	e "tender-workspace/internal/utils/myerrors"
+)
+
+type Usecase interface {
+	// SubmitFeedback оставляет отзыв на предложение от лица ответственного за тендер
+	SubmitFeedback(ctx context.Context, params *bqp.BidFeedback) (*ent.Bid, error)
+	// GetReviews возвращает прошлые отзывы на предложения автора, который сделал предложение на тендер
+	GetReviews(ctx context.Context, params *bqp.BidReviews) ([]*ent.Feedback, error)
+}
+
+var _ Usecase = (*UsecaseLayer)(nil)
+
+type UsecaseLayer struct {
+	repoFeedback     feedback.Repo
+	repoBids         bids.Repo
+	repoUser         user.Repo
+	repoOrganization organization.Repo
+	repoTender       tender.Repo
+}
+
+func NewUsecaseLayer(repoFeedback feedback.Repo, repoBids bids.Repo, repoUser user.Repo, repoOrganization organization.Repo, repoTender tender.Repo) *UsecaseLayer {
+	return &UsecaseLayer{
+		repoFeedback:     repoFeedback,
+		repoBids:         repoBids,
+		repoUser:         repoUser,
+		repoOrganization: repoOrganization,
+		repoTender:       repoTender,
+	}
+}
+
+func (u *UsecaseLayer) SubmitFeedback(ctx context.Context, params *bqp.BidFeedback) (*ent.Bid, error) {
+	// get user id
+	userData, err := u.repoUser.GetData(ctx, params.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrUserExist
+		}
+		return nil, err
+	}
+	// check that bid exists
+	bid, err := u.repoBids.GetBid(ctx, params.BidID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrNoBids
+		}
+		return nil, err
+	}
+	// only responsible employees of the tender organization can leave feedback
+	t, err := u.repoTender.GetTender(ctx, bid.TenderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrNoTenders
+		}
+		return nil, err
+	}
+	isResponsible, err := u.repoOrganization.IsUserResponsible(ctx, userData.ID, t.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible {
+		return nil, e.ErrResponsibilty
+	}
+	// feedback is left only on bids visible to the tender side
+	if bid.Status != "Published" && bid.Status != "Approved" && bid.Status != "Rejected" {
+		return nil, e.ErrBadPermission
+	}
+	_, err = u.repoFeedback.Create(ctx, newFeedback(params, userData))
+	if err != nil {
+		return nil, err
+	}
+	return bid, nil
+}
+
+func (u *UsecaseLayer) GetReviews(ctx context.Context, params *bqp.BidReviews) ([]*ent.Feedback, error) {
+	// get requester id
+	requester, err := u.repoUser.GetData(ctx, params.RequesterUsername)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrUserExist
+		}
+		return nil, err
+	}
+	// check tender existing
+	t, err := u.repoTender.GetTender(ctx, params.TenderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrTenderExist
+		}
+		return nil, err
+	}
+	// check requester responsibility
+	isResponsible, err := u.repoOrganization.IsUserResponsible(ctx, requester.ID, t.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible {
+		return nil, e.ErrResponsibilty
+	}
+	// get author id
+	author, err := u.repoUser.GetData(ctx, params.AuthorUsername)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrUserNotExist
+		}
+		return nil, err
+	}
+	// reviews are available only for authors who made a bid to the tender
+	has, err := u.repoBids.UserHasBid(ctx, author.ID, params.TenderID)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, e.ErrAuthorHasNoBid
+	}
+	return u.repoFeedback.GetAuthorFeedback(ctx, newAuthorFeedbackProps(params, author))
+}
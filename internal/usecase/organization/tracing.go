@@ -0,0 +1,52 @@
+package organization
+
+import (
+	"context"
+	ent "tender-workspace/internal/entity"
+	"tender-workspace/internal/entity/dto"
+	oqp "tender-workspace/internal/entity/dto/queries/organizations"
+	"tender-workspace/internal/utils/pagination"
+	"tender-workspace/internal/utils/tracing"
+)
+
+// TracingLayer
+// Usecase decorator that wraps every call into a span.
+type TracingLayer struct {
+	next Usecase
+}
+
+var _ Usecase = (*TracingLayer)(nil)
+
+func NewTracingLayer(next Usecase) *TracingLayer {
+	return &TracingLayer{
+		next: next,
+	}
+}
+
+func (t *TracingLayer) GetAll(ctx context.Context, params *oqp.OrganizationList) ([]*ent.Organization, *pagination.Cursors, error) {
+	ctx, span := tracing.Start(ctx, "usecase.organization.GetAll")
+	result, cursors, err := t.next.GetAll(ctx, params)
+	tracing.End(span, err)
+	return result, cursors, err
+}
+
+func (t *TracingLayer) Create(ctx context.Context, initData *dto.OrganizationInput) (*ent.Organization, error) {
+	ctx, span := tracing.Start(ctx, "usecase.organization.Create")
+	result, err := t.next.Create(ctx, initData)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *TracingLayer) Update(ctx context.Context, updateData *dto.OrganizationInput, organizationId int) (*ent.Organization, error) {
+	ctx, span := tracing.Start(ctx, "usecase.organization.Update")
+	result, err := t.next.Update(ctx, updateData, organizationId)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *TracingLayer) MakeResponsible(ctx context.Context, requesterUsername, username string, organizationID int) error {
+	ctx, span := tracing.Start(ctx, "usecase.organization.MakeResponsible")
+	err := t.next.MakeResponsible(ctx, requesterUsername, username, organizationID)
+	tracing.End(span, err)
+	return err
+}
@@ -12,13 +12,15 @@ import (
 	repoUser "tender-workspace/internal/repo/user"
 	mc "tender-workspace/internal/utils/myconstants"
 	e "tender-workspace/internal/utils/myerrors"
+	"tender-workspace/internal/utils/pagination"
 )
 
 type Usecase interface {
-	GetAll(ctx context.Context, params *oqp.OrganizationList) ([]*ent.Organization, error)
+	GetAll(ctx context.Context, params *oqp.OrganizationList) ([]*ent.Organization, *pagination.Cursors, error)
 	Create(ctx context.Context, initData *dto.OrganizationInput) (*ent.Organization, error)
 	Update(ctx context.Context, updateData *dto.OrganizationInput, organizationId int) (*ent.Organization, error)
-	MakeResponsible(ctx context.Context, username string, organizationID int) error
+	// MakeResponsible назначает ответственного, назначать может только уже ответственный за организацию
+	MakeResponsible(ctx context.Context, requesterUsername, username string, organizationID int) error
 }
 
 var _ Usecase = (*UsecaseLayer)(nil)
@@ -35,7 +37,7 @@ func NewUsecaseLayer(repoOrg repoOrg.Repo, repoUser repoUser.Repo) *UsecaseLayer
 	}
 }
 
-func (u *UsecaseLayer) GetAll(ctx context.Context, params *oqp.OrganizationList) ([]*ent.Organization, error) {
+func (u *UsecaseLayer) GetAll(ctx context.Context, params *oqp.OrganizationList) ([]*ent.Organization, *pagination.Cursors, error) {
 	return u.repoOrg.GetAll(ctx, params)
 }
 
@@ -46,11 +48,23 @@ func (u *UsecaseLayer) Create(ctx context.Context, initData *dto.OrganizationInp
 		return nil, e.ErrQPOrgType
 	}
 	initData.Type = typeUpper
+	// get creator id
+	creator, err := u.repoUser.GetData(ctx, initData.CreatorUsername)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrUserExist
+		}
+		return nil, err
+	}
 	dataCreate := newOrganization(initData)
 	org, err := u.repoOrg.Create(ctx, dataCreate)
 	if err != nil {
 		return nil, err
 	}
+	// creator becomes the first responsible, later ones are appointed by responsible employees
+	if err = u.repoOrg.MakeUserResponsible(ctx, creator.ID, org.ID); err != nil {
+		return nil, err
+	}
 	return org, nil
 }
 
@@ -70,7 +84,15 @@ func (u *UsecaseLayer) Update(ctx context.Context, updateData *dto.OrganizationI
 	return org, nil
 }
 
-func (u *UsecaseLayer) MakeResponsible(ctx context.Context, username string, organizationID int) error {
+func (u *UsecaseLayer) MakeResponsible(ctx context.Context, requesterUsername, username string, organizationID int) error {
+	// get requester id
+	requester, err := u.repoUser.GetData(ctx, requesterUsername)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return e.ErrUserExist
+		}
+		return err
+	}
 	// check user existing
 	user, err := u.repoUser.GetData(ctx, username)
 	if err != nil {
@@ -87,6 +109,14 @@ func (u *UsecaseLayer) MakeResponsible(ctx context.Context, username string, org
 		}
 		return err
 	}
+	// only responsible employee appoints other responsible ones
+	isRequesterResponsible, err := u.repoOrg.IsUserResponsible(ctx, requester.ID, organizationID)
+	if err != nil {
+		return err
+	}
+	if !isRequesterResponsible {
+		return e.ErrResponsibilty
+	}
 	// check if user responsible
 	isResponsible, err := u.repoOrg.IsUserResponsible(ctx, user.ID, organizationID)
 	if err != nil {
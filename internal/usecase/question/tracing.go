@@ -0,0 +1,45 @@
+package question
+
+import (
+	"context"
+	ent "tender-workspace/internal/entity"
+	"tender-workspace/internal/entity/dto"
+	tqp "tender-workspace/internal/entity/dto/queries/tenders"
+	"tender-workspace/internal/utils/pagination"
+	"tender-workspace/internal/utils/tracing"
+)
+
+// TracingLayer
+// Usecase decorator that wraps every call into a span.
+type TracingLayer struct {
+	next Usecase
+}
+
+var _ Usecase = (*TracingLayer)(nil)
+
+func NewTracingLayer(next Usecase) *TracingLayer {
+	return &TracingLayer{
+		next: next,
+	}
+}
+
+func (t *TracingLayer) AskQuestion(ctx context.Context, input *dto.QuestionInput, params *tqp.AskQuestion) (*ent.Question, error) {
+	ctx, span := tracing.Start(ctx, "usecase.question.AskQuestion")
+	result, err := t.next.AskQuestion(ctx, input, params)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *TracingLayer) GetQuestions(ctx context.Context, params *tqp.TenderQuestions) ([]*ent.Question, *pagination.Cursors, error) {
+	ctx, span := tracing.Start(ctx, "usecase.question.GetQuestions")
+	result, cursors, err := t.next.GetQuestions(ctx, params)
+	tracing.End(span, err)
+	return result, cursors, err
+}
+
+func (t *TracingLayer) AnswerQuestion(ctx context.Context, input *dto.AnswerInput, params *tqp.AnswerQuestion) (*ent.Question, error) {
+	ctx, span := tracing.Start(ctx, "usecase.question.AnswerQuestion")
+	result, err := t.next.AnswerQuestion(ctx, input, params)
+	tracing.End(span, err)
+	return result, err
+}
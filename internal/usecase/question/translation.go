@@ -0,0 +1,36 @@
+package question
+
+import (
+	ent "tender-workspace/internal/entity"
+	"tender-workspace/internal/entity/dto"
+	"tender-workspace/internal/repo/question"
+	"time"
+)
+
+func newQuestion(t *ent.Tender, user *ent.Employee, input *dto.QuestionInput, createdAt time.Time) *ent.Question {
+	return &ent.Question{
+		TenderID:  t.ID,
+		AuthorID:  user.ID,
+		Anonymous: input.Anonymous,
+		Text:      input.Text,
+		CreatedAt: createdAt,
+	}
+}
+
+func newAnswerProps(q *ent.Question, user *ent.Employee, input *dto.AnswerInput, answeredAt time.Time) *question.AnswerProps {
+	return &question.AnswerProps{
+		QuestionID: q.ID,
+		UserID:     user.ID,
+		Text:       input.Text,
+		Publish:    input.Publish,
+		AnsweredAt: answeredAt,
+	}
+}
+
+func newAmendment(updateData *dto.TenderUpdateDataInput) *ent.UpdateTenderData {
+	return &ent.UpdateTenderData{
+		Name:        updateData.Name,
+		Description: updateData.Description,
+		Type:        updateData.ServiceType,
+	}
+}
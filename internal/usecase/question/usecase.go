@@ -0,0 +1,200 @@
+package question
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	ent "tender-workspace/internal/entity"
+	"tender-workspace/internal/entity/dto"
+	tqp "tender-workspace/internal/entity/dto/queries/tenders"
+	"tender-workspace/internal/repo/organization"
+	"tender-workspace/internal/repo/question"
+	"tender-workspace/internal/repo/tender"
+	"tender-workspace/internal/repo/user"
+	mc "tender-workspace/internal/utils/myconstants"
+	e "tender-workspace/internal/utils/myerrors"
+	"tender-workspace/internal/utils/pagination"
+	"time"
+	"unicode/utf8"
+)
+
+type Usecase interface {
+	// AskQuestion задает вопрос по опубликованному тендеру от лица любого сотрудника
+	AskQuestion(ctx context.Context, input *dto.QuestionInput, params *tqp.AskQuestion) (*ent.Question, error)
+	// GetQuestions возвращает страницу вопросов по тендеру, видимых сотруднику
+	GetQuestions(ctx context.Context, params *tqp.TenderQuestions) ([]*ent.Question, *pagination.Cursors, error)
+	// AnswerQuestion отвечает на вопрос от лица ответственного за тендер и при необходимости изменяет тендер
+	AnswerQuestion(ctx context.Context, input *dto.AnswerInput, params *tqp.AnswerQuestion) (*ent.Question, error)
+}
+
+var _ Usecase = (*UsecaseLayer)(nil)
+
+type UsecaseLayer struct {
+	repoQuestion     question.Repo
+	repoUser         user.Repo
+	repoOrganization organization.Repo
+	repoTender       tender.Repo
+}
+
+func NewUsecaseLayer(repoQuestion question.Repo, repoUser user.Repo, repoOrganization organization.Repo, repoTender tender.Repo) *UsecaseLayer {
+	return &UsecaseLayer{
+		repoQuestion:     repoQuestion,
+		repoUser:         repoUser,
+		repoOrganization: repoOrganization,
+		repoTender:       repoTender,
+	}
+}
+
+func (u *UsecaseLayer) AskQuestion(ctx context.Context, input *dto.QuestionInput, params *tqp.AskQuestion) (*ent.Question, error) {
+	if err := checkText(input.Text); err != nil {
+		return nil, err
+	}
+	// get user id
+	userData, err := u.repoUser.GetData(ctx, params.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrUserExist
+		}
+		return nil, err
+	}
+	// check that tender exists
+	t, err := u.repoTender.GetTender(ctx, params.TenderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrNoTenders
+		}
+		return nil, err
+	}
+	if t.Status != "Published" {
+		return nil, e.ErrQuestionsClosed
+	}
+	return u.repoQuestion.Create(ctx, newQuestion(t, userData, input, time.Now()))
+}
+
+// GetQuestions
+// Tender side sees all questions with their authors. Other employees see questions with
+// published answers and their own questions, authors of anonymous questions are hidden from them.
+func (u *UsecaseLayer) GetQuestions(ctx context.Context, params *tqp.TenderQuestions) ([]*ent.Question, *pagination.Cursors, error) {
+	// get user id
+	userData, err := u.repoUser.GetData(ctx, params.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, e.ErrUserExist
+		}
+		return nil, nil, err
+	}
+	// check that tender exists
+	t, err := u.repoTender.GetTender(ctx, params.TenderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, e.ErrNoTenders
+		}
+		return nil, nil, err
+	}
+	isResponsible, err := u.repoOrganization.IsUserResponsible(ctx, userData.ID, t.OrganizationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	props := &question.TenderQuestionsProps{
+		TenderID: t.ID,
+		Page:     params.Page,
+	}
+	if isResponsible {
+		return u.repoQuestion.GetTenderQuestions(ctx, props)
+	}
+	// неопубликованный тендер виден только ответственным за него
+	if t.Status == "Created" {
+		return nil, nil, e.ErrBadPermission
+	}
+	props.ViewerID = userData.ID
+	questions, cursors, err := u.repoQuestion.GetTenderQuestions(ctx, props)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, q := range questions {
+		if q.Anonymous && q.AuthorID != userData.ID {
+			q.AuthorID = 0
+		}
+	}
+	return questions, cursors, nil
+}
+
+func (u *UsecaseLayer) AnswerQuestion(ctx context.Context, input *dto.AnswerInput, params *tqp.AnswerQuestion) (*ent.Question, error) {
+	if err := checkText(input.Text); err != nil {
+		return nil, err
+	}
+	if input.Tender != nil {
+		serviceType, ok := normalizeServiceType(input.Tender.ServiceType)
+		if !ok {
+			return nil, e.ErrQPServiceType
+		}
+		input.Tender.ServiceType = serviceType
+	}
+	// get user id
+	userData, err := u.repoUser.GetData(ctx, params.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrUserExist
+		}
+		return nil, err
+	}
+	// check that tender exists
+	t, err := u.repoTender.GetTender(ctx, params.TenderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrNoTenders
+		}
+		return nil, err
+	}
+	// check that question is asked on the tender
+	q, err := u.repoQuestion.GetQuestion(ctx, params.QuestionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrNoQuestion
+		}
+		return nil, err
+	}
+	if q.TenderID != t.ID {
+		return nil, e.ErrNoQuestion
+	}
+	// only responsible employees of the tender organization answer questions
+	isResponsible, err := u.repoOrganization.IsUserResponsible(ctx, userData.ID, t.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible {
+		return nil, e.ErrResponsibilty
+	}
+	props := newAnswerProps(q, userData, input, time.Now())
+	if input.Tender != nil {
+		if params.ExpectedVersion != 0 && params.ExpectedVersion != t.Version {
+			return nil, e.ErrPrecondition
+		}
+		props.Amendment = newAmendment(input.Tender)
+		props.TenderProps = &tender.UpdateTenderProps{
+			TenderID: t.ID,
+			UserID:   userData.ID,
+		}
+		props.TenderNewVersion = t.Version + 1
+	}
+	return u.repoQuestion.Answer(ctx, props)
+}
+
+func checkText(text string) error {
+	length := utf8.RuneCountInString(text)
+	if length == 0 || length > mc.MaxQuestionLength {
+		return e.ErrQuestion
+	}
+	return nil
+}
+
+// normalizeServiceType приводит тип услуги к виду, в котором он хранится у тендера
+func normalizeServiceType(serviceType string) (string, bool) {
+	serviceType = strings.ToLower(serviceType)
+	if _, ok := mc.AvaliableServiceType[serviceType]; !ok {
+		return "", false
+	}
+	runes := []rune(serviceType)
+	return strings.ToUpper(string(runes[0])) + string(runes[1:]), true
+}
@@ -0,0 +1,108 @@
+package tender
+
+import (
+	"context"
+	ent "tender-workspace/internal/entity"
+	"tender-workspace/internal/entity/dto"
+	tqp "tender-workspace/internal/entity/dto/queries/tenders"
+	"tender-workspace/internal/utils/pagination"
+	"tender-workspace/internal/utils/tracing"
+)
+
+// TracingLayer
+// Usecase decorator that wraps every call into a span.
+type TracingLayer struct {
+	next Usecase
+}
+
+var _ Usecase = (*TracingLayer)(nil)
+
+func NewTracingLayer(next Usecase) *TracingLayer {
+	return &TracingLayer{
+		next: next,
+	}
+}
+
+func (t *TracingLayer) GetTenders(ctx context.Context, params *tqp.ListTenders) ([]*ent.Tender, *pagination.Cursors, error) {
+	ctx, span := tracing.Start(ctx, "usecase.tender.GetTenders")
+	result, cursors, err := t.next.GetTenders(ctx, params)
+	tracing.End(span, err)
+	return result, cursors, err
+}
+
+func (t *TracingLayer) SearchTenders(ctx context.Context, params *tqp.SearchTenders) ([]*ent.TenderSearchResult, *pagination.Cursors, error) {
+	ctx, span := tracing.Start(ctx, "usecase.tender.SearchTenders")
+	result, cursors, err := t.next.SearchTenders(ctx, params)
+	tracing.End(span, err)
+	return result, cursors, err
+}
+
+func (t *TracingLayer) CreateTender(ctx context.Context, initData *dto.TenderInput) (*ent.Tender, error) {
+	ctx, span := tracing.Start(ctx, "usecase.tender.CreateTender")
+	result, err := t.next.CreateTender(ctx, initData)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *TracingLayer) GetUserTenders(ctx context.Context, params *tqp.ListUserTenders) ([]*ent.Tender, *pagination.Cursors, error) {
+	ctx, span := tracing.Start(ctx, "usecase.tender.GetUserTenders")
+	result, cursors, err := t.next.GetUserTenders(ctx, params)
+	tracing.End(span, err)
+	return result, cursors, err
+}
+
+func (t *TracingLayer) GetTenderStatus(ctx context.Context, params *tqp.TenderStatus) (*ent.Tender, *ent.BudgetUtilization, error) {
+	ctx, span := tracing.Start(ctx, "usecase.tender.GetTenderStatus")
+	result, utilization, err := t.next.GetTenderStatus(ctx, params)
+	tracing.End(span, err)
+	return result, utilization, err
+}
+
+func (t *TracingLayer) UpdateTenderStatus(ctx context.Context, params *tqp.UpdateTenderStatus) (*ent.Tender, error) {
+	ctx, span := tracing.Start(ctx, "usecase.tender.UpdateTenderStatus")
+	result, err := t.next.UpdateTenderStatus(ctx, params)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *TracingLayer) UpdateTender(ctx context.Context, updateData *dto.TenderUpdateDataInput, params *tqp.TenderUpdate) (*ent.Tender, error) {
+	ctx, span := tracing.Start(ctx, "usecase.tender.UpdateTender")
+	result, err := t.next.UpdateTender(ctx, updateData, params)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *TracingLayer) RollbackTender(ctx context.Context, params *tqp.TenderRollback) (*ent.Tender, error) {
+	ctx, span := tracing.Start(ctx, "usecase.tender.RollbackTender")
+	result, err := t.next.RollbackTender(ctx, params)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *TracingLayer) GetTenderVersions(ctx context.Context, params *tqp.TenderVersions) ([]*ent.TenderVersion, error) {
+	ctx, span := tracing.Start(ctx, "usecase.tender.GetTenderVersions")
+	result, err := t.next.GetTenderVersions(ctx, params)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *TracingLayer) GetTenderAward(ctx context.Context, params *tqp.TenderAward) (*ent.TenderAward, error) {
+	ctx, span := tracing.Start(ctx, "usecase.tender.GetTenderAward")
+	result, err := t.next.GetTenderAward(ctx, params)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *TracingLayer) GetTenderLots(ctx context.Context, params *tqp.TenderLots) ([]*ent.Lot, error) {
+	ctx, span := tracing.Start(ctx, "usecase.tender.GetTenderLots")
+	result, err := t.next.GetTenderLots(ctx, params)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *TracingLayer) CloseOverdueTenders(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "usecase.tender.CloseOverdueTenders")
+	result, err := t.next.CloseOverdueTenders(ctx)
+	tracing.End(span, err)
+	return result, err
+}
@@ -5,20 +5,81 @@ import (
 	"tender-workspace/internal/entity/dto"
 	tqp "tender-workspace/internal/entity/dto/queries/tenders"
 	t "tender-workspace/internal/repo/tender"
+	"time"
 )
 
 func newTender(user *ent.Employee, tenderInput *dto.TenderInput) *ent.Tender {
 	return &ent.Tender{
-		Name:           tenderInput.Name,
-		Description:    tenderInput.Description,
-		Type:           tenderInput.Type,
-		Status:         tenderInput.Status,
-		Version:        1,
-		OrganizationID: tenderInput.OrganizationID,
-		CreatorID:      user.ID,
+		Name:               tenderInput.Name,
+		Description:        tenderInput.Description,
+		Type:               tenderInput.Type,
+		Status:             tenderInput.Status,
+		Version:            1,
+		OrganizationID:     tenderInput.OrganizationID,
+		CreatorID:          user.ID,
+		SubmissionDeadline: tenderInput.SubmissionDeadline,
+		DecisionDeadline:   tenderInput.DecisionDeadline,
+		Budget:             newTenderBudget(tenderInput),
+		Criteria:           newCriteria(tenderInput.Criteria),
+		ScoreAggregation:   tenderInput.ScoreAggregation,
+		Sealed:             tenderInput.Sealed,
+		Auction:            newAuction(tenderInput.Auction),
+		Lots:               newLots(tenderInput.Lots),
 	}
 }
 
+func newLots(lots []dto.LotInput) []*ent.Lot {
+	res := make([]*ent.Lot, 0, len(lots))
+	for i, lot := range lots {
+		res = append(res, &ent.Lot{
+			Position:       i + 1,
+			Name:           lot.Name,
+			Description:    lot.Description,
+			Budget:         lot.Budget,
+			BudgetCurrency: lot.BudgetCurrency,
+		})
+	}
+	return res
+}
+
+func newAuction(auction *dto.AuctionInput) *ent.Auction {
+	if auction == nil {
+		return nil
+	}
+	return &ent.Auction{
+		Currency:        auction.Currency,
+		StartPrice:      auction.StartPrice,
+		MinDecrement:    auction.MinDecrement,
+		StartsAt:        auction.StartsAt,
+		EndsAt:          auction.EndsAt,
+		ExtensionWindow: time.Duration(auction.ExtensionWindow) * time.Second,
+		Extension:       time.Duration(auction.Extension) * time.Second,
+	}
+}
+
+func newCriteria(criteria []dto.CriterionInput) []*ent.Criterion {
+	res := make([]*ent.Criterion, 0, len(criteria))
+	for i, criterion := range criteria {
+		res = append(res, &ent.Criterion{
+			Position: i + 1,
+			Name:     criterion.Name,
+			Weight:   criterion.Weight,
+		})
+	}
+	return res
+}
+
+func newTenderBudget(tenderInput *dto.TenderInput) *ent.TenderBudget {
+	if tenderInput.Budget == nil {
+		return nil
+	}
+	return &ent.TenderBudget{
+		Amount:   *tenderInput.Budget,
+		Currency: tenderInput.BudgetCurrency,
+		Hidden:   tenderInput.BudgetHidden,
+		Policy:   tenderInput.BudgetPolicy,
+	}
+}
 
 // func newUserTenderProps(params *tqp.ListUserTenders, user *ent.Employee) *t.UserTendersProps {
 // 	return &t.UserTendersProps{
@@ -42,3 +103,18 @@ func newUpdateTenderProps(updateProps *tqp.TenderUpdate, user *ent.Employee) *t.
 		UserID:   user.ID,
 	}
 }
+
+func newRollbackTenderData(version *ent.TenderVersion) *ent.UpdateTenderData {
+	return &ent.UpdateTenderData{
+		Name:        version.Name,
+		Description: version.Description,
+		Type:        version.Type,
+	}
+}
+
+func newRollbackTenderProps(params *tqp.TenderRollback, user *ent.Employee) *t.UpdateTenderProps {
+	return &t.UpdateTenderProps{
+		TenderID: params.TenderID,
+		UserID:   user.ID,
+	}
+}
@@ -11,8 +11,15 @@ import (
 	"tender-workspace/internal/repo/organization"
 	"tender-workspace/internal/repo/tender"
 	"tender-workspace/internal/repo/user"
+	f "tender-workspace/internal/utils/functions"
+	"tender-workspace/internal/utils/metrics"
 	mc "tender-workspace/internal/utils/myconstants"
 	e "tender-workspace/internal/utils/myerrors"
+	"tender-workspace/internal/utils/pagination"
+	sm "tender-workspace/internal/utils/statemachine"
+	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 type CreateTenderData struct {
@@ -25,19 +32,31 @@ type CreateTenderData struct {
 }
 
 type Usecase interface {
-	GetTenders(ctx context.Context, params *tqp.ListTenders) ([]*ent.Tender, error)
+	GetTenders(ctx context.Context, params *tqp.ListTenders) ([]*ent.Tender, *pagination.Cursors, error)
+	// SearchTenders ищет опубликованные тендеры по тексту и фильтрам
+	SearchTenders(ctx context.Context, params *tqp.SearchTenders) ([]*ent.TenderSearchResult, *pagination.Cursors, error)
 	CreateTender(ctx context.Context, initData *dto.TenderInput) (*ent.Tender, error)
-	GetUserTenders(ctx context.Context, params *tqp.ListUserTenders) ([]*ent.Tender, error)
-	GetTenderStatus(ctx context.Context, params *tqp.TenderStatus) (string, error)
+	GetUserTenders(ctx context.Context, params *tqp.ListUserTenders) ([]*ent.Tender, *pagination.Cursors, error)
+	// GetTenderStatus возвращает тендер и долю бюджета, занятую победителем, если ее можно посчитать
+	GetTenderStatus(ctx context.Context, params *tqp.TenderStatus) (*ent.Tender, *ent.BudgetUtilization, error)
 	UpdateTenderStatus(ctx context.Context, params *tqp.UpdateTenderStatus) (*ent.Tender, error)
 	UpdateTender(ctx context.Context, updateData *dto.TenderUpdateDataInput, params *tqp.TenderUpdate) (*ent.Tender, error)
-
 	// RollbackTender откатывает параметры тендера к указанной версии
-	// RollbackTender(ctx context.Context, tenderID int, version int, username string) (Tender, error)
+	RollbackTender(ctx context.Context, params *tqp.TenderRollback) (*ent.Tender, error)
+	GetTenderVersions(ctx context.Context, params *tqp.TenderVersions) ([]*ent.TenderVersion, error)
+	// GetTenderAward возвращает победившее предложение закрытого тендера
+	GetTenderAward(ctx context.Context, params *tqp.TenderAward) (*ent.TenderAward, error)
+	// GetTenderLots возвращает лоты тендера с их победителями
+	GetTenderLots(ctx context.Context, params *tqp.TenderLots) ([]*ent.Lot, error)
+	// CloseOverdueTenders закрывает опубликованные тендеры с истекшим сроком решения, вызывается планировщиком
+	CloseOverdueTenders(ctx context.Context) (int, error)
 }
 
 var _ Usecase = (*UsecaseLayer)(nil)
 
+// closeOverdueBatch число тендеров, закрываемых в одной транзакции
+const closeOverdueBatch = 100
+
 type UsecaseLayer struct {
 	repoTenders      tender.Repo
 	repoUser         user.Repo
@@ -52,15 +71,26 @@ func NewUsecaseLayer(repoTenders tender.Repo, repoUser user.Repo, repoOrganizati
 	}
 }
 
-func (u *UsecaseLayer) GetTenders(ctx context.Context, params *tqp.ListTenders) ([]*ent.Tender, error) {
-	tenders, err := u.repoTenders.GetAll(ctx, params)
+func (u *UsecaseLayer) GetTenders(ctx context.Context, params *tqp.ListTenders) ([]*ent.Tender, *pagination.Cursors, error) {
+	tenders, cursors, err := u.repoTenders.GetAll(ctx, params)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, nil
-		}
-		return nil, err
+		return nil, nil, err
+	}
+	for _, t := range tenders {
+		hideBudget(t)
 	}
-	return tenders, nil
+	return tenders, cursors, nil
+}
+
+func (u *UsecaseLayer) SearchTenders(ctx context.Context, params *tqp.SearchTenders) ([]*ent.TenderSearchResult, *pagination.Cursors, error) {
+	results, cursors, err := u.repoTenders.Search(ctx, params)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, res := range results {
+		hideBudget(&res.Tender)
+	}
+	return results, cursors, nil
 }
 
 func (u *UsecaseLayer) CreateTender(ctx context.Context, initData *dto.TenderInput) (*ent.Tender, error) {
@@ -102,74 +132,90 @@ func (u *UsecaseLayer) CreateTender(ctx context.Context, initData *dto.TenderInp
 	if !isResponsible {
 		return nil, e.ErrResponsibilty
 	}
+	if err := checkDeadlines(initData, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := checkBudget(initData); err != nil {
+		return nil, err
+	}
+	if err := checkCriteria(initData); err != nil {
+		return nil, err
+	}
+	if err := checkAuction(initData, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := checkLots(initData); err != nil {
+		return nil, err
+	}
 	tenderProps := newTender(userData, initData)
 	t, err := u.repoTenders.Create(ctx, tenderProps)
 	if err != nil {
 		return nil, err
 	}
+	metrics.TenderCreated()
 	return t, nil
 }
 
-func (u *UsecaseLayer) GetUserTenders(ctx context.Context, params *tqp.ListUserTenders) ([]*ent.Tender, error) {
+func (u *UsecaseLayer) GetUserTenders(ctx context.Context, params *tqp.ListUserTenders) ([]*ent.Tender, *pagination.Cursors, error) {
 	// get user id
 	userData, err := u.repoUser.GetData(ctx, params.Username)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, e.ErrUserExist
+			return nil, nil, e.ErrUserExist
 		}
-		return nil, err
+		return nil, nil, err
 	}
 	// get array of organizations ids
 	organizationsIds, err := u.repoUser.GetUserOrganizationsIds(ctx, userData.ID)
 	if err != nil {
-		return nil, e.ErrUserIsNotResponsible
+		return nil, nil, e.ErrUserIsNotResponsible
 	}
-	var userTenders []*ent.Tender
-	for _, organizationId := range organizationsIds {
-		tenders, err := u.repoTenders.GetOrganizationTenders(ctx, organizationId)
-		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				continue
-			}
-			return nil, err
-		}
-		if len(tenders) != 0 {
-			userTenders = append(userTenders, tenders...)
-		}
-	}
-
-	if params.Offset >= len(userTenders) {
-		return nil, nil
+	if len(organizationsIds) == 0 {
+		return nil, &pagination.Cursors{}, nil
 	}
-	return userTenders[params.Offset:min(params.Offset+params.Limit, len(userTenders))], nil
+	return u.repoTenders.GetUserTenders(ctx, &tender.UserTendersProps{
+		OrganizationIDs: organizationsIds,
+		Page:            params.Page,
+	})
 }
 
-func (u *UsecaseLayer) GetTenderStatus(ctx context.Context, params *tqp.TenderStatus) (string, error) {
+func (u *UsecaseLayer) GetTenderStatus(ctx context.Context, params *tqp.TenderStatus) (*ent.Tender, *ent.BudgetUtilization, error) {
 	// check that tender exists
 	tender, err := u.repoTenders.GetTender(ctx, params.TenderID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return "", e.ErrNoTenders
+			return nil, nil, e.ErrNoTenders
 		}
-		return "", err
+		return nil, nil, err
 	}
 	// get user id
 	userData, err := u.repoUser.GetData(ctx, params.Username)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return "", e.ErrUserExist
+			return nil, nil, e.ErrUserExist
 		}
-		return "", err
+		return nil, nil, err
 	}
 	// check if user is responsible for the organization
 	isResponsible, err := u.repoOrganization.IsUserResponsible(ctx, userData.ID, tender.OrganizationID)
 	if err != nil {
-		return "", err
+		return nil, nil, err
 	}
 	if !isResponsible {
-		return "", e.ErrResponsibilty
+		return nil, nil, e.ErrResponsibilty
+	}
+	if tender.Budget == nil || tender.ClosedReason != mc.ClosedAwarded {
+		return tender, nil, nil
+	}
+	// тендер с лотами присуждается по лотам, общего победителя у него нет
+	award, err := u.repoTenders.GetAward(ctx, params.TenderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return tender, nil, nil
+		}
+		return nil, nil, err
 	}
-	return tender.Status, nil
+	return tender, newBudgetUtilization(tender.Budget, award), nil
 }
 
 func (u *UsecaseLayer) UpdateTenderStatus(ctx context.Context, params *tqp.UpdateTenderStatus) (*ent.Tender, error) {
@@ -178,6 +224,9 @@ func (u *UsecaseLayer) UpdateTenderStatus(ctx context.Context, params *tqp.Updat
 	if err != nil {
 		return nil, e.ErrNoTenders
 	}
+	if params.ExpectedVersion != 0 && params.ExpectedVersion != tender.Version {
+		return nil, e.ErrPrecondition
+	}
 	// get user id
 	userData, err := u.repoUser.GetData(ctx, params.Username)
 	if err != nil {
@@ -194,11 +243,17 @@ func (u *UsecaseLayer) UpdateTenderStatus(ctx context.Context, params *tqp.Updat
 	if !isResponsible {
 		return nil, e.ErrResponsibilty
 	}
+	if err := sm.Tender.Check(tender.Status, params.Status, sm.RoleTenderResponsible); err != nil {
+		return nil, err
+	}
 	// update status
 	tender, err = u.repoTenders.ChangeStatus(ctx, params.TenderID, tender.Version+1, params.Status)
 	if err != nil {
 		return nil, err
 	}
+	if tender.Status == "Closed" {
+		metrics.TenderClosed(mc.ClosedManual)
+	}
 	return tender, err
 }
 
@@ -214,6 +269,9 @@ func (u *UsecaseLayer) UpdateTender(ctx context.Context, updateData *dto.TenderU
 	if err != nil {
 		return nil, e.ErrNoTenders
 	}
+	if params.ExpectedVersion != 0 && params.ExpectedVersion != tender.Version {
+		return nil, e.ErrPrecondition
+	}
 	// get user id
 	userData, err := u.repoUser.GetData(ctx, params.Username)
 	if err != nil {
@@ -239,3 +297,371 @@ func (u *UsecaseLayer) UpdateTender(ctx context.Context, updateData *dto.TenderU
 	}
 	return tender, err
 }
+
+func (u *UsecaseLayer) RollbackTender(ctx context.Context, params *tqp.TenderRollback) (*ent.Tender, error) {
+	// check that tender exists
+	tender, err := u.repoTenders.GetTender(ctx, params.TenderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrNoTenders
+		}
+		return nil, err
+	}
+	if params.ExpectedVersion != 0 && params.ExpectedVersion != tender.Version {
+		return nil, e.ErrPrecondition
+	}
+	// get user id
+	userData, err := u.repoUser.GetData(ctx, params.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrUserExist
+		}
+		return nil, err
+	}
+	// check if user is responsible for the organization
+	isResponsible, err := u.repoOrganization.IsUserResponsible(ctx, userData.ID, tender.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible {
+		return nil, e.ErrResponsibilty
+	}
+	// get snapshot of the requested version
+	version, err := u.repoTenders.GetVersion(ctx, params.TenderID, params.Version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrNoTenderVersion
+		}
+		return nil, err
+	}
+	// rollback is considered as a new edit, so version is incremented
+	tenderData := newRollbackTenderData(version)
+	tenderProps := newRollbackTenderProps(params, userData)
+	return u.repoTenders.Update(ctx, tenderData, tenderProps, tender.Version+1)
+}
+
+func (u *UsecaseLayer) GetTenderVersions(ctx context.Context, params *tqp.TenderVersions) ([]*ent.TenderVersion, error) {
+	// check that tender exists
+	tender, err := u.repoTenders.GetTender(ctx, params.TenderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrNoTenders
+		}
+		return nil, err
+	}
+	// get user id
+	userData, err := u.repoUser.GetData(ctx, params.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrUserExist
+		}
+		return nil, err
+	}
+	// check if user is responsible for the organization
+	isResponsible, err := u.repoOrganization.IsUserResponsible(ctx, userData.ID, tender.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible {
+		return nil, e.ErrResponsibilty
+	}
+	return u.repoTenders.GetVersions(ctx, params.TenderID)
+}
+
+func (u *UsecaseLayer) GetTenderAward(ctx context.Context, params *tqp.TenderAward) (*ent.TenderAward, error) {
+	// check that tender exists
+	tender, err := u.repoTenders.GetTender(ctx, params.TenderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrNoTenders
+		}
+		return nil, err
+	}
+	// get user id
+	userData, err := u.repoUser.GetData(ctx, params.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrUserExist
+		}
+		return nil, err
+	}
+	award, err := u.repoTenders.GetAward(ctx, params.TenderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrNoAward
+		}
+		return nil, err
+	}
+	// award is visible to the tender side and to the winner
+	if award.CreatorID == userData.ID {
+		return award, nil
+	}
+	isResponsible, err := u.repoOrganization.IsUserResponsible(ctx, userData.ID, tender.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if isResponsible {
+		return award, nil
+	}
+	if award.ExecutorOrganizationID != 0 {
+		isResponsible, err = u.repoOrganization.IsUserResponsible(ctx, userData.ID, award.ExecutorOrganizationID)
+		if err != nil {
+			return nil, err
+		}
+		if isResponsible {
+			return award, nil
+		}
+	}
+	return nil, e.ErrBadPermission
+}
+
+// GetTenderLots
+// Lots are visible to every employee, as the tender itself, but amount of the hidden
+// budget is shown only to the tender side.
+func (u *UsecaseLayer) GetTenderLots(ctx context.Context, params *tqp.TenderLots) ([]*ent.Lot, error) {
+	// check that tender exists
+	tender, err := u.repoTenders.GetTender(ctx, params.TenderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrNoTenders
+		}
+		return nil, err
+	}
+	// get user id
+	userData, err := u.repoUser.GetData(ctx, params.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrUserExist
+		}
+		return nil, err
+	}
+	lots, err := u.repoTenders.GetLots(ctx, tender.ID)
+	if err != nil {
+		return nil, err
+	}
+	if tender.Budget == nil || !tender.Budget.Hidden {
+		return lots, nil
+	}
+	isResponsible, err := u.repoOrganization.IsUserResponsible(ctx, userData.ID, tender.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible {
+		for _, lot := range lots {
+			lot.Budget = nil
+		}
+	}
+	return lots, nil
+}
+
+func (u *UsecaseLayer) CloseOverdueTenders(ctx context.Context) (int, error) {
+	closed := 0
+	for {
+		tenders, err := u.repoTenders.CloseOverdue(ctx, time.Now(), closeOverdueBatch)
+		if err != nil {
+			return closed, err
+		}
+		for _, t := range tenders {
+			metrics.TenderClosed(t.ClosedReason)
+		}
+		closed += len(tenders)
+		if len(tenders) < closeOverdueBatch {
+			return closed, nil
+		}
+	}
+}
+
+// checkDeadlines
+// Deadlines are optional, but set ones must be in the future, submission deadline
+// needs a decision deadline and bids can't be decided on before they are collected.
+func checkDeadlines(initData *dto.TenderInput, now time.Time) error {
+	submission, decision := initData.SubmissionDeadline, initData.DecisionDeadline
+	if submission != nil && !submission.After(now) {
+		return e.ErrDeadline
+	}
+	if decision != nil && !decision.After(now) {
+		return e.ErrDeadline
+	}
+	if submission != nil && decision != nil && decision.Before(*submission) {
+		return e.ErrDeadline
+	}
+	// закрытые предложения раскрываются после срока подачи и до срока решения
+	if initData.Sealed && (submission == nil || decision == nil) {
+		return e.ErrSealed
+	}
+	// тендеры закрываются автоматически по сроку решения, без него срок подачи тендер бы не закрыл
+	if submission != nil && decision == nil {
+		return e.ErrDeadline
+	}
+	return nil
+}
+
+// checkBudget
+// Budget is optional, but set one must be positive amount in supported currency
+// that fits the column, policy defaults to rejecting bids over the budget.
+func checkBudget(initData *dto.TenderInput) error {
+	if initData.Budget == nil {
+		if initData.BudgetCurrency != "" || initData.BudgetHidden || initData.BudgetPolicy != "" {
+			return e.ErrBudget
+		}
+		return nil
+	}
+	if !initData.Budget.IsPositive() || !f.FitsNumeric(*initData.Budget, 2) {
+		return e.ErrBudget
+	}
+	initData.BudgetCurrency = strings.ToUpper(initData.BudgetCurrency)
+	if _, ok := mc.AvaliableCurrency[initData.BudgetCurrency]; !ok {
+		return e.ErrBudget
+	}
+	switch strings.ToLower(initData.BudgetPolicy) {
+	case "", "reject":
+		initData.BudgetPolicy = mc.BudgetPolicyReject
+	case "flag":
+		initData.BudgetPolicy = mc.BudgetPolicyFlag
+	default:
+		return e.ErrBudget
+	}
+	return nil
+}
+
+// checkCriteria
+// Criteria are optional, names must be unique within the tender,
+// aggregation method defaults to mean.
+func checkCriteria(initData *dto.TenderInput) error {
+	if len(initData.Criteria) > mc.MaxCriteria {
+		return e.ErrCriteria
+	}
+	names := make(map[string]struct{}, len(initData.Criteria))
+	for _, criterion := range initData.Criteria {
+		name := strings.ToLower(strings.TrimSpace(criterion.Name))
+		if _, ok := names[name]; ok {
+			return e.ErrCriteria
+		}
+		names[name] = struct{}{}
+	}
+	if initData.ScoreAggregation == "" {
+		initData.ScoreAggregation = mc.AggregationMean
+		return nil
+	}
+	aggregation, ok := mc.AvaliableAggregation[strings.ToLower(initData.ScoreAggregation)]
+	if !ok {
+		return e.ErrCriteria
+	}
+	initData.ScoreAggregation = aggregation
+	return nil
+}
+
+// checkAuction
+// Auction is optional. Auction tender is closed by the auction itself, so it can't have deadlines
+// or be sealed, and its currency must match the budget one.
+func checkAuction(initData *dto.TenderInput, now time.Time) error {
+	auction := initData.Auction
+	if auction == nil {
+		return nil
+	}
+	if initData.Sealed || initData.SubmissionDeadline != nil || initData.DecisionDeadline != nil {
+		return e.ErrAuction
+	}
+	auction.Currency = strings.ToUpper(auction.Currency)
+	if _, ok := mc.AvaliableCurrency[auction.Currency]; !ok {
+		return e.ErrAuction
+	}
+	if initData.Budget != nil && initData.BudgetCurrency != auction.Currency {
+		return e.ErrAuction
+	}
+	if !auction.StartPrice.IsPositive() || !f.FitsNumeric(auction.StartPrice, 2) {
+		return e.ErrAuction
+	}
+	if !auction.MinDecrement.IsPositive() || !f.FitsNumeric(auction.MinDecrement, 2) || !auction.MinDecrement.LessThan(auction.StartPrice) {
+		return e.ErrAuction
+	}
+	if !auction.StartsAt.After(now) || !auction.EndsAt.After(auction.StartsAt) {
+		return e.ErrAuction
+	}
+	if auction.ExtensionWindow < 0 || auction.ExtensionWindow > mc.MaxAuctionExtension {
+		return e.ErrAuction
+	}
+	if auction.Extension < 0 || auction.Extension > mc.MaxAuctionExtension {
+		return e.ErrAuction
+	}
+	return nil
+}
+
+// checkLots
+// Lots are optional, names must be unique within the tender. Lot budgets share one currency,
+// with the tender budget they must be in its currency and not exceed it in sum.
+func checkLots(initData *dto.TenderInput) error {
+	if len(initData.Lots) == 0 {
+		return nil
+	}
+	if len(initData.Lots) > mc.MaxLots || initData.Auction != nil {
+		return e.ErrLots
+	}
+	currency := ""
+	if initData.Budget != nil {
+		currency = initData.BudgetCurrency
+	}
+	names := make(map[string]struct{}, len(initData.Lots))
+	total := decimal.Zero
+	for i := range initData.Lots {
+		lot := &initData.Lots[i]
+		name := strings.ToLower(strings.TrimSpace(lot.Name))
+		if _, ok := names[name]; ok {
+			return e.ErrLots
+		}
+		names[name] = struct{}{}
+		if lot.Budget == nil {
+			if lot.BudgetCurrency != "" {
+				return e.ErrLots
+			}
+			continue
+		}
+		if !lot.Budget.IsPositive() || !f.FitsNumeric(*lot.Budget, 2) {
+			return e.ErrLots
+		}
+		lot.BudgetCurrency = strings.ToUpper(lot.BudgetCurrency)
+		if lot.BudgetCurrency == "" {
+			lot.BudgetCurrency = currency
+		}
+		if _, ok := mc.AvaliableCurrency[lot.BudgetCurrency]; !ok {
+			return e.ErrLots
+		}
+		if currency == "" {
+			currency = lot.BudgetCurrency
+		}
+		if lot.BudgetCurrency != currency {
+			return e.ErrLots
+		}
+		total = total.Add(*lot.Budget)
+	}
+	if initData.Budget != nil && total.GreaterThan(*initData.Budget) {
+		return e.ErrLots
+	}
+	return nil
+}
+
+// hideBudget убирает сумму скрытого бюджета из тендеров, которые видят участники
+func hideBudget(t *ent.Tender) {
+	if t.Budget != nil && t.Budget.Hidden {
+		t.Budget = &ent.TenderBudget{
+			Currency: t.Budget.Currency,
+			Hidden:   true,
+			Policy:   t.Budget.Policy,
+		}
+	}
+}
+
+// newBudgetUtilization
+// Utilization is known only when the winner has priced offer in the budget currency.
+func newBudgetUtilization(budget *ent.TenderBudget, award *ent.TenderAward) *ent.BudgetUtilization {
+	if award.BidOffer == nil || award.BidOffer.Currency != budget.Currency {
+		return nil
+	}
+	return &ent.BudgetUtilization{
+		BidID:       award.BidID,
+		BidTotal:    award.BidOffer.Total,
+		Budget:      budget.Amount,
+		Currency:    budget.Currency,
+		Utilization: award.BidOffer.Total.Mul(decimal.NewFromInt(100)).Div(budget.Amount).Round(2),
+	}
+}
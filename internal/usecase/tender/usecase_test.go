@@ -0,0 +1,51 @@
+package tender
+
+import (
+	"errors"
+	"tender-workspace/internal/entity/dto"
+	e "tender-workspace/internal/utils/myerrors"
+	"testing"
+	"time"
+)
+
+func TestCheckDeadlines(t *testing.T) {
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+	tests := []struct {
+		name       string
+		submission *time.Time
+		decision   *time.Time
+		sealed     bool
+		want       error
+	}{
+		{name: "no deadlines"},
+		{name: "submission only", submission: at(time.Hour), want: e.ErrDeadline},
+		{name: "decision only", decision: at(time.Hour)},
+		{name: "decision after submission", submission: at(time.Hour), decision: at(2 * time.Hour)},
+		{name: "decision equal to submission", submission: at(time.Hour), decision: at(time.Hour)},
+		{name: "submission now", submission: at(0), want: e.ErrDeadline},
+		{name: "submission in the past", submission: at(-time.Hour), want: e.ErrDeadline},
+		{name: "decision in the past", decision: at(-time.Minute), want: e.ErrDeadline},
+		{name: "decision before submission", submission: at(2 * time.Hour), decision: at(time.Hour), want: e.ErrDeadline},
+		{name: "sealed with both deadlines", submission: at(time.Hour), decision: at(2 * time.Hour), sealed: true},
+		{name: "sealed without deadlines", sealed: true, want: e.ErrSealed},
+		{name: "sealed without decision", submission: at(time.Hour), sealed: true, want: e.ErrSealed},
+		{name: "sealed without submission", decision: at(time.Hour), sealed: true, want: e.ErrSealed},
+		{name: "sealed with past deadline", submission: at(-time.Hour), decision: at(time.Hour), sealed: true, want: e.ErrDeadline},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := &dto.TenderInput{
+				SubmissionDeadline: tt.submission,
+				DecisionDeadline:   tt.decision,
+				Sealed:             tt.sealed,
+			}
+			if err := checkDeadlines(input, now); !errors.Is(err, tt.want) {
+				t.Errorf("checkDeadlines() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
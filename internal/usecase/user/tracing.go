@@ -0,0 +1,50 @@
+package user
+
+import (
+	"context"
+	ent "tender-workspace/internal/entity"
+	"tender-workspace/internal/entity/dto"
+	"tender-workspace/internal/utils/tracing"
+)
+
+// TracingLayer
+// Usecase decorator that wraps every call into a span.
+type TracingLayer struct {
+	next Usecase
+}
+
+var _ Usecase = (*TracingLayer)(nil)
+
+func NewTracingLayer(next Usecase) *TracingLayer {
+	return &TracingLayer{
+		next: next,
+	}
+}
+
+func (t *TracingLayer) GetData(ctx context.Context, username string) (*ent.Employee, error) {
+	ctx, span := tracing.Start(ctx, "usecase.user.GetData")
+	result, err := t.next.GetData(ctx, username)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *TracingLayer) Create(ctx context.Context, initData *dto.UserInput) (*ent.Employee, error) {
+	ctx, span := tracing.Start(ctx, "usecase.user.Create")
+	result, err := t.next.Create(ctx, initData)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *TracingLayer) GetUserOrganizations(ctx context.Context, username string) ([]int, error) {
+	ctx, span := tracing.Start(ctx, "usecase.user.GetUserOrganizations")
+	result, err := t.next.GetUserOrganizations(ctx, username)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *TracingLayer) Login(ctx context.Context, credentials *dto.LoginInput) (*ent.AuthToken, error) {
+	ctx, span := tracing.Start(ctx, "usecase.user.Login")
+	result, err := t.next.Login(ctx, credentials)
+	tracing.End(span, err)
+	return result, err
+}
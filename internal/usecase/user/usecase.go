@@ -8,12 +8,17 @@ import (
 	"tender-workspace/internal/entity/dto"
 	repoUser "tender-workspace/internal/repo/user"
 	e "tender-workspace/internal/utils/myerrors"
+	"tender-workspace/internal/utils/token"
+
+	"golang.org/x/crypto/bcrypt"
 )
 
 type Usecase interface {
 	GetData(ctx context.Context, username string) (*ent.Employee, error)
 	Create(ctx context.Context, initData *dto.UserInput) (*ent.Employee, error)
 	GetUserOrganizations(ctx context.Context, username string) ([]int, error)
+	// Login проверяет пароль сотрудника и выдает ему подписанный токен
+	Login(ctx context.Context, credentials *dto.LoginInput) (*ent.AuthToken, error)
 }
 
 var _ Usecase = (*UsecaseLayer)(nil)
@@ -36,8 +41,12 @@ func (u *UsecaseLayer) Create(ctx context.Context, initData *dto.UserInput) (*en
 	if !errors.Is(err, sql.ErrNoRows) {
 		return nil, err
 	}
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(initData.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
 	data := newUser(initData)
-	uData, err := u.repoUser.Create(ctx, data)
+	uData, err := u.repoUser.Create(ctx, data, string(passwordHash))
 	if err != nil {
 		return nil, err
 	}
@@ -45,14 +54,21 @@ func (u *UsecaseLayer) Create(ctx context.Context, initData *dto.UserInput) (*en
 }
 
 func (u *UsecaseLayer) GetData(ctx context.Context, username string) (*ent.Employee, error) {
-	return u.repoUser.GetData(ctx, username)
+	user, err := u.repoUser.GetData(ctx, username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrUserNotExist
+		}
+		return nil, err
+	}
+	return user, nil
 }
 
 func (u *UsecaseLayer) GetUserOrganizations(ctx context.Context, username string) ([]int, error) {
 	user, err := u.repoUser.GetData(ctx, username)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, e.ErrUserExist
+			return nil, e.ErrUserNotExist
 		}
 		return nil, err
 	}
@@ -62,3 +78,26 @@ func (u *UsecaseLayer) GetUserOrganizations(ctx context.Context, username string
 	}
 	return ids, nil
 }
+
+func (u *UsecaseLayer) Login(ctx context.Context, credentials *dto.LoginInput) (*ent.AuthToken, error) {
+	user, passwordHash, err := u.repoUser.GetCredentials(ctx, credentials.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, e.ErrBadCredentials
+		}
+		return nil, err
+	}
+	// employee without password can't log in
+	if passwordHash == "" {
+		return nil, e.ErrBadCredentials
+	}
+	err = bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(credentials.Password))
+	if err != nil {
+		return nil, e.ErrBadCredentials
+	}
+	signed, expiresAt, err := token.Issue(user.ID, user.Username)
+	if err != nil {
+		return nil, err
+	}
+	return &ent.AuthToken{Token: signed, ExpiresAt: expiresAt}, nil
+}
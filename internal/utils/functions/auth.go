@@ -0,0 +1,13 @@
+package functions
+
+import (
+	"context"
+	mc "tender-workspace/internal/utils/myconstants"
+)
+
+// GetAuthUsername
+// Returns username of the employee authenticated by the bearer token.
+func GetAuthUsername(ctx context.Context) (string, bool) {
+	username, ok := ctx.Value(mc.ContextKey(mc.AuthUsername)).(string)
+	return username, ok && username != ""
+}
@@ -0,0 +1,23 @@
+package functions
+
+import (
+	"net/http"
+	"tender-workspace/internal/utils/pagination"
+)
+
+// SetCursors
+// Passes cursors of the neighbour pages in 'X-Next-Cursor' and 'X-Prev-Cursor' headers,
+// so list bodies stay plain arrays as in the spec.
+// Client requests the page by sending the cursor back in 'cursor' query parameter.
+func SetCursors(w http.ResponseWriter, cursors *pagination.Cursors) {
+	if cursors == nil {
+		return
+	}
+	// заголовки с подчеркиванием nginx по умолчанию отбрасывает
+	if cursors.Next != "" {
+		w.Header().Set("X-Next-Cursor", cursors.Next)
+	}
+	if cursors.Prev != "" {
+		w.Header().Set("X-Prev-Cursor", cursors.Prev)
+	}
+}
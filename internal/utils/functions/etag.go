@@ -0,0 +1,34 @@
+package functions
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	e "tender-workspace/internal/utils/myerrors"
+)
+
+// ETag
+// Entity tag of tender or bid is derived from its version.
+func ETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// GetIfMatchVersion
+// Returns version expected by the client in 'If-Match' header.
+// Zero means that header is absent or equals '*', so any version matches.
+func GetIfMatchVersion(r *http.Request) (int, error) {
+	ifMatch := strings.TrimSpace(r.Header.Get("If-Match"))
+	if ifMatch == "" || ifMatch == "*" {
+		return 0, nil
+	}
+	// weak validator is compared the same way, version is the only thing that changes
+	ifMatch = strings.TrimPrefix(ifMatch, "W/")
+	if len(ifMatch) < 2 || ifMatch[0] != '"' || ifMatch[len(ifMatch)-1] != '"' {
+		return 0, e.ErrIfMatch
+	}
+	version, err := strconv.Atoi(ifMatch[1 : len(ifMatch)-1])
+	if err != nil || version < 1 {
+		return 0, e.ErrIfMatch
+	}
+	return version, nil
+}
@@ -0,0 +1,51 @@
+package functions
+
+import (
+	"errors"
+	"maps"
+	"net/http"
+	ent "tender-workspace/internal/entity"
+	mc "tender-workspace/internal/utils/myconstants"
+	e "tender-workspace/internal/utils/myerrors"
+)
+
+// ResponseError
+// Writes error as application/problem+json. Status, code and key are taken from the domain error
+// found in the chain, unknown errors are hidden behind myerrors.ErrInternal.
+func ResponseError(w http.ResponseWriter, r *http.Request, err error) {
+	problem := NewProblem(r, err)
+	props := NewResponseProps(w, problem, problem.Status, mc.ApplicationProblemJson)
+	Response(props)
+}
+
+func NewProblem(r *http.Request, err error) *ent.Problem {
+	var domainErr *e.Error
+	if !errors.As(err, &domainErr) {
+		domainErr = e.ErrInternal
+	}
+	// сообщение внутренних ошибок не должно уходить клиенту
+	detail := domainErr.Error()
+	if domainErr.Status < http.StatusInternalServerError {
+		detail = err.Error()
+	}
+	details := maps.Clone(domainErr.Details)
+	var detailer e.Detailer
+	if errors.As(err, &detailer) {
+		if details == nil {
+			details = make(map[string]any)
+		}
+		maps.Copy(details, detailer.Details())
+	}
+	requestId, _ := r.Context().Value(mc.ContextKey(mc.RequestID)).(string)
+	return &ent.Problem{
+		Type:      "/errors/" + domainErr.Key,
+		Title:     http.StatusText(domainErr.Status),
+		Status:    domainErr.Status,
+		Detail:    detail,
+		Instance:  r.URL.Path,
+		Code:      domainErr.Code,
+		Key:       domainErr.Key,
+		RequestID: requestId,
+		Details:   details,
+	}
+}
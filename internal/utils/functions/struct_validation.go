@@ -1,10 +1,14 @@
 package functions
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"strconv"
+	mc "tender-workspace/internal/utils/myconstants"
 	"unicode/utf8"
 
 	"github.com/asaskevich/govalidator"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 )
 
@@ -54,5 +58,47 @@ func InitDtoValidator(logger *zap.Logger) {
 		lastNameLen := utf8.RuneCountInString(lastName)
 		return lastNameLen >= 0 && lastNameLen <= 50
 	}
+	// bcrypt uses only first 72 bytes of the password
+	govalidator.TagMap["password"] = func(password string) bool {
+		return utf8.RuneCountInString(password) >= 8 && len(password) <= 72
+	}
+	govalidator.TagMap["unit"] = func(unit string) bool {
+		unitLen := utf8.RuneCountInString(unit)
+		return unitLen > 0 && unitLen <= 20
+	}
+
+	govalidator.TagMap["currency"] = func(currency string) bool {
+		_, ok := mc.AvaliableCurrency[currency]
+		return ok
+	}
+	govalidator.TagMap["commitment"] = func(commitment string) bool {
+		hash, err := hex.DecodeString(commitment)
+		return err == nil && len(hash) == sha256.Size
+	}
+	// decimal amounts must fit columns of bid_items
+	govalidator.CustomTypeTagMap.Set("quantity", func(i any, _ any) bool {
+		quantity, ok := i.(decimal.Decimal)
+		return ok && quantity.IsPositive() && FitsNumeric(quantity, 3)
+	})
+
+	govalidator.CustomTypeTagMap.Set("price", func(i any, _ any) bool {
+		price, ok := i.(decimal.Decimal)
+		return ok && !price.IsNegative() && FitsNumeric(price, 2)
+	})
+
+	govalidator.CustomTypeTagMap.Set("vatRate", func(i any, _ any) bool {
+		rate, ok := i.(decimal.Decimal)
+		return ok && !rate.IsNegative() && rate.LessThanOrEqual(decimal.NewFromInt(100)) && FitsNumeric(rate, 2)
+	})
+
+	govalidator.CustomTypeTagMap.Set("weight", func(i any, _ any) bool {
+		weight, ok := i.(decimal.Decimal)
+		return ok && weight.IsPositive() && weight.LessThanOrEqual(decimal.NewFromInt(100)) && FitsNumeric(weight, 2)
+	})
 	logger.Info("Custom tags created")
 }
+
+// FitsNumeric проверяет, что число помещается в NUMERIC(18, scale) без округления
+func FitsNumeric(d decimal.Decimal, scale int32) bool {
+	return d.Equal(d.Truncate(scale)) && d.Abs().LessThan(decimal.New(1, 18-scale))
+}
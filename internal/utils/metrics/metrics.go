@@ -0,0 +1,128 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"tender-workspace/services/postgres"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "tender"
+
+// Registry
+// Own registry instead of the global one, so only metrics declared here are exported.
+var Registry = prometheus.NewRegistry()
+
+// HTTP
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Number of handled HTTP requests by route template and status.",
+	}, []string{"method", "route", "status"})
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of HTTP requests by route template and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+)
+
+// DATABASE
+var (
+	dbQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "query_duration_seconds",
+		Help:      "Latency of SQL queries by repo method and result.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"repo", "method", "result"})
+)
+
+// DOMAIN
+var (
+	tendersCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tenders_created_total",
+		Help:      "Number of created tenders.",
+	})
+	bidsSubmitted = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "bids_submitted_total",
+		Help:      "Number of submitted bids.",
+	})
+	bidDecisions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "bid_decisions_total",
+		Help:      "Number of decisions made by responsible employees by outcome.",
+	}, []string{"decision"})
+	tendersClosed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tenders_closed_total",
+		Help:      "Number of closed tenders by reason.",
+	}, []string{"reason"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpDuration,
+		dbQueryDuration,
+		tendersCreated,
+		bidsSubmitted,
+		bidDecisions,
+		tendersClosed,
+	)
+}
+
+// Handler отдает метрики в текстовом формате Prometheus
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+func ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	statusLabel := strconv.Itoa(status)
+	httpRequests.WithLabelValues(method, route, statusLabel).Inc()
+	httpDuration.WithLabelValues(method, route, statusLabel).Observe(duration.Seconds())
+}
+
+// ObserveQuery
+// postgres.QueryObserver that times queries by repo method.
+func ObserveQuery(ctx context.Context, query postgres.QueryInfo) (context.Context, func(err error)) {
+	start := time.Now()
+	return ctx, func(err error) {
+		result := "ok"
+		// отсутствие строки - ожидаемый результат, а не ошибка запроса
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			result = "error"
+		}
+		dbQueryDuration.WithLabelValues(query.Repo, query.Method, result).Observe(time.Since(start).Seconds())
+	}
+}
+
+func TenderCreated() {
+	tendersCreated.Inc()
+}
+
+func BidSubmitted() {
+	bidsSubmitted.Inc()
+}
+
+func BidDecisionMade(decision string) {
+	bidDecisions.WithLabelValues(decision).Inc()
+}
+
+func TenderClosed(reason string) {
+	tendersClosed.WithLabelValues(reason).Inc()
+}
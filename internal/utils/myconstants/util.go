@@ -3,12 +3,97 @@ package myconstants
 type ContextKey string
 
 const (
-	ApplicationJson = "application/json"
-	TextPlain       = "text/plain"
+	ApplicationJson        = "application/json"
+	ApplicationProblemJson = "application/problem+json"
+	TextPlain              = "text/plain"
 )
 
 const (
-	RequestID = "request_id"
+	RequestID    = "request_id"
+	AuthUsername = "auth_username"
+	AuthUserID   = "auth_user_id"
+)
+
+// DecisionQuorum максимальное число одобрений, необходимое для принятия предложения
+const DecisionQuorum = 3
+
+// Причины закрытия тендера
+const (
+	ClosedManual             = "Manual"
+	ClosedAwarded            = "Awarded"
+	ClosedSubmissionDeadline = "SubmissionDeadline" // тендеры, закрытые по сроку подачи до перехода на срок решения
+	ClosedDecisionDeadline   = "DecisionDeadline"
+	ClosedAuctionEnded       = "AuctionEnded" // аукцион завершился без допустимых ставок
+)
+
+// Политики для предложений дороже бюджета тендера
+const (
+	BudgetPolicyReject = "Reject"
+	BudgetPolicyFlag   = "Flag"
+)
+
+// Способы свертки оценок экспертов по критерию
+const (
+	AggregationMean        = "Mean"
+	AggregationMedian      = "Median"
+	AggregationTrimmedMean = "TrimmedMean" // без наибольшей и наименьшей оценок
+)
+
+// AvaliableAggregation способы свертки оценок по значению в нижнем регистре
+var AvaliableAggregation = map[string]string{
+	"mean":        AggregationMean,
+	"median":      AggregationMedian,
+	"trimmedmean": AggregationTrimmedMean,
+}
+
+// Ограничения критериев оценки
+const (
+	MaxCriteria = 20
+	MaxScore    = 10
+)
+
+// Статусы предложения в рамках лота
+const (
+	BidLotPending  = "Pending"
+	BidLotAwarded  = "Awarded"
+	BidLotRejected = "Rejected"
+)
+
+// MaxLots наибольшее число лотов тендера
+const MaxLots = 50
+
+// MaxQuestionLength наибольшая длина вопроса и ответа по тендеру в символах
+const MaxQuestionLength = 1000
+
+// Ограничения файлов тендера или предложения
+const (
+	MaxAttachmentSize = 10 << 20 // bytes
+	MaxAttachments    = 20
+	// MaxAttachmentName наибольшая длина имени файла в символах
+	MaxAttachmentName = 255
+)
+
+// AvaliableAttachmentType
+// Allowed file types and the type detected by their content, office documents are zip archives inside.
+var AvaliableAttachmentType = map[string]string{
+	"application/pdf": "application/pdf",
+	"image/png":       "image/png",
+	"image/jpeg":      "image/jpeg",
+	"text/plain":      "text/plain",
+	"text/csv":        "text/plain",
+	"application/zip": "application/zip",
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document": "application/zip",
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":       "application/zip",
+}
+
+// MaxAuctionExtension наибольшее окно и продление торгов обратного аукциона в секундах
+const MaxAuctionExtension = 3600
+
+// Статусы торгов обратного аукциона
+const (
+	AuctionScheduled = "Scheduled"
+	AuctionRunning   = "Running"
+	AuctionEnded     = "Ended"
 )
 
 var AvaliableServiceType = map[string]struct{}{
@@ -37,10 +122,14 @@ var AvaliableOrganizationType = map[string]struct{}{
 	"JSC": {},
 }
 
-var AvaliableBidStatusCreator = map[string]struct{}{
-	"created":   {},
-	"published": {},
-	"canceled":  {},
+// AvaliableCurrency валюты ценовых предложений, ISO 4217
+var AvaliableCurrency = map[string]struct{}{
+	"RUB": {},
+	"USD": {},
+	"EUR": {},
+	"CNY": {},
+	"KZT": {},
+	"BYN": {},
 }
 
 var AvaliableBidStatusApprover = map[string]struct{}{
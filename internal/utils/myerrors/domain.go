@@ -0,0 +1,51 @@
+package myerrors
+
+import "maps"
+
+// Error
+// Domain error that knows how it's represented to the client.
+// The same error always produces the same HTTP status, code and key.
+type Error struct {
+	Code    int    // stable numeric code, grouped by HTTP status
+	Key     string // machine-readable key
+	Status  int    // HTTP status
+	Message string
+	Details map[string]any
+}
+
+func New(code int, key string, status int, message string) *Error {
+	return &Error{
+		Code:    code,
+		Key:     key,
+		Status:  status,
+		Message: message,
+	}
+}
+
+func (err *Error) Error() string {
+	return err.Message
+}
+
+// Is
+// Errors with the same key are equal, so copy with details still matches its sentinel.
+func (err *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Key == err.Key
+}
+
+// WithDetails возвращает копию ошибки с дополнительной информацией для клиента
+func (err *Error) WithDetails(details map[string]any) *Error {
+	withDetails := *err
+	withDetails.Details = maps.Clone(err.Details)
+	if withDetails.Details == nil {
+		withDetails.Details = make(map[string]any, len(details))
+	}
+	maps.Copy(withDetails.Details, details)
+	return &withDetails
+}
+
+// Detailer
+// Implemented by errors that wrap domain error and add details to it, e.g. illegal status transition.
+type Detailer interface {
+	Details() map[string]any
+}
@@ -1,52 +1,128 @@
 package myerrors
 
-import "errors"
+import (
+	"errors"
+	"net/http"
+)
 
 // HTTP
+// Codes: 1xxx - bad request, 2xxx - authentication and permissions, 3xxx - not found,
+// 4xxx - conflicts (40xx), preconditions (41xx) and rejected file uploads (42xx), 5xxx - server side.
 var (
-	ErrQPLimit           = errors.New("parameter 'limit' must be positive number")
-	ErrQPOffset          = errors.New("parameter 'offset' must be positive number")
-	ErrQPChangeStatus    = errors.New("parameter 'status' must be in list(Created, Published, Canceled)")
-	ErrQPDecision        = errors.New("parameter 'decision' must be in list(Approved, Rejected)")
-	ErrQPServiceType     = errors.New("parameter 'service_type' must be in list(Construction, Delivery, Manufacture)")
-	ErrQPBidStatus       = errors.New("parameter 'status' must be in list(Created, Published, Canceled)")
-	ErrQPBidStatusUpdate = errors.New("parameter 'status' must be in list(Created, Published, Canceled, Approved, Rejected)")
-	ErrQPOrgType         = errors.New("parameter 'type' must be in list(IE, LLC, JSC)")
-
-	ErrBadPermission          = errors.New("you doesn't have sufficient rights to obtain the resource")
-	ErrBidYourself            = errors.New("you can't offer your own company a service")
-	ErrSetDeprecatedStatus    = errors.New("you can't set this status to bid")
-	ErrUserAndOrg             = errors.New("you aren't responsible for this organizaton")
-	ErrMethodNotAllowed       = errors.New("method not allowed")
-	ErrInternal               = errors.New("internal server error, please try again later")
-	ErrRequestBody            = errors.New("invalid request body")
-	ErrUserExist              = errors.New("you aren't authorized")
-	ErrUserAlreadyExist       = errors.New("this username is already reserved")
-	ErrUserNotExist           = errors.New("user with this username is not exist")
-	ErrOrganizationExist      = errors.New("organization doesn't exist")
-	ErrTenderExist            = errors.New("tender doesn't exist")
-	ErrUserIsNotResponsible   = errors.New("you aren't responsible for any organizaton")
-	ErrUserAlreadyResponsible = errors.New("you are already responsible for this organizaton")
-	ErrUserAlreadyHasBid      = errors.New("you are already has bid to this tender")
-	ErrOrgAlreadyHasBid       = errors.New("your organization already has bid to this tender")
-
-	ErrBidID           = errors.New("you have specified incorrect parameter 'bidId'")
-	ErrTenderID        = errors.New("you have specified incorrect parameter 'tenderId'")
-	ErrTenderStatus    = errors.New("you have specified incorrect parameter 'status'")
-	ErrNoTenders       = errors.New("there are no tenders specified by your request")
-	ErrNoBids          = errors.New("there are no bids specified by your request")
-	ErrBadStatusCreate = errors.New("you must specify field 'status' with value 'Created'")
-	ErrResponsibilty   = errors.New("you aren't responsible for this organization")
-	ErrBigInterval     = errors.New("offset is bigger than size of selected tenders")
-
-	ErrExistServiceType = errors.New("you must specify parameter 'serviceType'")
-	ErrExistUsername    = errors.New("you must specify parameter 'username'")
-	ErrExistBidID       = errors.New("you must specify parameter 'bidId'")
-	ErrExistDecision    = errors.New("you must specify parameter 'decision'")
-	ErrExistFeedback    = errors.New("you must specify parameter 'feedback'")
-	ErrExistStatus      = errors.New("you must specify parameter 'status'")
-	ErrExistType        = errors.New("you must specify parameter 'type'")
-	ErrExistTenderID    = errors.New("you must specify parameter 'tenderId'")
+	ErrQPLimit           = New(1001, "invalid_limit", http.StatusBadRequest, "parameter 'limit' must be positive number")
+	ErrQPOffset          = New(1002, "invalid_offset", http.StatusBadRequest, "parameter 'offset' must be positive number")
+	ErrQPChangeStatus    = New(1003, "invalid_tender_status", http.StatusBadRequest, "parameter 'status' must be in list(Created, Published, Canceled)")
+	ErrQPDecision        = New(1004, "invalid_decision", http.StatusBadRequest, "parameter 'decision' must be in list(Approved, Rejected)")
+	ErrQPServiceType     = New(1005, "invalid_service_type", http.StatusBadRequest, "parameter 'service_type' must be in list(Construction, Delivery, Manufacture)")
+	ErrQPBidStatus       = New(1006, "invalid_bid_status", http.StatusBadRequest, "parameter 'status' must be in list(Created, Published, Canceled)")
+	ErrQPBidStatusUpdate = New(1007, "invalid_bid_status_update", http.StatusBadRequest, "parameter 'status' must be in list(Created, Published, Canceled, Approved, Rejected)")
+	ErrQPOrgType         = New(1008, "invalid_organization_type", http.StatusBadRequest, "parameter 'type' must be in list(IE, LLC, JSC)")
+	ErrQPDiffVersions    = New(1009, "invalid_diff_versions", http.StatusBadRequest, "parameters 'from' and 'to' must be positive numbers")
+	ErrQPFeedback        = New(1010, "invalid_feedback", http.StatusBadRequest, "parameter 'bidFeedback' must be shorter than 1000 symbols")
+	ErrIfMatch           = New(1011, "invalid_if_match", http.StatusBadRequest, "header 'If-Match' must contain single entity tag of the resource version")
+	ErrRequestBody       = New(1012, "invalid_request_body", http.StatusBadRequest, "invalid request body")
+	ErrBidYourself       = New(1013, "bid_to_own_tender", http.StatusBadRequest, "you can't offer your own company a service")
+	ErrBadStatusCreate   = New(1014, "invalid_initial_status", http.StatusBadRequest, "you must specify field 'status' with value 'Created'")
+	ErrBigInterval       = New(1015, "offset_out_of_range", http.StatusBadRequest, "offset is bigger than size of selected tenders")
+	ErrQPSort            = New(1016, "invalid_sort", http.StatusBadRequest, "parameter 'sort' or 'order' has unsupported value")
+	ErrQPCursor          = New(1017, "invalid_cursor", http.StatusBadRequest, "parameter 'cursor' is malformed or doesn't match 'sort' and 'order'")
+	ErrQPSearchQuery     = New(1018, "invalid_search_query", http.StatusBadRequest, "parameter 'q' must be shorter than 200 symbols")
+	ErrQPSearchLanguage  = New(1019, "invalid_search_language", http.StatusBadRequest, "parameter 'lang' must be in list(ru, en)")
+	ErrQPSearchStatus    = New(1020, "invalid_search_status", http.StatusBadRequest, "parameter 'status' must be in list(Published, Closed)")
+	ErrQPOrganizationID  = New(1021, "invalid_organization_id", http.StatusBadRequest, "parameter 'organization_id' must be positive number")
+	ErrQPDateRange       = New(1022, "invalid_date_range", http.StatusBadRequest, "parameters 'created_from' and 'created_to' must be dates (YYYY-MM-DD or RFC 3339) forming a valid range")
+	ErrDeadline          = New(1023, "invalid_deadline", http.StatusBadRequest, "deadlines must be in the future and 'submissionDeadline' requires 'decisionDeadline' not earlier than it")
+	ErrBidItems          = New(1024, "invalid_bid_items", http.StatusBadRequest, "bid must have at most 100 line items in one currency")
+	ErrBudget            = New(1025, "invalid_budget", http.StatusBadRequest, "'budget' must be positive amount with at most 2 decimal places and 'budgetCurrency', 'budgetPolicy' must be in list(Reject, Flag)")
+	ErrBidCurrency       = New(1026, "bid_currency_mismatch", http.StatusBadRequest, "currency of the bid must match currency of the tender budget")
+	ErrCriteria          = New(1027, "invalid_criteria", http.StatusBadRequest, "tender must have at most 20 criteria with unique names, 'scoreAggregation' must be in list(Mean, Median, TrimmedMean)")
+	ErrScores            = New(1028, "invalid_scores", http.StatusBadRequest, "scores must be integers from 0 to 10 given once for criteria of the tender")
+	ErrQPAggregation     = New(1029, "invalid_aggregation", http.StatusBadRequest, "parameter 'aggregation' must be in list(Mean, Median, TrimmedMean)")
+	ErrSealed            = New(1030, "invalid_sealed_tender", http.StatusBadRequest, "sealed tender must have 'submissionDeadline' and 'decisionDeadline', bids are revealed between them")
+	ErrCommitment        = New(1031, "invalid_commitment", http.StatusBadRequest, "'commitment' must be hex encoded SHA-256 hash")
+	ErrRevealMismatch    = New(1032, "reveal_mismatch", http.StatusBadRequest, "SHA-256 of 'nonce' followed by 'content' doesn't match commitment of the bid, 'nonce' must be at least 16 symbols")
+	ErrAuction           = New(1033, "invalid_auction", http.StatusBadRequest, "auction tender must not be sealed or have deadlines, 'auction' must have positive 'startPrice' and 'minDecrement' below it, 'startsAt' in the future before 'endsAt' and extensions up to 1 hour")
+	ErrAuctionPrice      = New(1034, "invalid_auction_price", http.StatusBadRequest, "'price' must be positive amount with at most 2 decimal places")
+	ErrLots              = New(1035, "invalid_lots", http.StatusBadRequest, "tender must have at most 50 lots with unique names, lot budgets must be positive amounts in one currency matching the tender budget and not exceeding it in sum, auction tender can't have lots")
+	ErrBidLots           = New(1036, "invalid_bid_lots", http.StatusBadRequest, "bid to the tender with lots must target unique lots of the tender in 'lotIds', bid to the tender without lots must not")
+	ErrQPLotID           = New(1037, "invalid_lot_id", http.StatusBadRequest, "parameter 'lot_id' must be positive number")
+	ErrLotDecision       = New(1038, "invalid_lot_decision", http.StatusBadRequest, "decision on the bid to the tender with lots must specify 'lot_id' targeted by the bid, decision on other bids must not")
+	ErrQuestion          = New(1039, "invalid_question", http.StatusBadRequest, "'text' of the question and the answer must be from 1 to 1000 symbols")
+	ErrAttachment        = New(1040, "invalid_attachment", http.StatusBadRequest, "multipart field 'file' must contain single file with name up to 255 symbols")
+
+	ErrBidID        = New(1101, "invalid_bid_id", http.StatusBadRequest, "you have specified incorrect parameter 'bidId'")
+	ErrTenderID     = New(1102, "invalid_tender_id", http.StatusBadRequest, "you have specified incorrect parameter 'tenderId'")
+	ErrTenderStatus = New(1103, "invalid_status", http.StatusBadRequest, "you have specified incorrect parameter 'status'")
+	ErrVersion      = New(1104, "invalid_version", http.StatusBadRequest, "you have specified incorrect parameter 'version'")
+	ErrQuestionID   = New(1105, "invalid_question_id", http.StatusBadRequest, "you have specified incorrect parameter 'questionId'")
+	ErrAttachmentID = New(1106, "invalid_attachment_id", http.StatusBadRequest, "you have specified incorrect parameter 'attachmentId'")
+
+	ErrExistServiceType  = New(1201, "missing_service_type", http.StatusBadRequest, "you must specify parameter 'serviceType'")
+	ErrExistUsername     = New(1202, "missing_username", http.StatusBadRequest, "you must specify parameter 'username'")
+	ErrExistAuthor       = New(1203, "missing_author_username", http.StatusBadRequest, "you must specify parameter 'authorUsername'")
+	ErrExistBidID        = New(1204, "missing_bid_id", http.StatusBadRequest, "you must specify parameter 'bidId'")
+	ErrExistDecision     = New(1205, "missing_decision", http.StatusBadRequest, "you must specify parameter 'decision'")
+	ErrExistFeedback     = New(1206, "missing_feedback", http.StatusBadRequest, "you must specify parameter 'feedback'")
+	ErrExistStatus       = New(1207, "missing_status", http.StatusBadRequest, "you must specify parameter 'status'")
+	ErrExistType         = New(1208, "missing_type", http.StatusBadRequest, "you must specify parameter 'type'")
+	ErrExistTenderID     = New(1209, "missing_tender_id", http.StatusBadRequest, "you must specify parameter 'tenderId'")
+	ErrExistVersion      = New(1210, "missing_version", http.StatusBadRequest, "you must specify parameter 'version'")
+	ErrExistQuestionID   = New(1211, "missing_question_id", http.StatusBadRequest, "you must specify parameter 'questionId'")
+	ErrExistAttachmentID = New(1212, "missing_attachment_id", http.StatusBadRequest, "you must specify parameter 'attachmentId'")
+
+	ErrUnauthorized         = New(2001, "unauthorized", http.StatusUnauthorized, "you must specify bearer token in 'Authorization' header")
+	ErrUserExist            = New(2002, "unknown_user", http.StatusUnauthorized, "you aren't authorized")
+	ErrBadCredentials       = New(2003, "bad_credentials", http.StatusUnauthorized, "invalid username or password")
+	ErrBadToken             = New(2004, "bad_token", http.StatusUnauthorized, "invalid or expired authorization token")
+	ErrBadPermission        = New(2101, "forbidden", http.StatusForbidden, "you doesn't have sufficient rights to obtain the resource")
+	ErrResponsibilty        = New(2102, "not_responsible", http.StatusForbidden, "you aren't responsible for this organization")
+	ErrUserAndOrg           = New(2103, "not_responsible_for_bid_organization", http.StatusForbidden, "you aren't responsible for this organizaton")
+	ErrUserIsNotResponsible = New(2104, "not_responsible_for_any_organization", http.StatusForbidden, "you aren't responsible for any organizaton")
+	ErrTransitionForbidden  = New(2105, "status_transition_forbidden", http.StatusForbidden, "you can't perform this status transition")
+
+	ErrUserNotExist      = New(3001, "user_not_found", http.StatusNotFound, "user with this username is not exist")
+	ErrOrganizationExist = New(3002, "organization_not_found", http.StatusNotFound, "organization doesn't exist")
+	ErrTenderExist       = New(3003, "tender_not_found", http.StatusNotFound, "tender doesn't exist")
+	ErrNoTenders         = New(3004, "no_tenders", http.StatusNotFound, "there are no tenders specified by your request")
+	ErrNoTenderVersion   = New(3005, "tender_version_not_found", http.StatusNotFound, "there is no tender version specified by your request")
+	ErrNoBids            = New(3006, "no_bids", http.StatusNotFound, "there are no bids specified by your request")
+	ErrNoBidVersion      = New(3007, "bid_version_not_found", http.StatusNotFound, "there is no bid version specified by your request")
+	ErrNoAward           = New(3008, "award_not_found", http.StatusNotFound, "tender hasn't been awarded yet")
+	ErrAuthorHasNoBid    = New(3009, "author_bid_not_found", http.StatusNotFound, "author doesn't have bids to this tender")
+	ErrNoBidOffer        = New(3010, "bid_offer_not_found", http.StatusNotFound, "bid doesn't have priced offer")
+	ErrNoCriteria        = New(3011, "criteria_not_found", http.StatusNotFound, "tender doesn't have evaluation criteria")
+	ErrNoAuction         = New(3012, "auction_not_found", http.StatusNotFound, "tender isn't reverse auction")
+	ErrNoLot             = New(3013, "lot_not_found", http.StatusNotFound, "tender doesn't have lot specified by your request")
+	ErrNoQuestion        = New(3014, "question_not_found", http.StatusNotFound, "tender doesn't have question specified by your request")
+	ErrNoAttachment      = New(3015, "attachment_not_found", http.StatusNotFound, "there is no attachment specified by your request")
+	ErrMethodNotAllowed  = New(3101, "method_not_allowed", http.StatusMethodNotAllowed, "method not allowed")
+
+	ErrUserAlreadyExist       = New(4001, "username_reserved", http.StatusConflict, "this username is already reserved")
+	ErrUserAlreadyResponsible = New(4002, "already_responsible", http.StatusConflict, "you are already responsible for this organizaton")
+	ErrUserAlreadyHasBid      = New(4003, "user_bid_exists", http.StatusConflict, "you are already has bid to this tender")
+	ErrOrgAlreadyHasBid       = New(4004, "organization_bid_exists", http.StatusConflict, "your organization already has bid to this tender")
+	ErrIllegalTransition      = New(4005, "illegal_status_transition", http.StatusConflict, "status transition is not allowed")
+	ErrDecisionConflict       = New(4006, "decision_conflict", http.StatusConflict, "tender or bid has been changed by another request, please try again")
+	ErrSubmissionClosed       = New(4007, "submission_closed", http.StatusConflict, "submission deadline of the tender has passed, bids can't be created or changed")
+	ErrOverBudget             = New(4008, "bid_over_budget", http.StatusConflict, "total of the bid exceeds budget of the tender")
+	ErrBidNotPublished        = New(4009, "bid_not_published", http.StatusConflict, "only published bids of published tender can be scored")
+	ErrSealedTender           = New(4010, "sealed_tender", http.StatusConflict, "tender accepts only sealed bids, their content is revealed after submission deadline")
+	ErrNotSealedTender        = New(4011, "tender_not_sealed", http.StatusConflict, "tender isn't sealed, bid must be submitted with its content")
+	ErrBidSealed              = New(4012, "bid_sealed", http.StatusConflict, "content of the sealed bid hasn't been revealed yet")
+	ErrRevealNotOpen          = New(4013, "reveal_not_open", http.StatusConflict, "published bids are revealed after submission deadline while the tender is published")
+	ErrNothingToReveal        = New(4014, "nothing_to_reveal", http.StatusConflict, "bid isn't sealed or has already been revealed")
+	ErrAuctionNotActive       = New(4015, "auction_not_active", http.StatusConflict, "offers are accepted only for published bids while the auction is running")
+	ErrAuctionOutbid          = New(4016, "auction_outbid", http.StatusConflict, "offer must not exceed start price and must be lower than the best offer at least by minimal decrement")
+	ErrAuctionTender          = New(4017, "auction_tender", http.StatusConflict, "reverse auction takes prices only as auction offers and is awarded automatically to the lowest one when it ends")
+	ErrLotAwarded             = New(4018, "lot_awarded", http.StatusConflict, "lot has already been awarded, bids to it aren't accepted")
+	ErrQuestionsClosed        = New(4019, "questions_closed", http.StatusConflict, "questions are accepted only while the tender is published")
+	ErrAttachmentsLimit       = New(4020, "attachments_limit", http.StatusConflict, "tender or bid can have at most 20 attachments")
+	ErrBidNotEditable         = New(4021, "bid_not_editable", http.StatusConflict, "only created or published bids can be changed")
+	ErrPrecondition           = New(4101, "precondition_failed", http.StatusPreconditionFailed, "resource has been modified, its version doesn't match 'If-Match'")
+	ErrAttachmentSize         = New(4201, "attachment_too_large", http.StatusRequestEntityTooLarge, "file must not be empty or larger than 10 MB")
+	ErrAttachmentType         = New(4202, "attachment_type_not_allowed", http.StatusUnsupportedMediaType, "file type must be in list(pdf, png, jpeg, txt, csv, zip, docx, xlsx) and match the file content")
+
+	ErrInternal = New(5001, "internal", http.StatusInternalServerError, "internal server error, please try again later")
 )
 
 // DATABASE
@@ -0,0 +1,221 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	e "tender-workspace/internal/utils/myerrors"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/shopspring/decimal"
+)
+
+// Поля, по которым можно сортировать списки
+const (
+	SortName      = "name"
+	SortCreatedAt = "created_at"
+	SortVersion   = "version"
+	// SortRelevance ранг полнотекстового поиска, по умолчанию сортируется по убыванию
+	SortRelevance = "relevance"
+	// SortTotal итоговая сумма ценового предложения
+	SortTotal = "total"
+)
+
+const defaultLimit = 5
+
+// Page
+// Parameters of the requested page. Paging is done by keyset (sort field, id),
+// offset is applied only to the first page requested without cursor.
+type Page struct {
+	Limit  int
+	Offset int
+	Sort   string
+	Desc   bool
+	Cursor *Cursor
+}
+
+// Cursor
+// Position of the page boundary, clients get it encoded and pass back as is.
+type Cursor struct {
+	Sort     string `json:"s"`
+	Desc     bool   `json:"d,omitempty"`
+	Value    string `json:"v"`
+	ID       int    `json:"id"`
+	Backward bool   `json:"b,omitempty"`
+
+	value any // Value приведенное к типу колонки сортировки
+}
+
+// Key значения полей сортировки одного элемента списка
+type Key struct {
+	ID        int
+	Name      string
+	CreatedAt time.Time
+	Version   int
+	Rank      float32
+	Total     decimal.Decimal
+}
+
+// Cursors
+// Encoded cursors of the neighbour pages, empty if there is no such page.
+type Cursors struct {
+	Next string
+	Prev string
+}
+
+// ParsePage
+// Reads limit, offset, sort, order and cursor query parameters. fields lists which sort fields
+// the endpoint supports, the first one is used by default.
+func ParsePage(queryParams url.Values, fields ...string) (Page, error) {
+	page := Page{Limit: defaultLimit, Sort: fields[0]}
+	if limitStr := queryParams.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 0 {
+			return page, e.ErrQPLimit
+		}
+		page.Limit = limit
+	}
+	if offsetStr := queryParams.Get("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			return page, e.ErrQPOffset
+		}
+		page.Offset = offset
+	}
+	if sort := queryParams.Get("sort"); sort != "" {
+		sort = strings.ToLower(sort)
+		if !slices.Contains(fields, sort) {
+			return page, e.ErrQPSort.WithDetails(map[string]any{"allowed": fields})
+		}
+		page.Sort = sort
+	}
+	switch strings.ToLower(queryParams.Get("order")) {
+	case "":
+		page.Desc = page.Sort == SortRelevance
+	case "asc":
+	case "desc":
+		page.Desc = true
+	default:
+		return page, e.ErrQPSort.WithDetails(map[string]any{"allowed": fields})
+	}
+	if cursorStr := queryParams.Get("cursor"); cursorStr != "" {
+		cursor, err := decode(cursorStr)
+		// курсор действителен только для той сортировки, в которой он был выдан
+		if err != nil || cursor.Sort != page.Sort || cursor.Desc != page.Desc {
+			return page, e.ErrQPCursor
+		}
+		page.Cursor = cursor
+		page.Offset = 0
+	}
+	return page, nil
+}
+
+// Apply
+// Adds keyset condition, order and limit to the select. One extra row is requested
+// to find out whether there is one more page, Cut removes it.
+func (p *Page) Apply(sb *sqlbuilder.SelectBuilder) {
+	desc := p.Desc
+	if p.Cursor != nil {
+		// назад по странице идем в обратном порядке, Cut вернет исходный
+		desc = p.Desc != p.Cursor.Backward
+		op := ">"
+		if desc {
+			op = "<"
+		}
+		sb.Where(fmt.Sprintf("(%s, id) %s (%s, %s)", p.Sort, op, sb.Var(p.Cursor.value), sb.Var(p.Cursor.ID)))
+	}
+	order := "ASC"
+	if desc {
+		order = "DESC"
+	}
+	sb.OrderBy(p.Sort+" "+order, "id "+order)
+	sb.Limit(p.Limit + 1)
+	if p.Offset != 0 {
+		sb.Offset(p.Offset)
+	}
+}
+
+// Cut
+// Trims the extra row requested by Apply, restores order of the backward page
+// and builds cursors of the neighbour pages.
+func Cut[T any](p *Page, items []T, key func(T) Key) ([]T, *Cursors) {
+	hasMore := len(items) > p.Limit
+	if hasMore {
+		items = items[:p.Limit]
+	}
+	backward := p.Cursor != nil && p.Cursor.Backward
+	if backward {
+		slices.Reverse(items)
+	}
+	cursors := &Cursors{}
+	if len(items) == 0 {
+		return items, cursors
+	}
+	// в направлении движения следующая страница есть, только если нашлась лишняя строка,
+	// в обратном - если пришли по курсору или пропустили строки через offset
+	hasNext, hasPrev := hasMore, p.Cursor != nil || p.Offset > 0
+	if backward {
+		hasNext, hasPrev = true, hasMore
+	}
+	if hasNext {
+		cursors.Next = p.encode(key(items[len(items)-1]), false)
+	}
+	if hasPrev {
+		cursors.Prev = p.encode(key(items[0]), true)
+	}
+	return items, cursors
+}
+
+func (p *Page) encode(key Key, backward bool) string {
+	cursor := Cursor{Sort: p.Sort, Desc: p.Desc, ID: key.ID, Backward: backward}
+	switch p.Sort {
+	case SortCreatedAt:
+		cursor.Value = key.CreatedAt.Format(time.RFC3339Nano)
+	case SortVersion:
+		cursor.Value = strconv.Itoa(key.Version)
+	case SortRelevance:
+		cursor.Value = strconv.FormatFloat(float64(key.Rank), 'g', -1, 32)
+	case SortTotal:
+		cursor.Value = key.Total.String()
+	default:
+		cursor.Value = key.Name
+	}
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decode(cursorStr string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursorStr)
+	if err != nil {
+		return nil, err
+	}
+	var cursor Cursor
+	if err = json.Unmarshal(raw, &cursor); err != nil {
+		return nil, err
+	}
+	switch cursor.Sort {
+	case SortCreatedAt:
+		cursor.value, err = time.Parse(time.RFC3339Nano, cursor.Value)
+	case SortVersion:
+		cursor.value, err = strconv.Atoi(cursor.Value)
+	case SortRelevance:
+		var rank float64
+		rank, err = strconv.ParseFloat(cursor.Value, 32)
+		cursor.value = float32(rank)
+	case SortTotal:
+		cursor.value, err = decimal.NewFromString(cursor.Value)
+	case SortName:
+		cursor.value = cursor.Value
+	default:
+		err = e.ErrQPCursor
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
@@ -0,0 +1,113 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/url"
+	e "tender-workspace/internal/utils/myerrors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	createdAt := time.Date(2024, 9, 1, 12, 30, 15, 123456789, time.UTC)
+	key := Key{
+		ID:        42,
+		Name:      "Доставка, этап 2",
+		CreatedAt: createdAt,
+		Version:   7,
+		Rank:      0.0759909,
+		Total:     decimal.RequireFromString("1250000.50"),
+	}
+	tests := []struct {
+		name      string
+		sort      string
+		desc      bool
+		backward  bool
+		wantValue any
+	}{
+		{name: "name", sort: SortName, wantValue: key.Name},
+		{name: "created_at desc", sort: SortCreatedAt, desc: true, wantValue: createdAt},
+		{name: "version backward", sort: SortVersion, backward: true, wantValue: 7},
+		{name: "relevance", sort: SortRelevance, desc: true, wantValue: key.Rank},
+		{name: "total", sort: SortTotal, wantValue: key.Total},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := &Page{Sort: tt.sort, Desc: tt.desc}
+			cursor, err := decode(page.encode(key, tt.backward))
+			if err != nil {
+				t.Fatalf("decode() error = %v", err)
+			}
+			if cursor.Sort != tt.sort || cursor.Desc != tt.desc || cursor.Backward != tt.backward || cursor.ID != key.ID {
+				t.Errorf("decode() = %+v", cursor)
+			}
+			switch want := tt.wantValue.(type) {
+			case time.Time:
+				if got, ok := cursor.value.(time.Time); !ok || !got.Equal(want) {
+					t.Errorf("value = %v, want %v", cursor.value, want)
+				}
+			case decimal.Decimal:
+				if got, ok := cursor.value.(decimal.Decimal); !ok || !got.Equal(want) {
+					t.Errorf("value = %v, want %v", cursor.value, want)
+				}
+			default:
+				if cursor.value != want {
+					t.Errorf("value = %v (%T), want %v (%T)", cursor.value, cursor.value, want, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "not base64", cursor: "не курсор"},
+		{name: "not json", cursor: encode("name:a:1")},
+		{name: "unknown sort", cursor: encode(`{"s":"price","v":"1","id":1}`)},
+		{name: "bad created_at", cursor: encode(`{"s":"created_at","v":"yesterday","id":1}`)},
+		{name: "bad version", cursor: encode(`{"s":"version","v":"1.5","id":1}`)},
+		{name: "bad total", cursor: encode(`{"s":"total","v":"a lot","id":1}`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if cursor, err := decode(tt.cursor); err == nil {
+				t.Errorf("decode() = %+v, want error", cursor)
+			}
+		})
+	}
+}
+
+func TestParsePageCursor(t *testing.T) {
+	page := &Page{Sort: SortCreatedAt, Desc: true}
+	cursor := page.encode(Key{ID: 3, CreatedAt: time.Now()}, false)
+	tests := []struct {
+		name  string
+		query url.Values
+		want  error
+	}{
+		{name: "same sort", query: url.Values{"sort": {"created_at"}, "order": {"desc"}, "cursor": {cursor}}},
+		{name: "other order", query: url.Values{"sort": {"created_at"}, "order": {"asc"}, "cursor": {cursor}}, want: e.ErrQPCursor},
+		{name: "other sort", query: url.Values{"sort": {"name"}, "order": {"desc"}, "cursor": {cursor}}, want: e.ErrQPCursor},
+		{name: "garbage", query: url.Values{"cursor": {"garbage"}}, want: e.ErrQPCursor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := ParsePage(tt.query, SortName, SortCreatedAt)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("ParsePage() error = %v, want %v", err, tt.want)
+			}
+			if tt.want == nil && (parsed.Cursor == nil || parsed.Cursor.ID != 3 || parsed.Offset != 0) {
+				t.Errorf("ParsePage() = %+v", parsed)
+			}
+		})
+	}
+}
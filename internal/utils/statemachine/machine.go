@@ -0,0 +1,90 @@
+package statemachine
+
+import (
+	"fmt"
+	e "tender-workspace/internal/utils/myerrors"
+)
+
+// Role
+// Who initiates the status change.
+type Role string
+
+const (
+	// RoleTenderResponsible ответственный за организацию, создавшую тендер
+	RoleTenderResponsible Role = "tender_responsible"
+	// RoleBidAuthor создатель предложения или ответственный за организацию, от имени которой оно подано
+	RoleBidAuthor Role = "bid_author"
+	// RoleSystem изменения, которые выполняет сам сервис
+	RoleSystem Role = "system"
+)
+
+type Transition struct {
+	From  string
+	To    string
+	Roles []Role
+}
+
+type Machine struct {
+	entity      string
+	transitions map[string]map[string]map[Role]struct{}
+}
+
+func newMachine(entity string, transitions []Transition) *Machine {
+	m := &Machine{
+		entity:      entity,
+		transitions: make(map[string]map[string]map[Role]struct{}),
+	}
+	for _, t := range transitions {
+		if _, ok := m.transitions[t.From]; !ok {
+			m.transitions[t.From] = make(map[string]map[Role]struct{})
+		}
+		roles := make(map[Role]struct{}, len(t.Roles))
+		for _, role := range t.Roles {
+			roles[role] = struct{}{}
+		}
+		m.transitions[t.From][t.To] = roles
+	}
+	return m
+}
+
+// Check
+// Returns nil if any of the roles may move entity from one status to another.
+// Otherwise returns *TransitionError.
+func (m *Machine) Check(from, to string, roles ...Role) error {
+	allowed, ok := m.transitions[from][to]
+	if !ok {
+		return &TransitionError{Entity: m.entity, From: from, To: to, Err: e.ErrIllegalTransition}
+	}
+	for _, role := range roles {
+		if _, ok := allowed[role]; ok {
+			return nil
+		}
+	}
+	return &TransitionError{Entity: m.entity, From: from, To: to, Err: e.ErrTransitionForbidden}
+}
+
+// TransitionError
+// Unwraps to myerrors.ErrIllegalTransition if transition isn't declared at all
+// or to myerrors.ErrTransitionForbidden if it's declared for other roles.
+type TransitionError struct {
+	Entity string
+	From   string
+	To     string
+	Err    error
+}
+
+func (err *TransitionError) Error() string {
+	return fmt.Sprintf("%s: %s can't change status from '%s' to '%s'", err.Err.Error(), err.Entity, err.From, err.To)
+}
+
+func (err *TransitionError) Unwrap() error {
+	return err.Err
+}
+
+func (err *TransitionError) Details() map[string]any {
+	return map[string]any{
+		"entity": err.Entity,
+		"from":   err.From,
+		"to":     err.To,
+	}
+}
@@ -0,0 +1,61 @@
+package statemachine
+
+import (
+	"errors"
+	e "tender-workspace/internal/utils/myerrors"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		machine *Machine
+		from    string
+		to      string
+		roles   []Role
+		want    error
+	}{
+		{name: "tender publish", machine: Tender, from: "Created", to: "Published", roles: []Role{RoleTenderResponsible}},
+		{name: "tender close draft", machine: Tender, from: "Created", to: "Closed", roles: []Role{RoleTenderResponsible}},
+		{name: "tender close by responsible", machine: Tender, from: "Published", to: "Closed", roles: []Role{RoleTenderResponsible}},
+		{name: "tender close by system", machine: Tender, from: "Published", to: "Closed", roles: []Role{RoleSystem}},
+		{name: "tender publish by system", machine: Tender, from: "Created", to: "Published", roles: []Role{RoleSystem}, want: e.ErrTransitionForbidden},
+		{name: "tender reopen", machine: Tender, from: "Closed", to: "Published", roles: []Role{RoleTenderResponsible, RoleSystem}, want: e.ErrIllegalTransition},
+		{name: "tender same status", machine: Tender, from: "Published", to: "Published", roles: []Role{RoleTenderResponsible}, want: e.ErrIllegalTransition},
+		{name: "tender unknown status", machine: Tender, from: "Created", to: "Archived", roles: []Role{RoleTenderResponsible}, want: e.ErrIllegalTransition},
+		{name: "tender no roles", machine: Tender, from: "Created", to: "Published", want: e.ErrTransitionForbidden},
+		{name: "bid publish", machine: Bid, from: "Created", to: "Published", roles: []Role{RoleBidAuthor}},
+		{name: "bid cancel draft", machine: Bid, from: "Created", to: "Canceled", roles: []Role{RoleBidAuthor}},
+		{name: "bid cancel published", machine: Bid, from: "Published", to: "Canceled", roles: []Role{RoleBidAuthor}},
+		{name: "bid approve by system", machine: Bid, from: "Published", to: "Approved", roles: []Role{RoleSystem}},
+		{name: "bid reject by system", machine: Bid, from: "Published", to: "Rejected", roles: []Role{RoleSystem}},
+		{name: "bid approve by author", machine: Bid, from: "Published", to: "Approved", roles: []Role{RoleBidAuthor}, want: e.ErrTransitionForbidden},
+		{name: "bid approve by tender side", machine: Bid, from: "Published", to: "Approved", roles: []Role{RoleTenderResponsible}, want: e.ErrTransitionForbidden},
+		{name: "bid publish by tender side", machine: Bid, from: "Created", to: "Published", roles: []Role{RoleTenderResponsible}, want: e.ErrTransitionForbidden},
+		{name: "bid approve draft", machine: Bid, from: "Created", to: "Approved", roles: []Role{RoleSystem}, want: e.ErrIllegalTransition},
+		{name: "bid restore canceled", machine: Bid, from: "Canceled", to: "Published", roles: []Role{RoleBidAuthor}, want: e.ErrIllegalTransition},
+		{name: "bid reject approved", machine: Bid, from: "Approved", to: "Rejected", roles: []Role{RoleSystem}, want: e.ErrIllegalTransition},
+		{name: "any of roles", machine: Bid, from: "Published", to: "Canceled", roles: []Role{RoleTenderResponsible, RoleBidAuthor}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.machine.Check(tt.from, tt.to, tt.roles...)
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("Check() = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Check() = %v, want %v", err, tt.want)
+			}
+			var transitionErr *TransitionError
+			if !errors.As(err, &transitionErr) {
+				t.Fatalf("Check() = %T, want *TransitionError", err)
+			}
+			if transitionErr.Entity != tt.machine.entity || transitionErr.From != tt.from || transitionErr.To != tt.to {
+				t.Errorf("Details() = %v", transitionErr.Details())
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package statemachine
+
+// Tender
+// Closed tender can't be reopened.
+var Tender = newMachine("tender", []Transition{
+	{From: "Created", To: "Published", Roles: []Role{RoleTenderResponsible}},
+	{From: "Created", To: "Closed", Roles: []Role{RoleTenderResponsible}},
+	{From: "Published", To: "Closed", Roles: []Role{RoleTenderResponsible, RoleSystem}},
+})
+
+// Bid
+// Author side manages draft and publication. Approved and Rejected are set by the service
+// once decisions of the tender side reach quorum. Canceled, Approved and Rejected are final.
+var Bid = newMachine("bid", []Transition{
+	{From: "Created", To: "Published", Roles: []Role{RoleBidAuthor}},
+	{From: "Created", To: "Canceled", Roles: []Role{RoleBidAuthor}},
+	{From: "Published", To: "Canceled", Roles: []Role{RoleBidAuthor}},
+	{From: "Published", To: "Approved", Roles: []Role{RoleSystem}},
+	{From: "Published", To: "Rejected", Roles: []Role{RoleSystem}},
+})
@@ -0,0 +1,74 @@
+package token
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/viper"
+)
+
+var (
+	ErrTokenInvalid = errors.New("token is invalid or expired")
+	ErrWeakSecret   = errors.New("JWT_SECRET must be a random string of at least 32 bytes, e.g. 'openssl rand -hex 32'")
+)
+
+// minSecretLength HS256 ключ не короче выхода хэш-функции
+const minSecretLength = 32
+
+// placeholders части заглушек из примеров конфигурации, с ними токен может подписать кто угодно
+var placeholders = []string{"change-me", "changeme", "change_me", "placeholder", "example"}
+
+// CheckSecret
+// Rejects empty, short and placeholder secrets, the service must not start with them.
+func CheckSecret(secret string) error {
+	if len(secret) < minSecretLength {
+		return ErrWeakSecret
+	}
+	lower := strings.ToLower(secret)
+	for _, placeholder := range placeholders {
+		if strings.Contains(lower, placeholder) {
+			return ErrWeakSecret
+		}
+	}
+	return nil
+}
+
+type Claims struct {
+	UserID int `json:"uid"`
+	jwt.RegisteredClaims
+}
+
+// Issue
+// Returns signed token for the employee and the moment it expires.
+func Issue(userID int, username string) (string, time.Time, error) {
+	timeNow := time.Now()
+	expiresAt := timeNow.Add(viper.GetDuration("JWT_TTL"))
+	claims := Claims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   username,
+			IssuedAt:  jwt.NewNumericDate(timeNow),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(viper.GetString("JWT_SECRET")))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
+}
+
+// Parse
+// Verifies signature and expiration of the token and returns its claims.
+func Parse(tokenStr string) (*Claims, error) {
+	claims := new(Claims)
+	t, err := jwt.ParseWithClaims(tokenStr, claims, func(t *jwt.Token) (any, error) {
+		return []byte(viper.GetString("JWT_SECRET")), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil || !t.Valid || claims.Subject == "" {
+		return nil, ErrTokenInvalid
+	}
+	return claims, nil
+}
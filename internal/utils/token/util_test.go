@@ -0,0 +1,30 @@
+package token
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckSecret(t *testing.T) {
+	tests := []struct {
+		name   string
+		secret string
+		want   error
+	}{
+		{name: "random hex", secret: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"},
+		{name: "exactly minimal length", secret: strings.Repeat("k", minSecretLength)},
+		{name: "empty", secret: "", want: ErrWeakSecret},
+		{name: "short", secret: "s3cr3t", want: ErrWeakSecret},
+		{name: "one byte short", secret: strings.Repeat("k", minSecretLength-1), want: ErrWeakSecret},
+		{name: "old placeholder", secret: "change-me-in-production", want: ErrWeakSecret},
+		{name: "long placeholder", secret: "CHANGE-ME-in-production-please-really-now", want: ErrWeakSecret},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckSecret(tt.secret); !errors.Is(err, tt.want) {
+				t.Errorf("CheckSecret() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	e "tender-workspace/internal/utils/myerrors"
+	"tender-workspace/services/postgres"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	tracerName         = "tender-workspace"
+	defaultServiceName = "tender-workspace"
+)
+
+// Экспортеры, которые можно выбрать через TRACING_EXPORTER
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+var ErrUnknownExporter = errors.New("TRACING_EXPORTER must be one of: none, stdout, otlp")
+
+// Init
+// Configures global tracer provider and W3C trace context propagation.
+// Returned function flushes remaining spans and must be called before exit.
+func Init(logger *zap.Logger) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	exporterName := viper.GetString("TRACING_EXPORTER")
+	if exporterName == "" {
+		exporterName = ExporterNone
+	}
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch exporterName {
+	case ExporterNone:
+		// без провайдера otel использует noop tracer, но traceparent все равно пробрасывается
+		logger.Info("tracing is disabled")
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case ExporterOTLP:
+		opts := []otlptracehttp.Option{}
+		if endpoint := viper.GetString("TRACING_OTLP_ENDPOINT"); endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(endpoint))
+		}
+		if viper.GetBool("TRACING_OTLP_INSECURE") {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exporter, err = otlptracehttp.New(context.Background(), opts...)
+	default:
+		return nil, ErrUnknownExporter
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s exporter: %w", exporterName, err)
+	}
+
+	serviceName := viper.GetString("TRACING_SERVICE_NAME")
+	if serviceName == "" {
+		serviceName = defaultServiceName
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, err
+	}
+	sampleRatio := 1.0
+	if viper.IsSet("TRACING_SAMPLE_RATIO") {
+		sampleRatio = viper.GetFloat64("TRACING_SAMPLE_RATIO")
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	logger.Info("tracing is enabled", zap.String("exporter", exporterName), zap.Float64("sample-ratio", sampleRatio))
+	return provider.Shutdown, nil
+}
+
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// Start начинает дочерний спан внутри сервиса
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, opts...)
+}
+
+// End
+// Records error if any and ends the span. Domain errors caused by the client
+// don't mark span as failed, only their key is saved.
+func End(span trace.Span, err error) {
+	var domainErr *e.Error
+	switch {
+	case err == nil:
+	case errors.As(err, &domainErr) && domainErr.Status < http.StatusInternalServerError:
+		span.SetAttributes(attribute.String("error.key", domainErr.Key))
+	default:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Extract достает родительский контекст из заголовка traceparent входящего запроса
+func Extract(ctx context.Context, header http.Header) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// TraceQuery
+// postgres.QueryObserver that creates span per SQL query named after repo method.
+func TraceQuery(ctx context.Context, query postgres.QueryInfo) (context.Context, func(err error)) {
+	ctx, span := Start(ctx, "repo."+query.Repo+"."+query.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBQueryText(query.SQL),
+			semconv.CodeNamespace(query.Repo),
+			semconv.CodeFunction(query.Method),
+		),
+	)
+	return ctx, func(err error) {
+		// отсутствие строки - ожидаемый результат, а не ошибка запроса
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = nil
+		}
+		End(span, err)
+	}
+}
@@ -0,0 +1,90 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Local
+// Storage in the directory of the local filesystem, key is a relative path of the file.
+type Local struct {
+	dir string
+}
+
+var _ Storage = (*Local)(nil)
+
+func NewLocal(dir string) (*Local, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create blob directory: %w", err)
+	}
+	return &Local{dir: dir}, nil
+}
+
+// Put
+// Writes content into temporary file and renames it, so readers never see a partial file.
+func (l *Local) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	// временный файл удаляется, если до переименования что-то пошло не так
+	defer os.Remove(tmp.Name())
+	written, err := io.Copy(tmp, content)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	if written != size {
+		tmp.Close()
+		return fmt.Errorf("blob size mismatch: expected %d, written %d", size, written)
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (l *Local) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return file, err
+}
+
+func (l *Local) Delete(ctx context.Context, key string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// path не дает ключу выйти за пределы каталога хранилища
+func (l *Local) path(key string) (string, error) {
+	if !fs.ValidPath(key) || strings.Contains(key, "\\") {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(key)), nil
+}
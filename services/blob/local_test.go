@@ -0,0 +1,124 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalPutGetDelete(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	storage, err := NewLocal(filepath.Join(dir, "attachments"))
+	if err != nil {
+		t.Fatalf("NewLocal() error = %v", err)
+	}
+	key := "tenders/1/3f2a.pdf"
+	content := []byte("%PDF-1.7 content")
+	if err := storage.Put(ctx, key, bytes.NewReader(content), int64(len(content)), "application/pdf"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	reader, err := storage.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	got, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil || !bytes.Equal(got, content) {
+		t.Fatalf("Get() = %q, %v, want %q", got, err, content)
+	}
+	// повторная запись заменяет файл целиком
+	replaced := []byte("new")
+	if err := storage.Put(ctx, key, bytes.NewReader(replaced), int64(len(replaced)), "application/pdf"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	reader, err = storage.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	got, _ = io.ReadAll(reader)
+	reader.Close()
+	if !bytes.Equal(got, replaced) {
+		t.Fatalf("Get() after replace = %q, want %q", got, replaced)
+	}
+
+	if err := storage.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := storage.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get() after Delete() error = %v, want ErrNotFound", err)
+	}
+	// удаление отсутствующего файла не ошибка
+	if err := storage.Delete(ctx, key); err != nil {
+		t.Fatalf("second Delete() error = %v", err)
+	}
+}
+
+func TestLocalPutSizeMismatch(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	storage, err := NewLocal(dir)
+	if err != nil {
+		t.Fatalf("NewLocal() error = %v", err)
+	}
+	if err := storage.Put(ctx, "bids/1/a.txt", strings.NewReader("short"), 100, "text/plain"); err == nil {
+		t.Fatal("Put() with wrong size error = nil")
+	}
+	if _, err := storage.Get(ctx, "bids/1/a.txt"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() of failed upload error = %v, want ErrNotFound", err)
+	}
+	// временные файлы неудачной загрузки не остаются
+	entries, err := os.ReadDir(filepath.Join(dir, "bids", "1"))
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("upload left %d files", len(entries))
+	}
+}
+
+func TestLocalPathTraversal(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	storage, err := NewLocal(filepath.Join(root, "attachments"))
+	if err != nil {
+		t.Fatalf("NewLocal() error = %v", err)
+	}
+	secret := filepath.Join(root, "secret.txt")
+	if err := os.WriteFile(secret, []byte("secret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	keys := []string{
+		"../secret.txt",
+		"tenders/../../secret.txt",
+		"/etc/passwd",
+		"..",
+		"",
+		"tenders/./1",
+		"tenders//1",
+		`..\secret.txt`,
+		`tenders\..\..\secret.txt`,
+	}
+	for _, key := range keys {
+		t.Run(key, func(t *testing.T) {
+			if err := storage.Put(ctx, key, strings.NewReader("x"), 1, "text/plain"); err == nil {
+				t.Error("Put() error = nil")
+			}
+			if reader, err := storage.Get(ctx, key); err == nil {
+				reader.Close()
+				t.Error("Get() error = nil")
+			}
+			if err := storage.Delete(ctx, key); err == nil {
+				t.Error("Delete() error = nil")
+			}
+		})
+	}
+	if content, err := os.ReadFile(secret); err != nil || string(content) != "secret" {
+		t.Errorf("file outside the storage = %q, %v", content, err)
+	}
+}
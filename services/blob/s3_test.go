@@ -0,0 +1,190 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3 хранит объекты в памяти и проверяет то, что шлет клиент: path-style адрес и подпись
+type fakeS3 struct {
+	t       *testing.T
+	bucket  string
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+}
+
+var authorizationRe = regexp.MustCompile(`^AWS4-HMAC-SHA256 Credential=test-access/\d{8}/eu-central-1/s3/aws4_request, ` +
+	`SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=[0-9a-f]{64}$`)
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !authorizationRe.MatchString(r.Header.Get("Authorization")) {
+		f.t.Errorf("%s %s: Authorization = %q", r.Method, r.URL.Path, r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	if _, err := time.Parse("20060102T150405Z", r.Header.Get("X-Amz-Date")); err != nil {
+		f.t.Errorf("X-Amz-Date = %q", r.Header.Get("X-Amz-Date"))
+	}
+	key, ok := strings.CutPrefix(r.URL.Path, "/"+f.bucket+"/")
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		if r.Header.Get("X-Amz-Content-Sha256") != unsignedPayload {
+			f.t.Errorf("PUT X-Amz-Content-Sha256 = %q", r.Header.Get("X-Amz-Content-Sha256"))
+		}
+		content, _ := io.ReadAll(r.Body)
+		if int64(len(content)) != r.ContentLength {
+			f.t.Errorf("PUT Content-Length = %d, body %d", r.ContentLength, len(content))
+		}
+		f.objects[key] = content
+		f.types[key] = r.Header.Get("Content-Type")
+	case http.MethodGet:
+		content, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(content)
+	case http.MethodDelete:
+		if _, ok := f.objects[key]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func newTestS3(t *testing.T, handler http.Handler) *S3 {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	storage, err := NewS3(&S3Config{
+		Endpoint:  server.URL,
+		Region:    "eu-central-1",
+		Bucket:    "attachments",
+		AccessKey: "test-access",
+		SecretKey: "test-secret",
+	})
+	if err != nil {
+		t.Fatalf("NewS3() error = %v", err)
+	}
+	return storage
+}
+
+func TestS3PutGetDelete(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeS3{t: t, bucket: "attachments", objects: map[string][]byte{}, types: map[string]string{}}
+	storage := newTestS3(t, fake)
+	key := "bids/7/5b1c.png"
+	content := []byte("\x89PNG\r\n\x1a\n image")
+	if err := storage.Put(ctx, key, bytes.NewReader(content), int64(len(content)), "image/png"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if !bytes.Equal(fake.objects[key], content) || fake.types[key] != "image/png" {
+		t.Fatalf("stored object = %q %q", fake.objects[key], fake.types[key])
+	}
+	reader, err := storage.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	got, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil || !bytes.Equal(got, content) {
+		t.Fatalf("Get() = %q, %v, want %q", got, err, content)
+	}
+	if err := storage.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := storage.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get() after Delete() error = %v, want ErrNotFound", err)
+	}
+	// отсутствующий объект удалять не нужно
+	if err := storage.Delete(ctx, key); err != nil {
+		t.Fatalf("second Delete() error = %v", err)
+	}
+}
+
+func TestS3ErrorStatus(t *testing.T) {
+	storage := newTestS3(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("<Error><Code>AccessDenied</Code></Error>"))
+	}))
+	err := storage.Put(context.Background(), "tenders/1/a.txt", strings.NewReader("a"), 1, "text/plain")
+	if err == nil || errors.Is(err, ErrNotFound) || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Errorf("Put() error = %v", err)
+	}
+	if _, err := storage.Get(context.Background(), "tenders/1/a.txt"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() error = %v", err)
+	}
+}
+
+func TestNewS3Config(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     S3Config
+		wantErr bool
+	}{
+		{name: "valid", cfg: S3Config{Endpoint: "http://minio:9000", Bucket: "b", AccessKey: "a", SecretKey: "s"}},
+		{name: "no endpoint", cfg: S3Config{Bucket: "b", AccessKey: "a", SecretKey: "s"}, wantErr: true},
+		{name: "no host", cfg: S3Config{Endpoint: "minio", Bucket: "b", AccessKey: "a", SecretKey: "s"}, wantErr: true},
+		{name: "no bucket", cfg: S3Config{Endpoint: "http://minio:9000", AccessKey: "a", SecretKey: "s"}, wantErr: true},
+		{name: "no credentials", cfg: S3Config{Endpoint: "http://minio:9000", Bucket: "b"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage, err := NewS3(&tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewS3() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && storage.cfg.Region != defaultS3Region {
+				t.Errorf("region = %q, want %q", storage.cfg.Region, defaultS3Region)
+			}
+		})
+	}
+}
+
+// TestSigningKey сверяет вывод ключа подписи с примером из документации AWS Signature Version 4
+func TestSigningKey(t *testing.T) {
+	key := hmacSHA256([]byte("AWS4wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"), "20150830")
+	key = hmacSHA256(key, "us-east-1")
+	key = hmacSHA256(key, "iam")
+	key = hmacSHA256(key, "aws4_request")
+	want := "c4afb1cc5771d871763a393e44b703571b55cc28424d1a5e86da6ed3c154a4b9"
+	if got := hex.EncodeToString(key); got != want {
+		t.Errorf("signing key = %s, want %s", got, want)
+	}
+}
+
+func TestSignIsDeterministic(t *testing.T) {
+	storage := &S3{cfg: S3Config{Region: "us-east-1", AccessKey: "a", SecretKey: "s"}}
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	sign := func(path string) string {
+		req := httptest.NewRequest(http.MethodGet, "http://minio:9000"+path, nil)
+		storage.sign(req, emptyPayloadHash, now)
+		return req.Header.Get("Authorization")
+	}
+	if sign("/b/key") != sign("/b/key") {
+		t.Error("same request is signed differently")
+	}
+	if sign("/b/key") == sign("/b/other") {
+		t.Error("different requests have the same signature")
+	}
+}
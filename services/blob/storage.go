@@ -0,0 +1,63 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// Storage
+// Pluggable storage of file contents addressed by key. Metadata of the files
+// is kept in PSQL, storage only holds bytes.
+type Storage interface {
+	Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+	// Get возвращает содержимое файла, его нужно закрыть после чтения
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Хранилища, которые можно выбрать через BLOB_STORAGE
+const (
+	StorageLocal = "local"
+	StorageS3    = "s3"
+)
+
+const defaultLocalDir = "data/attachments"
+
+var (
+	ErrUnknownStorage = errors.New("BLOB_STORAGE must be one of: local, s3")
+	ErrNotFound       = errors.New("blob not found")
+)
+
+// New
+// Creates storage selected by BLOB_STORAGE, local filesystem is used by default.
+func New(logger *zap.Logger) (Storage, error) {
+	storageName := viper.GetString("BLOB_STORAGE")
+	if storageName == "" {
+		storageName = StorageLocal
+	}
+	switch storageName {
+	case StorageLocal:
+		dir := viper.GetString("BLOB_LOCAL_DIR")
+		if dir == "" {
+			dir = defaultLocalDir
+		}
+		logger.Info("local blob storage is used", zap.String("dir", dir))
+		return NewLocal(dir)
+	case StorageS3:
+		cfg := &S3Config{
+			Endpoint:  viper.GetString("S3_ENDPOINT"),
+			Region:    viper.GetString("S3_REGION"),
+			Bucket:    viper.GetString("S3_BUCKET"),
+			AccessKey: viper.GetString("S3_ACCESS_KEY"),
+			SecretKey: viper.GetString("S3_SECRET_KEY"),
+		}
+		logger.Info("S3 blob storage is used", zap.String("endpoint", cfg.Endpoint), zap.String("bucket", cfg.Bucket))
+		return NewS3(cfg)
+	default:
+		return nil, ErrUnknownStorage
+	}
+}
@@ -0,0 +1,165 @@
+package postgres
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// QueryInfo
+// Describes the query for observers. Method is the name of the repo method that has run the query.
+type QueryInfo struct {
+	Repo   string
+	Method string
+	SQL    string
+}
+
+// QueryObserver вызывается перед выполнением запроса, возвращенная функция - после его завершения.
+// Для Query запрос считается завершенным после rows.Close, для QueryRow - после Scan.
+type QueryObserver func(ctx context.Context, query QueryInfo) (context.Context, func(err error))
+
+// Instrument
+// Wraps repo client so every query, including queries inside transactions, is passed to observers.
+func Instrument(client Client, repo string, observers ...QueryObserver) Client {
+	return &instrumentedClient{Client: client, repo: repo, observers: observers}
+}
+
+type instrumentedClient struct {
+	Client
+	repo      string
+	observers []QueryObserver
+}
+
+func (c *instrumentedClient) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	ctx, done := observe(ctx, c.observers, QueryInfo{Repo: c.repo, Method: callerMethod(), SQL: sql})
+	tag, err := c.Client.Exec(ctx, sql, arguments...)
+	done(err)
+	return tag, err
+}
+
+func (c *instrumentedClient) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	ctx, done := observe(ctx, c.observers, QueryInfo{Repo: c.repo, Method: callerMethod(), SQL: sql})
+	rows, err := c.Client.Query(ctx, sql, args...)
+	if err != nil {
+		done(err)
+		return nil, err
+	}
+	return &instrumentedRows{Rows: rows, done: done}, nil
+}
+
+func (c *instrumentedClient) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	ctx, done := observe(ctx, c.observers, QueryInfo{Repo: c.repo, Method: callerMethod(), SQL: sql})
+	return &instrumentedRow{Row: c.Client.QueryRow(ctx, sql, args...), done: done}
+}
+
+func (c *instrumentedClient) Begin(ctx context.Context) (pgx.Tx, error) {
+	tx, err := c.Client.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedTx{Tx: tx, repo: c.repo, observers: c.observers}, nil
+}
+
+type instrumentedTx struct {
+	pgx.Tx
+	repo      string
+	observers []QueryObserver
+}
+
+func (tx *instrumentedTx) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	ctx, done := observe(ctx, tx.observers, QueryInfo{Repo: tx.repo, Method: callerMethod(), SQL: sql})
+	tag, err := tx.Tx.Exec(ctx, sql, arguments...)
+	done(err)
+	return tag, err
+}
+
+func (tx *instrumentedTx) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	ctx, done := observe(ctx, tx.observers, QueryInfo{Repo: tx.repo, Method: callerMethod(), SQL: sql})
+	rows, err := tx.Tx.Query(ctx, sql, args...)
+	if err != nil {
+		done(err)
+		return nil, err
+	}
+	return &instrumentedRows{Rows: rows, done: done}, nil
+}
+
+func (tx *instrumentedTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	ctx, done := observe(ctx, tx.observers, QueryInfo{Repo: tx.repo, Method: callerMethod(), SQL: sql})
+	return &instrumentedRow{Row: tx.Tx.QueryRow(ctx, sql, args...), done: done}
+}
+
+type instrumentedRow struct {
+	pgx.Row
+	done func(err error)
+}
+
+func (row *instrumentedRow) Scan(dest ...any) error {
+	err := row.Row.Scan(dest...)
+	row.done(err)
+	return err
+}
+
+type instrumentedRows struct {
+	pgx.Rows
+	done   func(err error)
+	closed bool
+}
+
+func (rows *instrumentedRows) Close() {
+	rows.Rows.Close()
+	if !rows.closed {
+		rows.closed = true
+		rows.done(rows.Rows.Err())
+	}
+}
+
+func observe(ctx context.Context, observers []QueryObserver, query QueryInfo) (context.Context, func(err error)) {
+	dones := make([]func(err error), 0, len(observers))
+	for _, observer := range observers {
+		var done func(err error)
+		ctx, done = observer(ctx, query)
+		dones = append(dones, done)
+	}
+	return ctx, func(err error) {
+		for i := len(dones) - 1; i >= 0; i-- {
+			dones[i](err)
+		}
+	}
+}
+
+var methodNames sync.Map
+
+// callerMethod возвращает имя метода репозитория, вызвавшего Exec/Query/QueryRow
+func callerMethod() string {
+	pc, _, _, ok := runtime.Caller(2)
+	if !ok {
+		return "unknown"
+	}
+	if name, ok := methodNames.Load(pc); ok {
+		return name.(string)
+	}
+	name := "unknown"
+	if fn := runtime.FuncForPC(pc); fn != nil {
+		name = methodName(fn.Name())
+	}
+	methodNames.Store(pc, name)
+	return name
+}
+
+// methodName
+// "tender-workspace/internal/repo/bids.(*RepoLayer).SubmitDecision.func1" -> "SubmitDecision"
+func methodName(funcName string) string {
+	funcName = funcName[strings.LastIndex(funcName, "/")+1:]
+	parts := strings.Split(funcName, ".")
+	for i := len(parts) - 1; i > 0; i-- {
+		if strings.HasPrefix(parts[i], "func") || strings.HasPrefix(parts[i], "gowrap") {
+			continue
+		}
+		return parts[i]
+	}
+	return funcName
+}
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS feedback;
+DROP TABLE IF EXISTS bids;
+DROP TYPE IF EXISTS bid_status;
+DROP TYPE IF EXISTS creator_type;
+DROP TABLE IF EXISTS tender;
+DROP TYPE IF EXISTS tender_status;
+DROP TYPE IF EXISTS tender_type;
+DROP TABLE IF EXISTS organization_responsible;
+DROP TABLE IF EXISTS organization;
+DROP TYPE IF EXISTS organization_type;
+DROP TABLE IF EXISTS employee;
@@ -85,4 +85,4 @@ CREATE TABLE feedback (
     id SERIAL PRIMARY KEY,
     bid_id INT REFERENCES bids(id) ON DELETE CASCADE NOT NULL,
     text TEXT NOT NULL
-);
\ No newline at end of file
+);
@@ -0,0 +1,18 @@
+-- каждая версия тендера сохраняется для отката
+-- IF NOT EXISTS: в базах, созданных ddl.sql из initdb, таблица могла уже быть
+CREATE TABLE IF NOT EXISTS tender_history (
+    id SERIAL PRIMARY KEY,
+    tender_id INT REFERENCES tender(id) ON DELETE CASCADE NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL,
+    type tender_type NOT NULL,
+    status tender_status NOT NULL,
+    version INT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (tender_id, version)
+);
+
+-- текущие версии уже существующих тендеров попадают в историю
+INSERT INTO tender_history (tender_id, name, description, type, status, version, created_at)
+SELECT id, name, description, type, status, version, updated_at FROM tender
+ON CONFLICT (tender_id, version) DO NOTHING;
@@ -0,0 +1,17 @@
+-- каждая версия предложения сохраняется для отката и сравнения
+-- IF NOT EXISTS: в базах, созданных ddl.sql из initdb, таблица могла уже быть
+CREATE TABLE IF NOT EXISTS bids_history (
+    id SERIAL PRIMARY KEY,
+    bid_id INT REFERENCES bids(id) ON DELETE CASCADE NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL,
+    status bid_status NOT NULL,
+    version INT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (bid_id, version)
+);
+
+-- текущие версии уже существующих предложений попадают в историю
+INSERT INTO bids_history (bid_id, name, description, status, version, created_at)
+SELECT id, name, description, status, version, updated_at FROM bids
+ON CONFLICT (bid_id, version) DO NOTHING;
@@ -0,0 +1,3 @@
+ALTER TABLE feedback
+    DROP COLUMN IF EXISTS created_at,
+    DROP COLUMN IF EXISTS author_id;
@@ -0,0 +1,3 @@
+ALTER TABLE feedback
+    ADD COLUMN IF NOT EXISTS author_id INT REFERENCES employee(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP;
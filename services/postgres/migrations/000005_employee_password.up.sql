@@ -0,0 +1 @@
+ALTER TABLE employee ADD COLUMN IF NOT EXISTS password_hash TEXT;
@@ -0,0 +1,2 @@
+-- тестовые данные не создаются миграцией, откатывать нечего
+SELECT 1;
@@ -0,0 +1,4 @@
+-- Тестовые пользователи и организации перенесены в services/postgres/seed/dev.sql,
+-- который применяется только в локальном окружении (make seed-dev).
+-- Версия остается в цепочке, чтобы базы, где она уже применена, не расходились со сборкой.
+SELECT 1;
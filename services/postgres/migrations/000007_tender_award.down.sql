@@ -0,0 +1,4 @@
+ALTER TABLE tender
+    DROP COLUMN IF EXISTS awarded_at,
+    DROP COLUMN IF EXISTS executor_organization_id,
+    DROP COLUMN IF EXISTS winner_bid_id;
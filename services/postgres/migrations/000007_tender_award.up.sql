@@ -0,0 +1,5 @@
+-- победившее предложение и организация-исполнитель тендера
+ALTER TABLE tender
+    ADD COLUMN winner_bid_id INT REFERENCES bids(id) ON DELETE SET NULL,
+    ADD COLUMN executor_organization_id INT REFERENCES organization(id) ON DELETE SET NULL,
+    ADD COLUMN awarded_at TIMESTAMP;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS bid_decisions;
+DROP TYPE IF EXISTS bid_decision;
@@ -0,0 +1,14 @@
+CREATE TYPE bid_decision AS ENUM (
+    'Approved',
+    'Rejected'
+);
+
+-- решение каждого ответственного по предложению, итоговый статус определяется кворумом
+CREATE TABLE bid_decisions (
+    id SERIAL PRIMARY KEY,
+    bid_id INT REFERENCES bids(id) ON DELETE CASCADE NOT NULL,
+    user_id INT REFERENCES employee(id) ON DELETE CASCADE NOT NULL,
+    decision bid_decision NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (bid_id, user_id)
+);
@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS organization_created_at_id_idx;
+DROP INDEX IF EXISTS organization_name_id_idx;
+
+DROP INDEX IF EXISTS bids_version_id_idx;
+DROP INDEX IF EXISTS bids_created_at_id_idx;
+DROP INDEX IF EXISTS bids_name_id_idx;
+
+DROP INDEX IF EXISTS tender_version_id_idx;
+DROP INDEX IF EXISTS tender_created_at_id_idx;
+DROP INDEX IF EXISTS tender_name_id_idx;
+
+ALTER TABLE organization ALTER COLUMN created_at DROP NOT NULL;
+ALTER TABLE bids ALTER COLUMN created_at DROP NOT NULL;
+ALTER TABLE tender ALTER COLUMN created_at DROP NOT NULL;
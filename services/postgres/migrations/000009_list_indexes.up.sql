@@ -0,0 +1,19 @@
+-- keyset пагинация не работает со строками без created_at
+UPDATE tender SET created_at = CURRENT_TIMESTAMP WHERE created_at IS NULL;
+UPDATE bids SET created_at = CURRENT_TIMESTAMP WHERE created_at IS NULL;
+UPDATE organization SET created_at = CURRENT_TIMESTAMP WHERE created_at IS NULL;
+ALTER TABLE tender ALTER COLUMN created_at SET NOT NULL;
+ALTER TABLE bids ALTER COLUMN created_at SET NOT NULL;
+ALTER TABLE organization ALTER COLUMN created_at SET NOT NULL;
+
+-- индексы под keyset пагинацию списков: (поле сортировки, id)
+CREATE INDEX IF NOT EXISTS tender_name_id_idx ON tender (name, id);
+CREATE INDEX IF NOT EXISTS tender_created_at_id_idx ON tender (created_at, id);
+CREATE INDEX IF NOT EXISTS tender_version_id_idx ON tender (version, id);
+
+CREATE INDEX IF NOT EXISTS bids_name_id_idx ON bids (name, id);
+CREATE INDEX IF NOT EXISTS bids_created_at_id_idx ON bids (created_at, id);
+CREATE INDEX IF NOT EXISTS bids_version_id_idx ON bids (version, id);
+
+CREATE INDEX IF NOT EXISTS organization_name_id_idx ON organization (name, id);
+CREATE INDEX IF NOT EXISTS organization_created_at_id_idx ON organization (created_at, id);
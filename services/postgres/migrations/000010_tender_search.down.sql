@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS tender_organization_id_idx;
+DROP INDEX IF EXISTS tender_search_vector_idx;
+ALTER TABLE tender DROP COLUMN IF EXISTS search_vector;
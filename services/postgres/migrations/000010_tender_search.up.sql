@@ -0,0 +1,10 @@
+-- полнотекстовый поиск по названию (вес A) и описанию (вес B) в русской и английской конфигурации
+ALTER TABLE tender ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('russian', coalesce(description, '')), 'B') ||
+    setweight(to_tsvector('english', coalesce(description, '')), 'B')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS tender_search_vector_idx ON tender USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS tender_organization_id_idx ON tender (organization_id);
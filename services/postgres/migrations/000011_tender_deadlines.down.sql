@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS tender_overdue_idx;
+ALTER TABLE tender
+    DROP CONSTRAINT IF EXISTS tender_closed_reason,
+    DROP CONSTRAINT IF EXISTS tender_deadlines_order,
+    DROP COLUMN IF EXISTS closed_reason,
+    DROP COLUMN IF EXISTS decision_deadline,
+    DROP COLUMN IF EXISTS submission_deadline;
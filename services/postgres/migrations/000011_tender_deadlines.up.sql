@@ -0,0 +1,17 @@
+-- сроки приема и рассмотрения предложений, хранятся с часовым поясом клиента
+ALTER TABLE tender
+    ADD COLUMN submission_deadline TIMESTAMPTZ,
+    ADD COLUMN decision_deadline TIMESTAMPTZ,
+    ADD COLUMN closed_reason TEXT,
+    ADD CONSTRAINT tender_deadlines_order CHECK (decision_deadline >= submission_deadline),
+    ADD CONSTRAINT tender_closed_reason CHECK (
+        closed_reason IN ('Manual', 'Awarded', 'SubmissionDeadline', 'DecisionDeadline')
+    );
+
+-- закрытые до появления причин тендеры считаем закрытыми вручную или по выбору победителя
+UPDATE tender SET closed_reason = CASE WHEN winner_bid_id IS NULL THEN 'Manual' ELSE 'Awarded' END
+WHERE status = 'Closed';
+
+-- планировщик выбирает только опубликованные тендеры с истекшим сроком
+CREATE INDEX IF NOT EXISTS tender_overdue_idx ON tender (COALESCE(decision_deadline, submission_deadline))
+WHERE status = 'Published';
@@ -0,0 +1,16 @@
+DROP INDEX IF EXISTS bids_tender_total_id_idx;
+DROP TABLE IF EXISTS bid_items;
+
+ALTER TABLE bids_history
+    DROP COLUMN IF EXISTS offer_version,
+    DROP COLUMN IF EXISTS total,
+    DROP COLUMN IF EXISTS total_vat,
+    DROP COLUMN IF EXISTS total_net,
+    DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE bids
+    DROP COLUMN IF EXISTS offer_version,
+    DROP COLUMN IF EXISTS total,
+    DROP COLUMN IF EXISTS total_vat,
+    DROP COLUMN IF EXISTS total_net,
+    DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,34 @@
+-- ценовое предложение: итоги хранятся в предложении для сортировки,
+-- позиции неизменяемы и относятся к версии предложения, в которой были заданы
+ALTER TABLE bids
+    ADD COLUMN currency CHAR(3),
+    ADD COLUMN total_net NUMERIC(18, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN total_vat NUMERIC(18, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN total NUMERIC(18, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN offer_version INT;
+
+ALTER TABLE bids_history
+    ADD COLUMN currency CHAR(3),
+    ADD COLUMN total_net NUMERIC(18, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN total_vat NUMERIC(18, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN total NUMERIC(18, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN offer_version INT;
+
+CREATE TABLE bid_items (
+    id SERIAL PRIMARY KEY,
+    bid_id INT REFERENCES bids(id) ON DELETE CASCADE NOT NULL,
+    offer_version INT NOT NULL,
+    position INT NOT NULL,
+    name TEXT NOT NULL,
+    quantity NUMERIC(18, 3) NOT NULL CHECK (quantity > 0),
+    unit TEXT NOT NULL,
+    unit_price NUMERIC(18, 2) NOT NULL CHECK (unit_price >= 0),
+    vat_rate NUMERIC(5, 2) NOT NULL CHECK (vat_rate >= 0 AND vat_rate <= 100),
+    currency CHAR(3) NOT NULL,
+    net_amount NUMERIC(18, 2) NOT NULL,
+    vat_amount NUMERIC(18, 2) NOT NULL,
+    amount NUMERIC(18, 2) NOT NULL,
+    UNIQUE (bid_id, offer_version, position)
+);
+
+CREATE INDEX IF NOT EXISTS bids_tender_total_id_idx ON bids (tender_id, total, id);
@@ -0,0 +1,10 @@
+ALTER TABLE bids
+    DROP COLUMN IF EXISTS over_budget;
+
+ALTER TABLE tender
+    DROP CONSTRAINT IF EXISTS tender_budget_policy,
+    DROP CONSTRAINT IF EXISTS tender_budget,
+    DROP COLUMN IF EXISTS budget_policy,
+    DROP COLUMN IF EXISTS budget_hidden,
+    DROP COLUMN IF EXISTS budget_currency,
+    DROP COLUMN IF EXISTS budget;
@@ -0,0 +1,13 @@
+-- бюджет тендера: потолок цены в валюте, скрываемый от участников,
+-- и политика для предложений дороже потолка
+ALTER TABLE tender
+    ADD COLUMN budget NUMERIC(18, 2),
+    ADD COLUMN budget_currency CHAR(3),
+    ADD COLUMN budget_hidden BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN budget_policy TEXT NOT NULL DEFAULT 'Reject',
+    ADD CONSTRAINT tender_budget CHECK (budget > 0 AND budget_currency IS NOT NULL OR budget IS NULL),
+    ADD CONSTRAINT tender_budget_policy CHECK (budget_policy IN ('Reject', 'Flag'));
+
+-- предложения дороже потолка при политике Flag принимаются с отметкой
+ALTER TABLE bids
+    ADD COLUMN over_budget BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS bid_scores;
+
+ALTER TABLE tender
+    DROP CONSTRAINT IF EXISTS tender_score_aggregation,
+    DROP COLUMN IF EXISTS score_aggregation;
+
+DROP TABLE IF EXISTS tender_criteria;
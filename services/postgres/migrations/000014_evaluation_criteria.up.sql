@@ -0,0 +1,24 @@
+-- критерии оценки задаются при создании тендера, веса нормируются при подсчете итога
+CREATE TABLE tender_criteria (
+    id SERIAL PRIMARY KEY,
+    tender_id INT REFERENCES tender(id) ON DELETE CASCADE NOT NULL,
+    position INT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    weight NUMERIC(5, 2) NOT NULL CHECK (weight > 0),
+    UNIQUE (tender_id, position),
+    UNIQUE (tender_id, name)
+);
+
+ALTER TABLE tender
+    ADD COLUMN score_aggregation TEXT NOT NULL DEFAULT 'Mean',
+    ADD CONSTRAINT tender_score_aggregation CHECK (score_aggregation IN ('Mean', 'Median', 'TrimmedMean'));
+
+-- каждый ответственный ставит предложению одну оценку по каждому критерию
+CREATE TABLE bid_scores (
+    bid_id INT REFERENCES bids(id) ON DELETE CASCADE NOT NULL,
+    criterion_id INT REFERENCES tender_criteria(id) ON DELETE CASCADE NOT NULL,
+    user_id INT REFERENCES employee(id) ON DELETE CASCADE NOT NULL,
+    score INT NOT NULL CHECK (score >= 0 AND score <= 10),
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (bid_id, criterion_id, user_id)
+);
@@ -0,0 +1,7 @@
+ALTER TABLE bids
+    DROP COLUMN IF EXISTS revealed_at,
+    DROP COLUMN IF EXISTS commitment;
+
+ALTER TABLE tender
+    DROP CONSTRAINT IF EXISTS tender_sealed_deadline,
+    DROP COLUMN IF EXISTS sealed;
@@ -0,0 +1,9 @@
+-- в закрытом тендере участники до срока подачи присылают только хэш предложения,
+-- содержимое раскрывается после срока и сверяется с хэшем
+ALTER TABLE tender
+    ADD COLUMN sealed BOOLEAN NOT NULL DEFAULT false,
+    ADD CONSTRAINT tender_sealed_deadline CHECK (NOT sealed OR submission_deadline IS NOT NULL);
+
+ALTER TABLE bids
+    ADD COLUMN commitment CHAR(64),
+    ADD COLUMN revealed_at TIMESTAMPTZ;
@@ -0,0 +1,10 @@
+UPDATE tender SET closed_reason = 'Manual' WHERE closed_reason = 'AuctionEnded';
+
+ALTER TABLE tender
+    DROP CONSTRAINT IF EXISTS tender_closed_reason,
+    ADD CONSTRAINT tender_closed_reason CHECK (
+        closed_reason IN ('Manual', 'Awarded', 'SubmissionDeadline', 'DecisionDeadline')
+    );
+
+DROP TABLE IF EXISTS auction_offers;
+DROP TABLE IF EXISTS tender_auctions;
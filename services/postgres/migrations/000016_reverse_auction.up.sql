@@ -0,0 +1,36 @@
+-- в обратном аукционе участники в течение окна торгов снижают цену своих опубликованных предложений,
+-- ставка в последние секунды продлевает окончание торгов
+CREATE TABLE tender_auctions (
+    tender_id INT PRIMARY KEY REFERENCES tender(id) ON DELETE CASCADE,
+    currency CHAR(3) NOT NULL,
+    start_price NUMERIC(18, 2) NOT NULL CHECK (start_price > 0),
+    min_decrement NUMERIC(18, 2) NOT NULL CHECK (min_decrement > 0),
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    extension_window INT NOT NULL DEFAULT 0 CHECK (extension_window >= 0), -- секунды
+    extension INT NOT NULL DEFAULT 0 CHECK (extension >= 0),               -- секунды
+    closed_at TIMESTAMPTZ,
+    winner_bid_id INT REFERENCES bids(id),
+    CONSTRAINT tender_auctions_window CHECK (ends_at > starts_at)
+);
+
+-- планировщик выбирает только незакрытые аукционы с истекшим окном
+CREATE INDEX IF NOT EXISTS tender_auctions_ended_idx ON tender_auctions (ends_at)
+WHERE closed_at IS NULL;
+
+CREATE TABLE auction_offers (
+    id SERIAL PRIMARY KEY,
+    tender_id INT REFERENCES tender_auctions(tender_id) ON DELETE CASCADE NOT NULL,
+    bid_id INT REFERENCES bids(id) ON DELETE CASCADE NOT NULL,
+    price NUMERIC(18, 2) NOT NULL CHECK (price > 0),
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS auction_offers_price_idx ON auction_offers (tender_id, price, id);
+
+-- аукцион без допустимых ставок закрывает тендер без победителя
+ALTER TABLE tender
+    DROP CONSTRAINT IF EXISTS tender_closed_reason,
+    ADD CONSTRAINT tender_closed_reason CHECK (
+        closed_reason IN ('Manual', 'Awarded', 'SubmissionDeadline', 'DecisionDeadline', 'AuctionEnded')
+    );
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS bid_lot_decisions;
+DROP TABLE IF EXISTS bid_lots;
+DROP TYPE IF EXISTS bid_lot_status;
+DROP TABLE IF EXISTS tender_lots;
@@ -0,0 +1,44 @@
+-- тендер может делиться на лоты со своим описанием и бюджетом,
+-- тогда предложения подаются на лоты, а победитель выбирается по каждому лоту
+CREATE TABLE tender_lots (
+    id SERIAL PRIMARY KEY,
+    tender_id INT REFERENCES tender(id) ON DELETE CASCADE NOT NULL,
+    position INT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(500) NOT NULL,
+    budget NUMERIC(18, 2) CHECK (budget > 0),
+    budget_currency CHAR(3),
+    winner_bid_id INT REFERENCES bids(id) ON DELETE SET NULL,
+    executor_organization_id INT REFERENCES organization(id) ON DELETE SET NULL,
+    awarded_at TIMESTAMP,
+    UNIQUE (tender_id, position),
+    UNIQUE (tender_id, name),
+    CONSTRAINT tender_lots_budget CHECK ((budget IS NULL) = (budget_currency IS NULL))
+);
+
+CREATE TYPE bid_lot_status AS ENUM (
+    'Pending',
+    'Awarded',
+    'Rejected'
+);
+
+-- лоты, на которые подано предложение, и решение по каждому из них
+CREATE TABLE bid_lots (
+    bid_id INT REFERENCES bids(id) ON DELETE CASCADE NOT NULL,
+    lot_id INT REFERENCES tender_lots(id) ON DELETE CASCADE NOT NULL,
+    status bid_lot_status NOT NULL DEFAULT 'Pending',
+    PRIMARY KEY (bid_id, lot_id)
+);
+
+CREATE INDEX IF NOT EXISTS bid_lots_lot_idx ON bid_lots (lot_id);
+
+-- решение каждого ответственного по предложению в рамках лота
+CREATE TABLE bid_lot_decisions (
+    bid_id INT NOT NULL,
+    lot_id INT NOT NULL,
+    user_id INT REFERENCES employee(id) ON DELETE CASCADE NOT NULL,
+    decision bid_decision NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (bid_id, lot_id, user_id),
+    FOREIGN KEY (bid_id, lot_id) REFERENCES bid_lots (bid_id, lot_id) ON DELETE CASCADE
+);
@@ -0,0 +1,19 @@
+-- вопросы участников по тендеру и ответы ответственных за него,
+-- опубликованный ответ виден всем, кому виден тендер
+CREATE TABLE tender_questions (
+    id SERIAL PRIMARY KEY,
+    tender_id INT REFERENCES tender(id) ON DELETE CASCADE NOT NULL,
+    author_id INT REFERENCES employee(id) ON DELETE CASCADE NOT NULL,
+    anonymous BOOLEAN NOT NULL DEFAULT FALSE,
+    text VARCHAR(1000) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    answer VARCHAR(1000),
+    answered_by INT REFERENCES employee(id) ON DELETE SET NULL,
+    answered_at TIMESTAMP,
+    published BOOLEAN NOT NULL DEFAULT FALSE,
+    -- версия тендера, измененного вместе с ответом
+    tender_version INT,
+    CONSTRAINT tender_questions_published CHECK (NOT published OR answer IS NOT NULL)
+);
+
+CREATE INDEX IF NOT EXISTS tender_questions_tender_id_created_at_idx ON tender_questions (tender_id, created_at, id);
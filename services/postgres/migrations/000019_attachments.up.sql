@@ -0,0 +1,17 @@
+-- файлы тендеров и предложений, содержимое лежит в blob-хранилище по storage_key
+CREATE TABLE attachments (
+    id SERIAL PRIMARY KEY,
+    tender_id INT REFERENCES tender(id) ON DELETE CASCADE,
+    bid_id INT REFERENCES bids(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL CHECK (size > 0),
+    sha256 CHAR(64) NOT NULL,
+    storage_key VARCHAR(200) UNIQUE NOT NULL,
+    creator_id INT REFERENCES employee(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL,
+    CONSTRAINT attachments_owner CHECK ((tender_id IS NULL) <> (bid_id IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS attachments_tender_id_idx ON attachments (tender_id) WHERE tender_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS attachments_bid_id_idx ON attachments (bid_id) WHERE bid_id IS NOT NULL;
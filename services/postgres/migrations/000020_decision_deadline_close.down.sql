@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS tender_overdue_idx;
+CREATE INDEX IF NOT EXISTS tender_overdue_idx ON tender (COALESCE(decision_deadline, submission_deadline))
+WHERE status = 'Published';
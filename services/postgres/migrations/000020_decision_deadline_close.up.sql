@@ -0,0 +1,5 @@
+-- тендеры закрываются автоматически только по сроку решения,
+-- после срока подачи по собранным предложениям еще принимаются решения
+DROP INDEX IF EXISTS tender_overdue_idx;
+CREATE INDEX IF NOT EXISTS tender_overdue_idx ON tender (decision_deadline)
+WHERE status = 'Published' AND decision_deadline IS NOT NULL;
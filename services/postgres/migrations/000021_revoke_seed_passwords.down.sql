@@ -0,0 +1,2 @@
+-- сброшенные пароли не восстанавливаются
+SELECT 1;
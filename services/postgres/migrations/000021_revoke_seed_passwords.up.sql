@@ -0,0 +1,4 @@
+-- Раньше миграция 000006 создавала тестовых пользователей с общеизвестным паролем во всех окружениях.
+-- Пароль сбрасывается, такие пользователи больше не могут войти, их данные сохраняются.
+UPDATE employee SET password_hash = NULL
+WHERE password_hash = '$2a$10$0rLGY1QNcC.OZWhvH6XVP.iSASRR.0l4ieFcB5J0T/ZA51/UksGr2';
@@ -0,0 +1,4 @@
+-- срок решения, выставленный старым закрытым тендерам, остается
+ALTER TABLE tender
+    DROP CONSTRAINT IF EXISTS tender_sealed_deadline,
+    ADD CONSTRAINT tender_sealed_deadline CHECK (NOT sealed OR submission_deadline IS NOT NULL);
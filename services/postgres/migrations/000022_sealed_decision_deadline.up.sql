@@ -0,0 +1,10 @@
+-- закрытый тендер раскрывается после срока подачи и ждет решения до своего срока, поэтому нужны оба срока.
+-- Тендерам, созданным до этого требования, срок решения ставится равным сроку подачи:
+-- до перехода на срок решения они и закрывались по сроку подачи
+UPDATE tender SET decision_deadline = submission_deadline
+WHERE sealed AND decision_deadline IS NULL;
+
+ALTER TABLE tender
+    DROP CONSTRAINT IF EXISTS tender_sealed_deadline,
+    ADD CONSTRAINT tender_sealed_deadline
+        CHECK (NOT sealed OR (submission_deadline IS NOT NULL AND decision_deadline IS NOT NULL));
@@ -0,0 +1,3 @@
+-- выставленный срок решения остается
+ALTER TABLE tender
+    DROP CONSTRAINT IF EXISTS tender_submission_decision;
@@ -0,0 +1,8 @@
+-- тендер закрывается автоматически только по сроку решения, поэтому срок подачи без него не задается.
+-- Тендерам, созданным до этого требования, срок решения ставится равным сроку подачи:
+-- до перехода на срок решения они и закрывались по сроку подачи
+UPDATE tender SET decision_deadline = submission_deadline
+WHERE submission_deadline IS NOT NULL AND decision_deadline IS NULL;
+
+ALTER TABLE tender
+    ADD CONSTRAINT tender_submission_decision CHECK (submission_deadline IS NULL OR decision_deadline IS NOT NULL);
@@ -0,0 +1,335 @@
+package migrations
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// Файлы миграций именуются как <версия>_<название>.up.sql / <версия>_<название>.down.sql
+//
+//go:embed *.sql
+var files embed.FS
+
+// lockKey ключ advisory lock, чтобы несколько реплик не применяли миграции одновременно
+const lockKey = 7253471
+
+// baselineVersion миграция, повторяющая ddl.sql, которым раньше создавалась схема через initdb
+const baselineVersion = 1
+
+var (
+	sqlRowCreateMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INT PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`
+	// схема без таблицы миграций создана прежним ddl.sql
+	sqlRowCheckBaseline        = `SELECT to_regclass('schema_migrations') IS NOT NULL, to_regclass('employee') IS NOT NULL`
+	sqlRowHasMigrationsTable   = `SELECT to_regclass('schema_migrations') IS NOT NULL`
+	sqlRowGetAppliedMigrations = `SELECT version, applied_at FROM schema_migrations ORDER BY version`
+	sqlRowInsertMigration      = `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`
+	sqlRowDeleteMigration      = `DELETE FROM schema_migrations WHERE version=$1`
+	sqlRowLock                 = `SELECT pg_advisory_lock($1)`
+	sqlRowUnlock               = `SELECT pg_advisory_unlock($1)`
+)
+
+var (
+	ErrBadMigrationName = errors.New("migration file name must look like <version>_<name>.<up|down>.sql")
+	ErrNoDownMigration  = errors.New("migration has no down script")
+	ErrUnknownMigration = errors.New("database has migration that is unknown to this build")
+)
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+type Migrator struct {
+	Pool       *pgxpool.Pool
+	Logger     *zap.Logger
+	migrations []Migration
+}
+
+func NewMigrator(pool *pgxpool.Pool, logger *zap.Logger) (*Migrator, error) {
+	migrations, err := load()
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{
+		Pool:       pool,
+		Logger:     logger,
+		migrations: migrations,
+	}, nil
+}
+
+// Up применяет steps еще не примененных миграций по возрастанию версии, steps <= 0 - все.
+// Возвращает количество примененных миграций.
+func (m *Migrator) Up(ctx context.Context, steps int) (int, error) {
+	var count int
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		if err := m.adoptBaseline(ctx, conn); err != nil {
+			return err
+		}
+		if _, err := conn.Exec(ctx, sqlRowCreateMigrationsTable); err != nil {
+			return err
+		}
+		applied, err := getApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if steps > 0 && count == steps {
+				break
+			}
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			err = runInTx(ctx, conn, migration.Up, sqlRowInsertMigration, migration.Version, migration.Name)
+			if err != nil {
+				return fmt.Errorf("migration %d_%s up: %w", migration.Version, migration.Name, err)
+			}
+			m.Logger.Info("migration applied", zap.Int("version", migration.Version), zap.String("name", migration.Name))
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Down откатывает steps последних примененных миграций, steps <= 0 - одну.
+// Возвращает количество откаченных миграций.
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	if steps <= 0 {
+		steps = 1
+	}
+	var count int
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := getApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0 && count < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, ErrNoDownMigration)
+			}
+			err = runInTx(ctx, conn, migration.Down, sqlRowDeleteMigration, migration.Version)
+			if err != nil {
+				return fmt.Errorf("migration %d_%s down: %w", migration.Version, migration.Name, err)
+			}
+			m.Logger.Info("migration rolled back", zap.Int("version", migration.Version), zap.String("name", migration.Name))
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Status возвращает состояние всех известных миграций по возрастанию версии.
+// Схема не меняется: без таблицы schema_migrations все миграции считаются не примененными.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	conn, err := m.Pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+	applied, err := getApplied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		appliedAt, ok := applied[migration.Version]
+		statuses = append(statuses, MigrationStatus{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			Applied:   ok,
+			AppliedAt: appliedAt,
+		})
+		delete(applied, migration.Version)
+	}
+	if len(applied) != 0 {
+		return statuses, ErrUnknownMigration
+	}
+	return statuses, nil
+}
+
+// adoptBaseline
+// Database created by the old initdb ddl.sql already has the initial schema, but no schema_migrations.
+// Initial migration is marked as applied instead of running it, following ones upgrade the data in place.
+func (m *Migrator) adoptBaseline(ctx context.Context, conn *pgxpool.Conn) error {
+	var hasMigrations, hasSchema bool
+	if err := conn.QueryRow(ctx, sqlRowCheckBaseline).Scan(&hasMigrations, &hasSchema); err != nil {
+		return err
+	}
+	if hasMigrations || !hasSchema {
+		return nil
+	}
+	for _, migration := range m.migrations {
+		if migration.Version != baselineVersion {
+			continue
+		}
+		err := runInTx(ctx, conn, sqlRowCreateMigrationsTable, sqlRowInsertMigration, migration.Version, migration.Name)
+		if err != nil {
+			return fmt.Errorf("failed to adopt existing schema: %w", err)
+		}
+		m.Logger.Info("existing schema adopted as baseline", zap.Int("version", migration.Version), zap.String("name", migration.Name))
+	}
+	return nil
+}
+
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := m.Pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+	if _, err = conn.Exec(ctx, sqlRowLock, lockKey); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := conn.Exec(context.Background(), sqlRowUnlock, lockKey); err != nil {
+			m.Logger.Warn(fmt.Sprintf("failed to release migration lock: %v", err))
+		}
+	}()
+	return fn(conn)
+}
+
+// getApplied
+// Returns applied versions with their time, only reads the database, so it's safe for probes.
+// Missing schema_migrations means nothing is applied yet.
+func getApplied(ctx context.Context, conn *pgxpool.Conn) (map[int]time.Time, error) {
+	applied := make(map[int]time.Time)
+	var hasTable bool
+	if err := conn.QueryRow(ctx, sqlRowHasMigrationsTable).Scan(&hasTable); err != nil {
+		return nil, err
+	}
+	if !hasTable {
+		return applied, nil
+	}
+	rows, err := conn.Query(ctx, sqlRowGetAppliedMigrations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+// runInTx выполняет скрипт миграции и изменение schema_migrations в одной транзакции
+func runInTx(ctx context.Context, conn *pgxpool.Conn, script, sqlRowBookkeeping string, args ...any) (err error) {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+	// без аргументов pgx использует simple protocol, поэтому скрипт может содержать несколько команд
+	if _, err = tx.Exec(ctx, script); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(ctx, sqlRowBookkeeping, args...); err != nil {
+		return err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func load() ([]Migration, error) {
+	entries, err := fs.ReadDir(files, ".")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		version, name, direction, err := parseFileName(entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+		content, err := files.ReadFile(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: name}
+			byVersion[version] = migration
+		}
+		if migration.Name != name {
+			return nil, fmt.Errorf("%s: version %d is used by %q: %w", entry.Name(), version, migration.Name, ErrBadMigrationName)
+		}
+		if direction == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script: %w", migration.Version, migration.Name, ErrBadMigrationName)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+func parseFileName(fileName string) (int, string, string, error) {
+	base, ok := strings.CutSuffix(fileName, ".sql")
+	if !ok {
+		return 0, "", "", ErrBadMigrationName
+	}
+	dot := strings.LastIndex(base, ".")
+	if dot == -1 {
+		return 0, "", "", ErrBadMigrationName
+	}
+	base, direction := base[:dot], base[dot+1:]
+	if direction != "up" && direction != "down" {
+		return 0, "", "", ErrBadMigrationName
+	}
+	rawVersion, name, ok := strings.Cut(base, "_")
+	if !ok || name == "" {
+		return 0, "", "", ErrBadMigrationName
+	}
+	version, err := strconv.Atoi(rawVersion)
+	if err != nil || version <= 0 {
+		return 0, "", "", ErrBadMigrationName
+	}
+	return version, name, direction, nil
+}
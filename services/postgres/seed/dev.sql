@@ -0,0 +1,94 @@
+-- Тестовые данные для локальной разработки, применяются после миграций: make seed-dev
+-- В цепочку миграций не входят и в production не попадают.
+-- Скрипт можно применять повторно.
+
+-- Инициализация БД пользователями/рабочими
+-- пароль всех тестовых пользователей: tender2024
+INSERT INTO employee (
+    username,
+    first_name,
+    last_name,
+    password_hash,
+    created_at,
+    updated_at
+) VALUES (
+    'tussan_pussan',
+    'Ivan',
+    'Lobanov',
+    '$2a$10$0rLGY1QNcC.OZWhvH6XVP.iSASRR.0l4ieFcB5J0T/ZA51/UksGr2',
+    CURRENT_TIMESTAMP,
+    CURRENT_TIMESTAMP
+), (
+    'igormed',
+    'Igor',
+    'Medvedev',
+    '$2a$10$0rLGY1QNcC.OZWhvH6XVP.iSASRR.0l4ieFcB5J0T/ZA51/UksGr2',
+    CURRENT_TIMESTAMP,
+    CURRENT_TIMESTAMP
+), (
+    'futplayer',
+    'Miroslav',
+    'Kalinin',
+    '$2a$10$0rLGY1QNcC.OZWhvH6XVP.iSASRR.0l4ieFcB5J0T/ZA51/UksGr2',
+    CURRENT_TIMESTAMP,
+    CURRENT_TIMESTAMP
+), (
+    'niceday55',
+    'George',
+    'Afanasyev',
+    '$2a$10$0rLGY1QNcC.OZWhvH6XVP.iSASRR.0l4ieFcB5J0T/ZA51/UksGr2',
+    CURRENT_TIMESTAMP,
+    CURRENT_TIMESTAMP
+)
+-- пароль возвращается, если его сбросила миграция 000021
+ON CONFLICT (username) DO UPDATE SET password_hash = EXCLUDED.password_hash;
+
+-- Инициализация БД организациями
+INSERT INTO organization (
+    name,
+    description,
+    type,
+    created_at,
+    updated_at
+)
+SELECT seed.name, seed.description, seed.type::organization_type, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+FROM (VALUES (
+    'T-Bank',
+    'T-Bank is a modern financial institution that focuses on providing innovative banking solutions
+    to meet the diverse needs of its customers. Established with the vision of transforming
+    the banking experience, T-Bank leverages technology to offer a wide range of services, including
+    personal and business banking, loans, investment options, and digital banking solutions.',
+    'JSC'
+), (
+    'Tech Solutions',
+    'Tech Solutions is a leading provider of technology consulting and software development services.
+    Our mission is to help businesses leverage technology to improve efficiency and drive growth.
+    We specialize in custom software solutions, IT strategy, and digital transformation.',
+    'LLC'
+), (
+    'Green Energy Corp',
+    'Green Energy Corp is dedicated to providing sustainable energy solutions.
+    We focus on renewable energy sources such as solar and wind power, aiming to reduce carbon footprints
+    and promote environmental sustainability. Our goal is to make clean energy accessible to everyone.',
+    'JSC'
+)) AS seed (name, description, type)
+WHERE NOT EXISTS (SELECT 1 FROM organization o WHERE o.name = seed.name);
+
+-- Инициализация БД ответственными за организации
+INSERT INTO organization_responsible (
+    organization_id,
+    user_id
+)
+SELECT o.id, e.id
+FROM (VALUES
+    ('T-Bank', 'tussan_pussan'),
+    ('T-Bank', 'igormed'),
+    ('Tech Solutions', 'futplayer'),
+    ('Green Energy Corp', 'futplayer'),
+    ('Green Energy Corp', 'tussan_pussan')
+) AS seed (organization, username)
+JOIN organization o ON o.name = seed.organization
+JOIN employee e ON e.username = seed.username
+WHERE NOT EXISTS (
+    SELECT 1 FROM organization_responsible r WHERE r.organization_id = o.id AND r.user_id = e.id
+);
@@ -6,31 +6,121 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 )
 
-func Init(logger *zap.Logger) *pgx.Conn {
-	conn, err := pgx.Connect(context.Background(), viper.GetString("POSTGRES_CONN"))
+// Client
+// Common subset of pgxpool.Pool and pgx.Tx used by the repo layer.
+// Each call acquires connection from the pool, so the client is safe for concurrent use.
+type Client interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+var _ Client = (*pgxpool.Pool)(nil)
+
+// Init
+// Creates pool and waits until PSQL accepts connections, but not longer than POSTGRES_CONNECT_TIMEOUT.
+// Used by tools that can't work without database, the server waits with WaitForConnection.
+func Init(logger *zap.Logger) *pgxpool.Pool {
+	pool, err := New(logger)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("error while parsing PSQL pool config: %v", err))
+	}
+	timeout := viper.GetDuration("POSTGRES_CONNECT_TIMEOUT")
+	if timeout <= 0 {
+		timeout = defaultConnectTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := WaitForConnection(ctx, pool, logger); err != nil {
+		logger.Fatal(fmt.Sprintf("can't establish connection to PSQL: %v", err))
+	}
+	return pool
+}
+
+// New создает пул без подключения к PSQL, соединения открываются при первом запросе
+func New(logger *zap.Logger) (*pgxpool.Pool, error) {
+	cfg, err := newPoolConfig()
 	if err != nil {
-		logger.Fatal(fmt.Sprintf("error while connecting to PSQL: %v", err))
+		return nil, err
 	}
-	const maxConnAttempts = 3
+	pool, err := pgxpool.NewWithConfig(context.Background(), cfg)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("PSQL pool created",
+		zap.Int32("max-conns", cfg.MaxConns),
+		zap.Int32("min-conns", cfg.MinConns),
+		zap.Duration("health-check-period", cfg.HealthCheckPeriod),
+		zap.Duration("max-conn-lifetime", cfg.MaxConnLifetime),
+	)
+	return pool, nil
+}
 
-	var successConn bool
-	for i := 0; i < maxConnAttempts; i++ {
-		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-		err := conn.Ping(ctx)
+const (
+	defaultConnectTimeout = time.Minute
+	pingTimeout           = 3 * time.Second
+	initialBackoff        = 500 * time.Millisecond
+	defaultMaxBackoff     = 30 * time.Second
+)
+
+// WaitForConnection
+// Pings PSQL with exponential backoff until it answers or ctx is done.
+// Backoff is capped by POSTGRES_CONNECT_MAX_BACKOFF.
+func WaitForConnection(ctx context.Context, pool *pgxpool.Pool, logger *zap.Logger) error {
+	maxBackoff := viper.GetDuration("POSTGRES_CONNECT_MAX_BACKOFF")
+	if maxBackoff <= 0 {
+		maxBackoff = defaultMaxBackoff
+	}
+	backoff := initialBackoff
+	for attempt := 1; ; attempt++ {
+		pingCtx, cancel := context.WithTimeout(ctx, pingTimeout)
+		err := pool.Ping(pingCtx)
 		cancel()
 		if err == nil {
-			successConn = true
-			break
+			logger.Info("PSQL connected successfully", zap.Int("attempt", attempt))
+			return nil
 		}
-		logger.Warn(fmt.Sprintf("error while ping to PSQL: %v", err))
+		logger.Warn(fmt.Sprintf("error while ping to PSQL: %v", err),
+			zap.Int("attempt", attempt),
+			zap.Duration("retry-in", backoff),
+		)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w, last error: %v", ctx.Err(), err)
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// newPoolConfig
+// Parameters that aren't specified keep pgxpool defaults.
+func newPoolConfig() (*pgxpool.Config, error) {
+	cfg, err := pgxpool.ParseConfig(viper.GetString("POSTGRES_CONN"))
+	if err != nil {
+		return nil, err
+	}
+	if maxConns := viper.GetInt32("POSTGRES_POOL_MAX_CONNS"); maxConns > 0 {
+		cfg.MaxConns = maxConns
+	}
+	if minConns := viper.GetInt32("POSTGRES_POOL_MIN_CONNS"); minConns > 0 {
+		cfg.MinConns = minConns
+	}
+	if period := viper.GetDuration("POSTGRES_POOL_HEALTH_CHECK_PERIOD"); period > 0 {
+		cfg.HealthCheckPeriod = period
+	}
+	if lifetime := viper.GetDuration("POSTGRES_POOL_MAX_CONN_LIFETIME"); lifetime > 0 {
+		cfg.MaxConnLifetime = lifetime
 	}
-	if !successConn {
-		logger.Fatal("can't establish connection to PSQL")
+	if idleTime := viper.GetDuration("POSTGRES_POOL_MAX_CONN_IDLE_TIME"); idleTime > 0 {
+		cfg.MaxConnIdleTime = idleTime
 	}
-	logger.Info("PSQL connected successfully")
-	return conn
+	return cfg, nil
 }